@@ -0,0 +1,9 @@
+package v1alpha1
+
+// v1alpha1 is the storage version and the hub other versions convert through.
+
+// Hub marks Breakglass as the conversion hub.
+func (*Breakglass) Hub() {}
+
+// Hub marks ClusterBreakglass as the conversion hub.
+func (*ClusterBreakglass) Hub() {}
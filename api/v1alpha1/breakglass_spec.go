@@ -18,6 +18,11 @@ type BreakglassSpec struct {
 	Policy       []Policy `json:"policy,omitempty"`
 	ClusterRoles []string `json:"clusterRoles,omitempty"`
 
+	// ClusterRoleScope binds spec.clusterRoles through RoleBindings in the selected namespaces
+	// instead of a cluster-wide ClusterRoleBinding. Only valid together with clusterRoles.
+	// +optional
+	ClusterRoleScope *NamespaceScope `json:"clusterRoleScope,omitempty"`
+
 	// Approval requirements.
 	// +optional
 	Approval *ApprovalSpec `json:"approval,omitempty"`
@@ -32,6 +37,54 @@ type BreakglassSpec struct {
 	// Optional external ticket identifier.
 	// +optional
 	TicketID string `json:"ticketID,omitempty"`
+
+	// DryRun renders the RBAC the request would create into status.plan without granting anything.
+	// The request stays Pending until dryRun is cleared.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Revocation ends access early. Setting it revokes any active access, stops future
+	// recurring activations and moves the request to Revoked. It cannot be removed once set.
+	// +optional
+	Revocation *RevocationSpec `json:"revocation,omitempty"`
+
+	// Extensions requests more time for the currently active window. Entries can only be
+	// appended while access is active and go through the approval rules again if the
+	// request required approval.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []ExtensionRequest `json:"extensions,omitempty"`
+}
+
+// ExtensionRequest asks to push the end of the active window further out.
+type ExtensionRequest struct {
+	// Name identifies the extension. BreakglassApprovals reference it via spec.extension.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Duration is added to the end of the active window.
+	Duration metav1.Duration `json:"duration"`
+
+	// Reason explains why more time is needed.
+	// +kubebuilder:validation:MinLength=1
+	Reason string `json:"reason"`
+
+	// RequestedBy is stamped by the admission webhook from the authenticated request.
+	// +optional
+	RequestedBy *UserIdentity `json:"requestedBy,omitempty"`
+}
+
+// RevocationSpec records a manual early revocation of a breakglass request.
+type RevocationSpec struct {
+	// Reason explains why access was revoked.
+	// +kubebuilder:validation:MinLength=1
+	Reason string `json:"reason"`
+
+	// RevokedBy is stamped by the admission webhook from the authenticated request.
+	// Any value supplied by the client is overwritten.
+	// +optional
+	RevokedBy *UserIdentity `json:"revokedBy,omitempty"`
 }
 
 // Policy defines RBAC rules with optional namespace scoping.
@@ -45,11 +98,39 @@ type Policy struct {
 	Rules []rbacv1.PolicyRule `json:"rules"`
 }
 
+// NamespaceScope selects the namespaces a ClusterRole is bound in.
+// The union of Namespaces and the namespaces matching Selector is used.
+type NamespaceScope struct {
+	// Namespaces lists namespaces by name.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector matches namespaces by label. It is evaluated each time access is granted.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
 // ApprovalSpec defines approval configuration for the breakglass request.
 type ApprovalSpec struct {
 	// Required indicates whether manual approval is required.
 	// +kubebuilder:default=true
 	Required bool `json:"required"`
+
+	// MinApprovals is the number of distinct approvers needed before access is granted.
+	// The requester never counts towards this number.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MinApprovals int32 `json:"minApprovals,omitempty"`
+
+	// Users that may approve or deny the request.
+	// If both Users and Groups are empty any authenticated user other than the requester may decide.
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Groups whose members may approve or deny the request.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
 }
 
 // ScheduleSpec defines the timing for breakglass activation.
@@ -58,6 +139,11 @@ type ScheduleSpec struct {
 	// +optional
 	Start metav1.Time `json:"start,omitempty"`
 
+	// Until ends the schedule. No window opens at or after it, and a window that is
+	// still active at Until is cut short.
+	// +optional
+	Until *metav1.Time `json:"until,omitempty"`
+
 	// Duration after which access is revoked. If omitted
 	// +optional
 	Duration metav1.Duration `json:"duration"`
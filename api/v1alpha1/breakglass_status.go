@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -10,6 +11,8 @@ type BreakglassCondition string
 const (
 	// NoCondition represents an unset or empty condition
 	NoCondition BreakglassCondition = ""
+	// ConditionValidating indicates the breakglass request is being checked before it can be approved
+	ConditionValidating BreakglassCondition = "Validating"
 	// ConditionPending indicates the breakglass request is pending
 	ConditionPending BreakglassCondition = "Pending"
 	// ConditionApproved indicates the breakglass request has been approved
@@ -30,6 +33,37 @@ const (
 	ConditionRecurringActive BreakglassCondition = "RecurringActive"
 )
 
+// BreakglassPhase is the lifecycle state of a breakglass request. The controller dispatches on it.
+// +kubebuilder:validation:Enum=Validating;Pending;Approved;Denied;Scheduled;Active;Expired;Revoked;Failed
+type BreakglassPhase string
+
+const (
+	// PhaseValidating indicates the request is being checked before it waits for approval
+	PhaseValidating BreakglassPhase = "Validating"
+	// PhasePending indicates the request is waiting for approval
+	PhasePending BreakglassPhase = "Pending"
+	// PhaseApproved indicates the request has been approved and is about to be scheduled
+	PhaseApproved BreakglassPhase = "Approved"
+	// PhaseDenied indicates the request was denied
+	PhaseDenied BreakglassPhase = "Denied"
+	// PhaseScheduled indicates the request is waiting for its next activation window
+	PhaseScheduled BreakglassPhase = "Scheduled"
+	// PhaseActive indicates access is currently granted
+	PhaseActive BreakglassPhase = "Active"
+	// PhaseExpired indicates the schedule has ended and access was removed
+	PhaseExpired BreakglassPhase = "Expired"
+	// PhaseRevoked indicates access was revoked early
+	PhaseRevoked BreakglassPhase = "Revoked"
+	// PhaseFailed indicates the last operation failed; the request is retried from Pending
+	PhaseFailed BreakglassPhase = "Failed"
+)
+
+// MaxPhaseHistory is the number of phase transitions kept in status.history.
+const MaxPhaseHistory = 20
+
+// MaxActivationHistory is the number of activation windows kept in status.activationHistory.
+const MaxActivationHistory = 10
+
 // BreakglassConditionReason represents the reason for a breakglass condition
 type BreakglassConditionReason string
 
@@ -74,8 +108,37 @@ const (
 	ReasonRecurringWaiting BreakglassConditionReason = "RecurringWaiting"
 	// ReasonRecurringInvalidSchedule indicates the recurring schedule is invalid
 	ReasonRecurringInvalidSchedule BreakglassConditionReason = "RecurringInvalidSchedule"
+	// ReasonRequesterLacksPermissions indicates the requester does not hold the permissions being granted
+	ReasonRequesterLacksPermissions BreakglassConditionReason = "RequesterLacksPermissions"
+	// ReasonPolicyViolation indicates no BreakglassPolicy admits the request
+	ReasonPolicyViolation BreakglassConditionReason = "PolicyViolation"
+	// ReasonProtectedAccess indicates the request targets denylisted ClusterRoles, subjects or wildcard rules
+	ReasonProtectedAccess BreakglassConditionReason = "ProtectedAccess"
+	// ReasonNamespaceRestricted indicates the request grants access outside its own namespace without an allowance
+	ReasonNamespaceRestricted BreakglassConditionReason = "NamespaceRestricted"
 	// ReasonMaxActivationsReached indicates the maximum number of activations has been reached
 	ReasonMaxActivationsReached BreakglassConditionReason = "MaxActivationsReached"
+	// ReasonScheduleEnded indicates schedule.until has passed and no further windows open
+	ReasonScheduleEnded BreakglassConditionReason = "ScheduleEnded"
+	// ReasonDryRun indicates the request only renders its RBAC plan and grants nothing
+	ReasonDryRun BreakglassConditionReason = "DryRun"
+	// ReasonTicketInvalid indicates the linked ticket does not exist or is not open, or no ticket is linked
+	ReasonTicketInvalid BreakglassConditionReason = "TicketInvalid"
+	// ReasonTicketClosed indicates access was revoked because the linked ticket was closed
+	ReasonTicketClosed BreakglassConditionReason = "TicketClosed"
+	// ReasonRBACDrift indicates access was revoked because granted RBAC objects were tampered with
+	ReasonRBACDrift BreakglassConditionReason = "RBACDrift"
+	// ReasonResourceConflict indicates an RBAC object with a generated name belongs to another request
+	ReasonResourceConflict BreakglassConditionReason = "ResourceConflict"
+	// ReasonGrantsImmutable indicates access was revoked because its subjects or roles changed while
+	// no policy allows it
+	ReasonGrantsImmutable BreakglassConditionReason = "GrantsImmutable"
+	// ReasonApprovalsUnverified indicates the request needs approval but approver identities cannot be
+	// trusted because the admission webhook is disabled
+	ReasonApprovalsUnverified BreakglassConditionReason = "ApprovalsUnverified"
+	// ReasonRequesterUnknown indicates the request needs approval but carries no requester annotation, so
+	// self-approvals cannot be told apart
+	ReasonRequesterUnknown BreakglassConditionReason = "RequesterUnknown"
 )
 
 // BreakglassStatus defines the observed state of Breakglass (set by the operator).
@@ -84,6 +147,15 @@ type BreakglassStatus struct {
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// Phase is the current lifecycle state of the request.
+	// +optional
+	Phase BreakglassPhase `json:"phase,omitempty"`
+
+	// History lists the most recent phase transitions, oldest first.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	History []PhaseTransition `json:"history,omitempty"`
+
 	GrantedAt  *metav1.Time       `json:"grantedAt,omitempty"`
 	ExpiresAt  *metav1.Time       `json:"expiresAt,omitempty"`
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -92,13 +164,203 @@ type BreakglassStatus struct {
 	// +optional
 	ApprovedBy string `json:"approvedBy,omitempty"`
 
-	// CreatedResources tracks the names of RBAC resources created by this breakglass.
+	// ApprovedAt is when the approval that completed the quorum was admitted.
+	// +optional
+	ApprovedAt *metav1.Time `json:"approvedAt,omitempty"`
+
+	// Approvals lists every approval counted towards the quorum so far.
+	// +optional
+	Approvals []ApprovalRecord `json:"approvals,omitempty"`
+
+	// DeniedBy is the username that denied the breakglass request.
+	// +optional
+	DeniedBy string `json:"deniedBy,omitempty"`
+
+	// DeniedAt is when the denying BreakglassApproval was admitted.
+	// +optional
+	DeniedAt *metav1.Time `json:"deniedAt,omitempty"`
+
+	// RevokedBy is the username that revoked the breakglass request.
+	// +optional
+	RevokedBy string `json:"revokedBy,omitempty"`
+
+	// RevokedAt is when access was revoked.
+	// +optional
+	RevokedAt *metav1.Time `json:"revokedAt,omitempty"`
+
+	// AdmittedByPolicy is the BreakglassPolicy that admitted the request, if any policies exist.
+	// +optional
+	AdmittedByPolicy string `json:"admittedByPolicy,omitempty"`
+
+	// Extensions is the audit history of decided window extensions.
+	// +optional
+	Extensions []ExtensionRecord `json:"extensions,omitempty"`
+
+	// CreatedResources tracks the names of the RBAC resources of the open activation window. It is
+	// cleared when the window is revoked; ActivationHistory keeps the resources of past windows.
 	// +optional
 	CreatedResources []string `json:"createdResources,omitempty"`
 
+	// GrantsHash identifies the subjects, clusterRoles, clusterRoleScope and policy the open window
+	// was granted from. A spec change only counts as a change of grants when it changes this hash.
+	// +optional
+	GrantsHash string `json:"grantsHash,omitempty"`
+
 	// Optional tracking for recurring requests.
 	NextActivationAt *metav1.Time `json:"nextActivationAt,omitempty"`
 	ActivationCount  int32        `json:"activationCount,omitempty"`
+
+	// ActivationHistory lists the most recent activation windows, oldest first.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	ActivationHistory []ActivationRecord `json:"activationHistory,omitempty"`
+
+	// Plan is the RBAC the request would create. It is rendered while the request waits for
+	// approval or runs as a dry run, so approvers can review concrete objects.
+	// +optional
+	Plan *RBACPlan `json:"plan,omitempty"`
+}
+
+// RBAC resource kinds rendered into status.plan.
+const (
+	KindRole               = "Role"
+	KindRoleBinding        = "RoleBinding"
+	KindClusterRole        = "ClusterRole"
+	KindClusterRoleBinding = "ClusterRoleBinding"
+)
+
+// RBACPlan lists the RBAC objects a request would create, without creating them.
+type RBACPlan struct {
+	// ObservedGeneration is the spec generation the plan was rendered from.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// RenderedAt is when the plan was rendered.
+	RenderedAt metav1.Time `json:"renderedAt"`
+
+	// Objects lists the objects in the order they would be created.
+	// +optional
+	Objects []PlannedObject `json:"objects,omitempty"`
+
+	// Warnings reports problems found while rendering, such as a referenced ClusterRole that does not exist.
+	// +optional
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// PlannedObject is a single RBAC object in a plan.
+type PlannedObject struct {
+	// Kind is Role, RoleBinding, ClusterRole or ClusterRoleBinding.
+	// +kubebuilder:validation:Enum=Role;RoleBinding;ClusterRole;ClusterRoleBinding
+	Kind string `json:"kind"`
+
+	// Namespace of a Role or RoleBinding.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// RoleRef is the role a binding grants.
+	// +optional
+	RoleRef *rbacv1.RoleRef `json:"roleRef,omitempty"`
+
+	// Subjects a binding grants the role to.
+	// +optional
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+
+	// Rules of a Role or ClusterRole. For bindings these are the effective rules of the referenced
+	// role, with existing ClusterRoles expanded.
+	// +optional
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+}
+
+// ActivationRecord is the audit record of a single activation window.
+type ActivationRecord struct {
+	// Activation is the activation count this window was granted as.
+	Activation int32 `json:"activation"`
+
+	// GrantedAt is when the RBAC resources were granted.
+	GrantedAt metav1.Time `json:"grantedAt"`
+
+	// RevokedAt is when the RBAC resources were removed. It is unset while the window is open.
+	// +optional
+	RevokedAt *metav1.Time `json:"revokedAt,omitempty"`
+
+	// ApprovedBy is the approver recorded when the window was granted.
+	// +optional
+	ApprovedBy string `json:"approvedBy,omitempty"`
+
+	// CreatedResources lists the RBAC resources that made up the window.
+	// +optional
+	CreatedResources []string `json:"createdResources,omitempty"`
+}
+
+// PhaseTransition records a single phase change.
+type PhaseTransition struct {
+	// From is the phase before the transition. It is empty for the first transition.
+	// +optional
+	From BreakglassPhase `json:"from,omitempty"`
+
+	// To is the phase after the transition.
+	To BreakglassPhase `json:"to"`
+
+	// Reason is the condition reason recorded with the transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Actor is the user that caused the transition, or "system" for the controller.
+	// +optional
+	Actor string `json:"actor,omitempty"`
+
+	// Time is when the transition happened.
+	Time metav1.Time `json:"time"`
+}
+
+// ApprovalRecord is a single approval counted towards a Breakglass quorum.
+type ApprovalRecord struct {
+	// Approver is the username that approved.
+	Approver string `json:"approver"`
+
+	// ApprovalRef is the name of the BreakglassApproval carrying the decision.
+	ApprovalRef string `json:"approvalRef"`
+
+	// ApprovedAt is when the BreakglassApproval was admitted.
+	ApprovedAt metav1.Time `json:"approvedAt"`
+}
+
+// ExtensionRecord is the outcome of an ExtensionRequest.
+type ExtensionRecord struct {
+	// Name of the ExtensionRequest.
+	Name string `json:"name"`
+
+	// Duration that was requested.
+	Duration metav1.Duration `json:"duration"`
+
+	// Decision is Approve or Deny.
+	Decision ApprovalDecision `json:"decision"`
+
+	// DecidedBy is the comma-separated usernames that decided the extension.
+	DecidedBy string `json:"decidedBy"`
+
+	// DecidedAt is when the decision was recorded.
+	DecidedAt metav1.Time `json:"decidedAt"`
+
+	// WindowStart is the start of the window that was extended.
+	// +optional
+	WindowStart *metav1.Time `json:"windowStart,omitempty"`
+
+	// ExtendedUntil is the new end of the window after applying this extension.
+	// +optional
+	ExtendedUntil *metav1.Time `json:"extendedUntil,omitempty"`
+
+	// Message explains the decision.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// String returns the string representation of the phase
+func (p BreakglassPhase) String() string {
+	return string(p)
 }
 
 // String returns the string representation of the condition
@@ -4,8 +4,30 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Requester identity annotations are stamped by the mutating webhook from the admission request
+// and cannot be changed afterwards.
+const (
+	// RequestedByAnnotation holds the username of the user that created the Breakglass.
+	// It is used to enforce that requesters never approve their own request.
+	RequestedByAnnotation = "access.cloudnimbus.io/requested-by"
+	// RequestedByUIDAnnotation holds the UID of the user that created the Breakglass.
+	RequestedByUIDAnnotation = "access.cloudnimbus.io/requested-by-uid"
+	// RequestedByGroupsAnnotation holds the comma-separated groups of the user that created the Breakglass.
+	RequestedByGroupsAnnotation = "access.cloudnimbus.io/requested-by-groups"
+)
+
+// RequesterAnnotations lists the annotations that make up the requester identity.
+var RequesterAnnotations = []string{
+	RequestedByAnnotation,
+	RequestedByUIDAnnotation,
+	RequestedByGroupsAnnotation,
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +protobuf=true
 
 // Breakglass is the Schema for the breakglasses API
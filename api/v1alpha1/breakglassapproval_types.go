@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ApprovalDecision is the outcome recorded by a BreakglassApproval.
+type ApprovalDecision string
+
+const (
+	// DecisionApprove approves the referenced breakglass request
+	DecisionApprove ApprovalDecision = "Approve"
+	// DecisionDeny denies the referenced breakglass request
+	DecisionDeny ApprovalDecision = "Deny"
+)
+
+// BreakglassRefKind is the kind of request a BreakglassApproval decides on.
+type BreakglassRefKind string
+
+const (
+	// RefKindBreakglass refers to a Breakglass in the same namespace as the approval
+	RefKindBreakglass BreakglassRefKind = "Breakglass"
+	// RefKindClusterBreakglass refers to a ClusterBreakglass
+	RefKindClusterBreakglass BreakglassRefKind = "ClusterBreakglass"
+)
+
+// UserIdentity is an authenticated Kubernetes user as seen by the API server.
+type UserIdentity struct {
+	// Username is the name of the authenticated user.
+	Username string `json:"username"`
+
+	// UID is a unique value that identifies the user across time.
+	// +optional
+	UID string `json:"uid,omitempty"`
+
+	// Groups the user belonged to when the request was admitted.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// BreakglassApprovalSpec defines an approve or deny decision for a Breakglass request.
+type BreakglassApprovalSpec struct {
+	// BreakglassRef is the name of the Breakglass, in the same namespace, this decision applies to.
+	// +kubebuilder:validation:MinLength=1
+	BreakglassRef string `json:"breakglassRef"`
+
+	// BreakglassKind is the kind breakglassRef refers to. Decisions on a ClusterBreakglass are
+	// only honoured in the namespace the operator is configured to read cluster approvals from.
+	// +kubebuilder:validation:Enum=Breakglass;ClusterBreakglass
+	// +optional
+	BreakglassKind BreakglassRefKind `json:"breakglassKind,omitempty"`
+
+	// BreakglassUID is the UID of the request breakglassRef named when the decision was admitted.
+	// It is stamped by the admission webhook, so a decision never carries over to a request that is
+	// deleted and recreated under the same name. Any value supplied by the client is overwritten.
+	// +optional
+	BreakglassUID types.UID `json:"breakglassUID,omitempty"`
+
+	// Extension names the spec.extensions entry of the Breakglass this decision applies to.
+	// Empty means the decision applies to the request itself.
+	// +optional
+	Extension string `json:"extension,omitempty"`
+
+	// Decision is either Approve or Deny.
+	// +kubebuilder:validation:Enum=Approve;Deny
+	Decision ApprovalDecision `json:"decision"`
+
+	// Reason explains the decision. Required when denying.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Approver is stamped by the admission webhook from the authenticated request.
+	// Any value supplied by the client is overwritten.
+	// +optional
+	Approver *UserIdentity `json:"approver,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Breakglass",type=string,JSONPath=`.spec.breakglassRef`
+//+kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.breakglassKind`,priority=1
+//+kubebuilder:printcolumn:name="Decision",type=string,JSONPath=`.spec.decision`
+//+kubebuilder:printcolumn:name="Approver",type=string,JSONPath=`.spec.approver.username`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BreakglassApproval records an authenticated approve or deny decision for a Breakglass.
+// Approvals are immutable once created and are kept for audit.
+type BreakglassApproval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BreakglassApprovalSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BreakglassApprovalList contains a list of BreakglassApproval decisions.
+type BreakglassApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BreakglassApproval `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BreakglassApproval{}, &BreakglassApprovalList{})
+}
@@ -0,0 +1,92 @@
+package v1alpha1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BreakglassPolicySpec declares the guardrails Breakglass requests must stay within.
+type BreakglassPolicySpec struct {
+	// Namespaces lists the namespaces whose Breakglass requests this policy governs.
+	// Empty matches every namespace.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// AllowedClusterRoles lists the ClusterRoles that may be requested via spec.clusterRoles.
+	// A single "*" entry allows any ClusterRole.
+	// +optional
+	AllowedClusterRoles []string `json:"allowedClusterRoles,omitempty"`
+
+	// AllowedRules bounds ad-hoc spec.policy rules. Every requested rule must be covered by
+	// one of these rules.
+	// +optional
+	AllowedRules []rbacv1.PolicyRule `json:"allowedRules,omitempty"`
+
+	// AllowClusterScope permits requests that grant access cluster-wide, either through
+	// unscoped clusterRoles or policy entries without a namespace.
+	// +optional
+	AllowClusterScope bool `json:"allowClusterScope,omitempty"`
+
+	// MaxDuration caps the length of an activation window, including extensions.
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// MaxActivations caps spec.schedule.maxActivations of recurring requests.
+	// Recurring requests must then set maxActivations themselves.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxActivations *int32 `json:"maxActivations,omitempty"`
+
+	// RequesterGroups restricts who may request access to members of these groups.
+	// Empty allows any requester.
+	// +optional
+	RequesterGroups []string `json:"requesterGroups,omitempty"`
+
+	// RequireApproval makes spec.approval.required mandatory.
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
+
+	// AllowedTargetNamespaces lists the namespaces, besides their own, in which governed Breakglass
+	// requests may grant access when requests are restricted to their own namespace. "*" allows any.
+	// +optional
+	AllowedTargetNamespaces []string `json:"allowedTargetNamespaces,omitempty"`
+
+	// AllowProtected lets requests admitted by this policy bind the ClusterRoles, subjects
+	// and wildcard rules refused by the operator's denylist.
+	// +optional
+	AllowProtected bool `json:"allowProtected,omitempty"`
+
+	// AllowGrantChanges lets approved requests admitted by this policy change spec.subjects,
+	// spec.clusterRoles, spec.clusterRoleScope and spec.policy. Changes to active requests are applied
+	// to the live RBAC. Without it these fields are immutable once a request is approved.
+	// +optional
+	AllowGrantChanges bool `json:"allowGrantChanges,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Max Duration",type=string,JSONPath=`.spec.maxDuration`
+//+kubebuilder:printcolumn:name="Require Approval",type=boolean,JSONPath=`.spec.requireApproval`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BreakglassPolicy is a cluster-scoped set of guardrails for Breakglass requests.
+// When at least one policy exists, every request must be admitted by a policy matching its namespace.
+type BreakglassPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BreakglassPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BreakglassPolicyList contains a list of BreakglassPolicy guardrails.
+type BreakglassPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BreakglassPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BreakglassPolicy{}, &BreakglassPolicyList{})
+}
@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:resource:scope=Cluster
+
+// ClusterBreakglass is a cluster-scoped Breakglass request. It is the kind to use for cluster-wide
+// grants, or grants spanning namespaces, when namespaced requests are restricted to their own namespace.
+type ClusterBreakglass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BreakglassSpec   `json:"spec,omitempty"`
+	Status BreakglassStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterBreakglassList contains a list of ClusterBreakglass requests.
+type ClusterBreakglassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterBreakglass `json:"items"`
+}
+
+// ToBreakglass returns a copy of c as a Breakglass without a namespace, which is how the
+// Breakglass controller, handlers and webhooks process cluster-scoped requests.
+func (c *ClusterBreakglass) ToBreakglass() *Breakglass {
+	return &Breakglass{
+		ObjectMeta: *c.ObjectMeta.DeepCopy(),
+		Spec:       *c.Spec.DeepCopy(),
+		Status:     *c.Status.DeepCopy(),
+	}
+}
+
+// FromBreakglass copies the metadata, spec and status of bg, as returned by ToBreakglass, onto c.
+func (c *ClusterBreakglass) FromBreakglass(bg *Breakglass) {
+	bg.ObjectMeta.DeepCopyInto(&c.ObjectMeta)
+	c.Namespace = ""
+	bg.Spec.DeepCopyInto(&c.Spec)
+	bg.Status.DeepCopyInto(&c.Status)
+}
+
+// IsClusterScoped reports whether bg was converted from a ClusterBreakglass.
+func (bg *Breakglass) IsClusterScoped() bool {
+	return bg.Namespace == ""
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterBreakglass{}, &ClusterBreakglassList{})
+}
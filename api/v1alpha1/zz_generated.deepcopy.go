@@ -10,9 +10,60 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivationRecord) DeepCopyInto(out *ActivationRecord) {
+	*out = *in
+	in.GrantedAt.DeepCopyInto(&out.GrantedAt)
+	if in.RevokedAt != nil {
+		in, out := &in.RevokedAt, &out.RevokedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CreatedResources != nil {
+		in, out := &in.CreatedResources, &out.CreatedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivationRecord.
+func (in *ActivationRecord) DeepCopy() *ActivationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalRecord) DeepCopyInto(out *ApprovalRecord) {
+	*out = *in
+	in.ApprovedAt.DeepCopyInto(&out.ApprovedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalRecord.
+func (in *ApprovalRecord) DeepCopy() *ApprovalRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApprovalSpec) DeepCopyInto(out *ApprovalSpec) {
 	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalSpec.
@@ -52,6 +103,84 @@ func (in *Breakglass) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakglassApproval) DeepCopyInto(out *BreakglassApproval) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakglassApproval.
+func (in *BreakglassApproval) DeepCopy() *BreakglassApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakglassApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BreakglassApproval) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakglassApprovalList) DeepCopyInto(out *BreakglassApprovalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BreakglassApproval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakglassApprovalList.
+func (in *BreakglassApprovalList) DeepCopy() *BreakglassApprovalList {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakglassApprovalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BreakglassApprovalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakglassApprovalSpec) DeepCopyInto(out *BreakglassApprovalSpec) {
+	*out = *in
+	if in.Approver != nil {
+		in, out := &in.Approver, &out.Approver
+		*out = new(UserIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakglassApprovalSpec.
+func (in *BreakglassApprovalSpec) DeepCopy() *BreakglassApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakglassApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BreakglassList) DeepCopyInto(out *BreakglassList) {
 	*out = *in
@@ -84,6 +213,116 @@ func (in *BreakglassList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakglassPolicy) DeepCopyInto(out *BreakglassPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakglassPolicy.
+func (in *BreakglassPolicy) DeepCopy() *BreakglassPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakglassPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BreakglassPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakglassPolicyList) DeepCopyInto(out *BreakglassPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BreakglassPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakglassPolicyList.
+func (in *BreakglassPolicyList) DeepCopy() *BreakglassPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakglassPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BreakglassPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakglassPolicySpec) DeepCopyInto(out *BreakglassPolicySpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedClusterRoles != nil {
+		in, out := &in.AllowedClusterRoles, &out.AllowedClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedRules != nil {
+		in, out := &in.AllowedRules, &out.AllowedRules
+		*out = make([]v1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxActivations != nil {
+		in, out := &in.MaxActivations, &out.MaxActivations
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequesterGroups != nil {
+		in, out := &in.RequesterGroups, &out.RequesterGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedTargetNamespaces != nil {
+		in, out := &in.AllowedTargetNamespaces, &out.AllowedTargetNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakglassPolicySpec.
+func (in *BreakglassPolicySpec) DeepCopy() *BreakglassPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakglassPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BreakglassSpec) DeepCopyInto(out *BreakglassSpec) {
 	*out = *in
@@ -104,12 +343,29 @@ func (in *BreakglassSpec) DeepCopyInto(out *BreakglassSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClusterRoleScope != nil {
+		in, out := &in.ClusterRoleScope, &out.ClusterRoleScope
+		*out = new(NamespaceScope)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Approval != nil {
 		in, out := &in.Approval, &out.Approval
 		*out = new(ApprovalSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	in.Schedule.DeepCopyInto(&out.Schedule)
+	if in.Revocation != nil {
+		in, out := &in.Revocation, &out.Revocation
+		*out = new(RevocationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]ExtensionRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakglassSpec.
@@ -125,6 +381,13 @@ func (in *BreakglassSpec) DeepCopy() *BreakglassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BreakglassStatus) DeepCopyInto(out *BreakglassStatus) {
 	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]PhaseTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.GrantedAt != nil {
 		in, out := &in.GrantedAt, &out.GrantedAt
 		*out = (*in).DeepCopy()
@@ -140,6 +403,32 @@ func (in *BreakglassStatus) DeepCopyInto(out *BreakglassStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ApprovedAt != nil {
+		in, out := &in.ApprovedAt, &out.ApprovedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Approvals != nil {
+		in, out := &in.Approvals, &out.Approvals
+		*out = make([]ApprovalRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeniedAt != nil {
+		in, out := &in.DeniedAt, &out.DeniedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RevokedAt != nil {
+		in, out := &in.RevokedAt, &out.RevokedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]ExtensionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.CreatedResources != nil {
 		in, out := &in.CreatedResources, &out.CreatedResources
 		*out = make([]string, len(*in))
@@ -149,6 +438,18 @@ func (in *BreakglassStatus) DeepCopyInto(out *BreakglassStatus) {
 		in, out := &in.NextActivationAt, &out.NextActivationAt
 		*out = (*in).DeepCopy()
 	}
+	if in.ActivationHistory != nil {
+		in, out := &in.ActivationHistory, &out.ActivationHistory
+		*out = make([]ActivationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(RBACPlan)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakglassStatus.
@@ -161,6 +462,184 @@ func (in *BreakglassStatus) DeepCopy() *BreakglassStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBreakglass) DeepCopyInto(out *ClusterBreakglass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBreakglass.
+func (in *ClusterBreakglass) DeepCopy() *ClusterBreakglass {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBreakglass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBreakglass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBreakglassList) DeepCopyInto(out *ClusterBreakglassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterBreakglass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBreakglassList.
+func (in *ClusterBreakglassList) DeepCopy() *ClusterBreakglassList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBreakglassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBreakglassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionRecord) DeepCopyInto(out *ExtensionRecord) {
+	*out = *in
+	out.Duration = in.Duration
+	in.DecidedAt.DeepCopyInto(&out.DecidedAt)
+	if in.WindowStart != nil {
+		in, out := &in.WindowStart, &out.WindowStart
+		*out = (*in).DeepCopy()
+	}
+	if in.ExtendedUntil != nil {
+		in, out := &in.ExtendedUntil, &out.ExtendedUntil
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionRecord.
+func (in *ExtensionRecord) DeepCopy() *ExtensionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionRequest) DeepCopyInto(out *ExtensionRequest) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.RequestedBy != nil {
+		in, out := &in.RequestedBy, &out.RequestedBy
+		*out = new(UserIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionRequest.
+func (in *ExtensionRequest) DeepCopy() *ExtensionRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceScope) DeepCopyInto(out *NamespaceScope) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceScope.
+func (in *NamespaceScope) DeepCopy() *NamespaceScope {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseTransition) DeepCopyInto(out *PhaseTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseTransition.
+func (in *PhaseTransition) DeepCopy() *PhaseTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedObject) DeepCopyInto(out *PlannedObject) {
+	*out = *in
+	if in.RoleRef != nil {
+		in, out := &in.RoleRef, &out.RoleRef
+		*out = new(v1.RoleRef)
+		**out = **in
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]v1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedObject.
+func (in *PlannedObject) DeepCopy() *PlannedObject {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Policy) DeepCopyInto(out *Policy) {
 	*out = *in
@@ -183,10 +662,62 @@ func (in *Policy) DeepCopy() *Policy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACPlan) DeepCopyInto(out *RBACPlan) {
+	*out = *in
+	in.RenderedAt.DeepCopyInto(&out.RenderedAt)
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]PlannedObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACPlan.
+func (in *RBACPlan) DeepCopy() *RBACPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevocationSpec) DeepCopyInto(out *RevocationSpec) {
+	*out = *in
+	if in.RevokedBy != nil {
+		in, out := &in.RevokedBy, &out.RevokedBy
+		*out = new(UserIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevocationSpec.
+func (in *RevocationSpec) DeepCopy() *RevocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RevocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScheduleSpec) DeepCopyInto(out *ScheduleSpec) {
 	*out = *in
 	in.Start.DeepCopyInto(&out.Start)
+	if in.Until != nil {
+		in, out := &in.Until, &out.Until
+		*out = (*in).DeepCopy()
+	}
 	out.Duration = in.Duration
 	if in.MaxActivations != nil {
 		in, out := &in.MaxActivations, &out.MaxActivations
@@ -204,3 +735,23 @@ func (in *ScheduleSpec) DeepCopy() *ScheduleSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserIdentity) DeepCopyInto(out *UserIdentity) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserIdentity.
+func (in *UserIdentity) DeepCopy() *UserIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(UserIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
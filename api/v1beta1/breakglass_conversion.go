@@ -0,0 +1,412 @@
+package v1beta1
+
+import (
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+// ConvertTo converts this Breakglass to the v1alpha1 hub version.
+func (src *Breakglass) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*accessv1alpha1.Breakglass)
+	dst.ObjectMeta = src.ObjectMeta
+	convertSpecTo(&src.Spec, &dst.Spec)
+	convertStatusTo(&src.Status, &dst.Status, src.Spec.Policy)
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 hub version to this Breakglass.
+func (dst *Breakglass) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*accessv1alpha1.Breakglass)
+	dst.ObjectMeta = src.ObjectMeta
+	convertSpecFrom(&src.Spec, &dst.Spec)
+	convertStatusFrom(&src.Status, &dst.Status, src.Spec.Policy)
+	return nil
+}
+
+// ConvertTo converts this ClusterBreakglass to the v1alpha1 hub version.
+func (src *ClusterBreakglass) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*accessv1alpha1.ClusterBreakglass)
+	dst.ObjectMeta = src.ObjectMeta
+	convertSpecTo(&src.Spec, &dst.Spec)
+	convertStatusTo(&src.Status, &dst.Status, src.Spec.Policy)
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 hub version to this ClusterBreakglass.
+func (dst *ClusterBreakglass) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*accessv1alpha1.ClusterBreakglass)
+	dst.ObjectMeta = src.ObjectMeta
+	convertSpecFrom(&src.Spec, &dst.Spec)
+	convertStatusFrom(&src.Status, &dst.Status, src.Spec.Policy)
+	return nil
+}
+
+func convertSpecTo(src *BreakglassSpec, dst *accessv1alpha1.BreakglassSpec) {
+	dst.Subjects = src.Subjects
+	dst.ClusterRoles = src.ClusterRoles
+	dst.Justification = src.Justification
+	dst.TicketID = src.TicketID
+	dst.DryRun = src.DryRun
+	dst.Schedule = accessv1alpha1.ScheduleSpec(src.Schedule)
+	dst.ClusterRoleScope = (*accessv1alpha1.NamespaceScope)(src.ClusterRoleScope)
+	dst.Approval = (*accessv1alpha1.ApprovalSpec)(src.Approval)
+
+	dst.Policy = nil
+	for _, p := range src.Policy {
+		dst.Policy = append(dst.Policy, accessv1alpha1.Policy(p))
+	}
+
+	dst.Revocation = nil
+	if src.Revocation != nil {
+		dst.Revocation = &accessv1alpha1.RevocationSpec{
+			Reason:    src.Revocation.Reason,
+			RevokedBy: (*accessv1alpha1.UserIdentity)(src.Revocation.RevokedBy),
+		}
+	}
+
+	dst.Extensions = nil
+	for _, ext := range src.Extensions {
+		dst.Extensions = append(dst.Extensions, accessv1alpha1.ExtensionRequest{
+			Name:        ext.Name,
+			Duration:    ext.Duration,
+			Reason:      ext.Reason,
+			RequestedBy: (*accessv1alpha1.UserIdentity)(ext.RequestedBy),
+		})
+	}
+}
+
+func convertSpecFrom(src *accessv1alpha1.BreakglassSpec, dst *BreakglassSpec) {
+	dst.Subjects = src.Subjects
+	dst.ClusterRoles = src.ClusterRoles
+	dst.Justification = src.Justification
+	dst.TicketID = src.TicketID
+	dst.DryRun = src.DryRun
+	dst.Schedule = ScheduleSpec(src.Schedule)
+	dst.ClusterRoleScope = (*NamespaceScope)(src.ClusterRoleScope)
+	dst.Approval = (*ApprovalSpec)(src.Approval)
+
+	dst.Policy = nil
+	for _, p := range src.Policy {
+		dst.Policy = append(dst.Policy, Policy(p))
+	}
+
+	dst.Revocation = nil
+	if src.Revocation != nil {
+		dst.Revocation = &RevocationSpec{
+			Reason:    src.Revocation.Reason,
+			RevokedBy: (*UserIdentity)(src.Revocation.RevokedBy),
+		}
+	}
+
+	dst.Extensions = nil
+	for _, ext := range src.Extensions {
+		dst.Extensions = append(dst.Extensions, ExtensionRequest{
+			Name:        ext.Name,
+			Duration:    ext.Duration,
+			Reason:      ext.Reason,
+			RequestedBy: (*UserIdentity)(ext.RequestedBy),
+		})
+	}
+}
+
+// convertStatusTo converts status to v1alpha1.
+func convertStatusTo(src *BreakglassStatus, dst *accessv1alpha1.BreakglassStatus, policies []Policy) {
+	dst.ObservedGeneration = src.ObservedGeneration
+	dst.Phase = accessv1alpha1.BreakglassPhase(src.Phase)
+	dst.Conditions = src.Conditions
+	dst.GrantedAt = src.GrantedAt
+	dst.ExpiresAt = src.ExpiresAt
+	dst.ApprovedBy = src.ApprovedBy
+	dst.ApprovedAt = src.ApprovedAt
+	dst.DeniedBy = src.DeniedBy
+	dst.DeniedAt = src.DeniedAt
+	dst.RevokedBy = src.RevokedBy
+	dst.RevokedAt = src.RevokedAt
+	dst.AdmittedByPolicy = src.AdmittedByPolicy
+	dst.GrantsHash = src.GrantsHash
+	dst.NextActivationAt = src.NextActivationAt
+	dst.ActivationCount = src.ActivationCount
+
+	dst.History = nil
+	for _, h := range src.History {
+		dst.History = append(dst.History, accessv1alpha1.PhaseTransition{
+			From:   accessv1alpha1.BreakglassPhase(h.From),
+			To:     accessv1alpha1.BreakglassPhase(h.To),
+			Reason: h.Reason,
+			Actor:  h.Actor,
+			Time:   h.Time,
+		})
+	}
+
+	dst.Approvals = nil
+	for _, a := range src.Approvals {
+		dst.Approvals = append(dst.Approvals, accessv1alpha1.ApprovalRecord(a))
+	}
+
+	dst.Extensions = nil
+	for _, ext := range src.Extensions {
+		dst.Extensions = append(dst.Extensions, accessv1alpha1.ExtensionRecord{
+			Name:          ext.Name,
+			Duration:      ext.Duration,
+			Decision:      accessv1alpha1.ApprovalDecision(ext.Decision),
+			DecidedBy:     ext.DecidedBy,
+			DecidedAt:     ext.DecidedAt,
+			WindowStart:   ext.WindowStart,
+			ExtendedUntil: ext.ExtendedUntil,
+			Message:       ext.Message,
+		})
+	}
+
+	dst.CreatedResources = nil
+	for _, ref := range src.CreatedResources {
+		dst.CreatedResources = append(dst.CreatedResources, ref.legacyName(policies))
+	}
+
+	dst.ActivationHistory = nil
+	for _, a := range src.ActivationHistory {
+		record := accessv1alpha1.ActivationRecord{
+			Activation: a.Activation,
+			GrantedAt:  a.GrantedAt,
+			RevokedAt:  a.RevokedAt,
+			ApprovedBy: a.ApprovedBy,
+		}
+		for _, ref := range a.CreatedResources {
+			record.CreatedResources = append(record.CreatedResources, ref.legacyName(policies))
+		}
+		dst.ActivationHistory = append(dst.ActivationHistory, record)
+	}
+
+	dst.Plan = nil
+	if src.Plan != nil {
+		dst.Plan = &accessv1alpha1.RBACPlan{
+			ObservedGeneration: src.Plan.ObservedGeneration,
+			RenderedAt:         src.Plan.RenderedAt,
+			Warnings:           src.Plan.Warnings,
+		}
+		for _, obj := range src.Plan.Objects {
+			dst.Plan.Objects = append(dst.Plan.Objects, accessv1alpha1.PlannedObject(obj))
+		}
+	}
+}
+
+// convertStatusFrom converts status from v1alpha1. Objects written before status.phase
+// existed have their phase derived from the conditions.
+func convertStatusFrom(src *accessv1alpha1.BreakglassStatus, dst *BreakglassStatus, policies []accessv1alpha1.Policy) {
+	dst.ObservedGeneration = src.ObservedGeneration
+	dst.Phase = BreakglassPhase(src.Phase)
+	if dst.Phase == "" {
+		dst.Phase = phaseFromConditions(src.Conditions)
+	}
+	dst.Conditions = src.Conditions
+	dst.GrantedAt = src.GrantedAt
+	dst.ExpiresAt = src.ExpiresAt
+	dst.ApprovedBy = src.ApprovedBy
+	dst.ApprovedAt = src.ApprovedAt
+	dst.DeniedBy = src.DeniedBy
+	dst.DeniedAt = src.DeniedAt
+	dst.RevokedBy = src.RevokedBy
+	dst.RevokedAt = src.RevokedAt
+	dst.AdmittedByPolicy = src.AdmittedByPolicy
+	dst.GrantsHash = src.GrantsHash
+	dst.NextActivationAt = src.NextActivationAt
+	dst.ActivationCount = src.ActivationCount
+
+	dst.History = nil
+	for _, h := range src.History {
+		dst.History = append(dst.History, PhaseTransition{
+			From:   BreakglassPhase(h.From),
+			To:     BreakglassPhase(h.To),
+			Reason: h.Reason,
+			Actor:  h.Actor,
+			Time:   h.Time,
+		})
+	}
+
+	dst.Approvals = nil
+	for _, a := range src.Approvals {
+		dst.Approvals = append(dst.Approvals, ApprovalRecord(a))
+	}
+
+	dst.Extensions = nil
+	for _, ext := range src.Extensions {
+		dst.Extensions = append(dst.Extensions, ExtensionRecord{
+			Name:          ext.Name,
+			Duration:      ext.Duration,
+			Decision:      ApprovalDecision(ext.Decision),
+			DecidedBy:     ext.DecidedBy,
+			DecidedAt:     ext.DecidedAt,
+			WindowStart:   ext.WindowStart,
+			ExtendedUntil: ext.ExtendedUntil,
+			Message:       ext.Message,
+		})
+	}
+
+	dst.CreatedResources = nil
+	for _, name := range src.CreatedResources {
+		dst.CreatedResources = append(dst.CreatedResources, parseLegacyName(name, policies))
+	}
+
+	dst.ActivationHistory = nil
+	for _, a := range src.ActivationHistory {
+		record := ActivationRecord{
+			Activation: a.Activation,
+			GrantedAt:  a.GrantedAt,
+			RevokedAt:  a.RevokedAt,
+			ApprovedBy: a.ApprovedBy,
+		}
+		for _, name := range a.CreatedResources {
+			record.CreatedResources = append(record.CreatedResources, parseLegacyName(name, policies))
+		}
+		dst.ActivationHistory = append(dst.ActivationHistory, record)
+	}
+
+	dst.Plan = nil
+	if src.Plan != nil {
+		dst.Plan = &RBACPlan{
+			ObservedGeneration: src.Plan.ObservedGeneration,
+			RenderedAt:         src.Plan.RenderedAt,
+			Warnings:           src.Plan.Warnings,
+		}
+		for _, obj := range src.Plan.Objects {
+			dst.Plan.Objects = append(dst.Plan.Objects, PlannedObject(obj))
+		}
+	}
+}
+
+// phaseFromConditions derives the phase from the condition the v1alpha1 controller
+// dispatches on, which is the last one in the list.
+func phaseFromConditions(conditions []metav1.Condition) BreakglassPhase {
+	if len(conditions) == 0 {
+		return ""
+	}
+	switch accessv1alpha1.BreakglassCondition(conditions[len(conditions)-1].Type) {
+	case accessv1alpha1.ConditionValidating:
+		return PhaseValidating
+	case accessv1alpha1.ConditionPending:
+		return PhasePending
+	case accessv1alpha1.ConditionApproved:
+		return PhaseApproved
+	case accessv1alpha1.ConditionDenied:
+		return PhaseDenied
+	case accessv1alpha1.ConditionRecurringPending:
+		return PhaseScheduled
+	case accessv1alpha1.ConditionActive, accessv1alpha1.ConditionRecurringActive:
+		return PhaseActive
+	case accessv1alpha1.ConditionExpired:
+		return PhaseExpired
+	case accessv1alpha1.ConditionRevoked:
+		return PhaseRevoked
+	case accessv1alpha1.ConditionFailed:
+		return PhaseFailed
+	}
+	return ""
+}
+
+// v1alpha1 records created resources as bare names, "breakglass-<suffix>-<hash>", except for RoleBindings
+// created for clusterRoleScope which are recorded as "<namespace>/<name>". Requests granted before names
+// were hashed carry "breakglass-<uid>-<suffix>" instead. The suffix starts with the kind; Roles and
+// RoleBindings created for spec.policy[i] end it with the index and live in that policy's namespace.
+const legacyPrefix = "breakglass-"
+
+// legacyUIDLength is the number of UID characters older operators put in resource names.
+const legacyUIDLength = 8
+
+// legacyHashLength is the number of hex characters ending the names the operator generates.
+const legacyHashLength = 16
+
+// parseLegacyName converts a v1alpha1 created resource entry into a ResourceRef. Entries that do
+// not follow the naming scheme are kept as a name without kind.
+func parseLegacyName(name string, policies []accessv1alpha1.Policy) ResourceRef {
+	if ns, n, ok := strings.Cut(name, "/"); ok {
+		return ResourceRef{Kind: KindRoleBinding, Namespace: ns, Name: n}
+	}
+
+	rest, ok := legacyKindSuffix(name)
+	if !ok {
+		return ResourceRef{Name: name}
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "clusterrolebinding-"), strings.HasPrefix(rest, "policy-clusterrolebinding-"):
+		return ResourceRef{Kind: KindClusterRoleBinding, Name: name}
+	case strings.HasPrefix(rest, "policy-clusterrole-"):
+		return ResourceRef{Kind: KindClusterRole, Name: name}
+	case strings.HasPrefix(rest, "policy-role-"):
+		return ResourceRef{Kind: KindRole, Namespace: policyNamespace(rest, "policy-role-", policies), Name: name}
+	case strings.HasPrefix(rest, "policy-rolebinding-"):
+		ns := policyNamespace(rest, "policy-rolebinding-", policies)
+		return ResourceRef{Kind: KindRoleBinding, Namespace: ns, Name: name}
+	case strings.HasPrefix(rest, "role-"):
+		return ResourceRef{Kind: KindRole, Namespace: policyNamespace(rest, "role-", policies), Name: name}
+	case strings.HasPrefix(rest, "rolebinding-"):
+		return ResourceRef{Kind: KindRoleBinding, Namespace: policyNamespace(rest, "rolebinding-", policies), Name: name}
+	}
+	return ResourceRef{Name: name}
+}
+
+// legacyName converts r back into its v1alpha1 created resource entry.
+func (r ResourceRef) legacyName(policies []Policy) string {
+	if r.Namespace == "" || r.Kind == KindRole {
+		return r.Name
+	}
+	if r.Kind == KindRoleBinding {
+		if rest, ok := legacyKindSuffix(r.Name); ok {
+			for _, prefix := range []string{"policy-rolebinding-", "rolebinding-"} {
+				if !strings.HasPrefix(rest, prefix) {
+					continue
+				}
+				if i, ok := policyIndex(rest, prefix, len(policies)); ok && policies[i].Namespace == r.Namespace {
+					return r.Name
+				}
+			}
+		}
+	}
+	return r.Namespace + "/" + r.Name
+}
+
+// legacyKindSuffix strips the "breakglass-" prefix and the "-<hash>" ending, or for older names the
+// "breakglass-<uid>-" prefix, from name.
+func legacyKindSuffix(name string) (string, bool) {
+	rest, ok := strings.CutPrefix(name, legacyPrefix)
+	if !ok {
+		return "", false
+	}
+	if cut := len(rest) - legacyHashLength - 1; cut > 0 && rest[cut] == '-' && isLowerHex(rest[cut+1:]) {
+		return rest[:cut], true
+	}
+	if len(rest) <= legacyUIDLength || rest[legacyUIDLength] != '-' {
+		return "", false
+	}
+	return rest[legacyUIDLength+1:], true
+}
+
+// isLowerHex reports whether s consists of lowercase hex digits only.
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// policyNamespace returns the namespace of the policy whose index follows prefix in rest.
+func policyNamespace(rest, prefix string, policies []accessv1alpha1.Policy) string {
+	if i, ok := policyIndex(rest, prefix, len(policies)); ok {
+		return policies[i].Namespace
+	}
+	return ""
+}
+
+func policyIndex(rest, prefix string, n int) (int, bool) {
+	i, err := strconv.Atoi(strings.TrimPrefix(rest, prefix))
+	if err != nil || i < 0 || i >= n {
+		return 0, false
+	}
+	return i, true
+}
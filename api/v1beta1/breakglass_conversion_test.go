@@ -0,0 +1,209 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+func hubBreakglass() *accessv1alpha1.Breakglass {
+	start := metav1.NewTime(time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC))
+	until := metav1.NewTime(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	return &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			Policy: []accessv1alpha1.Policy{
+				{Namespace: "payments", Rules: []rbacv1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}}},
+				{Rules: []rbacv1.PolicyRule{{Verbs: []string{"list"}, Resources: []string{"nodes"}}}},
+			},
+			Schedule: accessv1alpha1.ScheduleSpec{
+				Start:    start,
+				Until:    &until,
+				Duration: metav1.Duration{Duration: time.Hour},
+				Cron:     "0 9 * * 1-5",
+			},
+			Justification: "on-call",
+			DryRun:        true,
+			Extensions: []accessv1alpha1.ExtensionRequest{{
+				Name:        "more",
+				Duration:    metav1.Duration{Duration: time.Hour},
+				Reason:      "still debugging",
+				RequestedBy: &accessv1alpha1.UserIdentity{Username: "alice"},
+			}},
+		},
+		Status: accessv1alpha1.BreakglassStatus{
+			Phase: accessv1alpha1.PhaseActive,
+			History: []accessv1alpha1.PhaseTransition{
+				{To: accessv1alpha1.PhasePending, Reason: "Pending", Actor: "system", Time: start},
+				{From: accessv1alpha1.PhasePending, To: accessv1alpha1.PhaseActive, Reason: "Activated", Actor: "bob", Time: start},
+			},
+			Conditions: []metav1.Condition{
+				{Type: string(accessv1alpha1.ConditionApproved), Status: metav1.ConditionTrue, Reason: "Approved"},
+				{Type: string(accessv1alpha1.ConditionRecurringActive), Status: metav1.ConditionTrue, Reason: "Activated"},
+			},
+			Extensions: []accessv1alpha1.ExtensionRecord{{
+				Name:     "more",
+				Decision: accessv1alpha1.DecisionApprove,
+			}},
+			CreatedResources: []string{
+				"breakglass-01234567-role-0",
+				"breakglass-01234567-rolebinding-0",
+				"breakglass-01234567-policy-clusterrole-1",
+				"breakglass-01234567-policy-clusterrolebinding-1",
+				"unrecognised",
+			},
+			ActivationCount: 2,
+			ActivationHistory: []accessv1alpha1.ActivationRecord{
+				{
+					Activation:       1,
+					GrantedAt:        start,
+					RevokedAt:        &until,
+					ApprovedBy:       "bob",
+					CreatedResources: []string{"breakglass-01234567-role-0", "breakglass-01234567-rolebinding-0"},
+				},
+				{Activation: 2, GrantedAt: until, ApprovedBy: "bob"},
+			},
+			Plan: &accessv1alpha1.RBACPlan{
+				ObservedGeneration: 1,
+				RenderedAt:         start,
+				Objects: []accessv1alpha1.PlannedObject{{
+					Kind:      accessv1alpha1.KindRole,
+					Namespace: "payments",
+					Name:      "breakglass-01234567-role-0",
+					Rules:     []rbacv1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}},
+				}},
+				Warnings: []string{"ClusterRole view does not exist"},
+			},
+		},
+	}
+}
+
+func TestBreakglassConversion_RoundTrip(t *testing.T) {
+	hub := hubBreakglass()
+
+	spoke := &Breakglass{}
+	require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+
+	assert.Equal(t, PhaseActive, spoke.Status.Phase)
+	assert.Equal(t, hub.Spec.Schedule.Until, spoke.Spec.Schedule.Until)
+	assert.Equal(t, []ResourceRef{
+		{Kind: KindRole, Namespace: "payments", Name: "breakglass-01234567-role-0"},
+		{Kind: KindRoleBinding, Namespace: "payments", Name: "breakglass-01234567-rolebinding-0"},
+		{Kind: KindClusterRole, Name: "breakglass-01234567-policy-clusterrole-1"},
+		{Kind: KindClusterRoleBinding, Name: "breakglass-01234567-policy-clusterrolebinding-1"},
+		{Name: "unrecognised"},
+	}, spoke.Status.CreatedResources)
+	assert.Equal(t, []ResourceRef{
+		{Kind: KindRole, Namespace: "payments", Name: "breakglass-01234567-role-0"},
+		{Kind: KindRoleBinding, Namespace: "payments", Name: "breakglass-01234567-rolebinding-0"},
+	}, spoke.Status.ActivationHistory[0].CreatedResources)
+
+	back := &accessv1alpha1.Breakglass{}
+	require.NoError(t, spoke.ConvertTo(back))
+	assert.Equal(t, hub, back)
+}
+
+func TestBreakglassConversion_ScopedClusterRoles(t *testing.T) {
+	hub := hubBreakglass()
+	hub.Spec.Policy = nil
+	hub.Spec.ClusterRoles = []string{"view"}
+	hub.Spec.ClusterRoleScope = &accessv1alpha1.NamespaceScope{Namespaces: []string{"payments"}}
+	hub.Status.CreatedResources = []string{
+		"breakglass-01234567-clusterrolebinding-view",
+		"payments/breakglass-01234567-rolebinding-view",
+	}
+
+	spoke := &Breakglass{}
+	require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+	assert.Equal(t, []ResourceRef{
+		{Kind: KindClusterRoleBinding, Name: "breakglass-01234567-clusterrolebinding-view"},
+		{Kind: KindRoleBinding, Namespace: "payments", Name: "breakglass-01234567-rolebinding-view"},
+	}, spoke.Status.CreatedResources)
+
+	back := &accessv1alpha1.Breakglass{}
+	require.NoError(t, spoke.ConvertTo(back))
+	assert.Equal(t, hub.Status.CreatedResources, back.Status.CreatedResources)
+}
+
+func TestBreakglassConversion_HashedNames(t *testing.T) {
+	hub := hubBreakglass()
+	hub.Spec.ClusterRoles = []string{"view"}
+	hub.Status.CreatedResources = []string{
+		"breakglass-policy-role-0-3f2a9c0d1e4b5a67",
+		"breakglass-policy-rolebinding-0-0d1e4b5a673f2a9c",
+		"breakglass-policy-clusterrole-1-5a673f2a9c0d1e4b",
+		"breakglass-policy-clusterrolebinding-1-9c0d1e4b5a673f2a",
+		"breakglass-clusterrolebinding-view-4b5a673f2a9c0d1e",
+		"payments/breakglass-rolebinding-view-673f2a9c0d1e4b5a",
+	}
+	hub.Status.ActivationHistory[0].CreatedResources = hub.Status.CreatedResources[:2]
+	hub.Status.Plan = nil
+
+	spoke := &Breakglass{}
+	require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+	assert.Equal(t, []ResourceRef{
+		{Kind: KindRole, Namespace: "payments", Name: "breakglass-policy-role-0-3f2a9c0d1e4b5a67"},
+		{Kind: KindRoleBinding, Namespace: "payments", Name: "breakglass-policy-rolebinding-0-0d1e4b5a673f2a9c"},
+		{Kind: KindClusterRole, Name: "breakglass-policy-clusterrole-1-5a673f2a9c0d1e4b"},
+		{Kind: KindClusterRoleBinding, Name: "breakglass-policy-clusterrolebinding-1-9c0d1e4b5a673f2a"},
+		{Kind: KindClusterRoleBinding, Name: "breakglass-clusterrolebinding-view-4b5a673f2a9c0d1e"},
+		{Kind: KindRoleBinding, Namespace: "payments", Name: "breakglass-rolebinding-view-673f2a9c0d1e4b5a"},
+	}, spoke.Status.CreatedResources)
+	assert.Equal(t, spoke.Status.CreatedResources[:2], spoke.Status.ActivationHistory[0].CreatedResources)
+
+	back := &accessv1alpha1.Breakglass{}
+	require.NoError(t, spoke.ConvertTo(back))
+	assert.Equal(t, hub, back)
+}
+
+func TestClusterBreakglassConversion_RoundTrip(t *testing.T) {
+	bg := hubBreakglass()
+	hub := &accessv1alpha1.ClusterBreakglass{}
+	hub.FromBreakglass(bg)
+
+	spoke := &ClusterBreakglass{}
+	require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+	assert.Equal(t, PhaseActive, spoke.Status.Phase)
+
+	back := &accessv1alpha1.ClusterBreakglass{}
+	require.NoError(t, spoke.ConvertTo(back))
+	assert.Equal(t, hub, back)
+}
+
+func TestPhaseFromConditions(t *testing.T) {
+	tests := []struct {
+		condition accessv1alpha1.BreakglassCondition
+		want      BreakglassPhase
+	}{
+		{accessv1alpha1.ConditionPending, PhasePending},
+		{accessv1alpha1.ConditionRecurringPending, PhaseScheduled},
+		{accessv1alpha1.ConditionActive, PhaseActive},
+		{accessv1alpha1.ConditionExpired, PhaseExpired},
+		{accessv1alpha1.ConditionRevoked, PhaseRevoked},
+		{"Unknown", ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.condition), func(t *testing.T) {
+			got := phaseFromConditions([]metav1.Condition{{Type: string(tt.condition)}})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.Equal(t, BreakglassPhase(""), phaseFromConditions(nil))
+}
+
+func TestBreakglassConversion_LegacyPhase(t *testing.T) {
+	hub := hubBreakglass()
+	hub.Status.Phase = ""
+	hub.Status.History = nil
+
+	spoke := &Breakglass{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+	assert.Equal(t, PhaseActive, spoke.Status.Phase)
+}
@@ -0,0 +1,179 @@
+package v1beta1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BreakglassSpec defines the desired state of a Breakglass access request.
+type BreakglassSpec struct {
+	// Subjects defines the users/groups/service accounts to grant temporary access.
+	// At least one subject is required.
+	// +kubebuilder:validation:MinItems=1
+	Subjects []rbacv1.Subject `json:"subjects"`
+
+	// Either specify an ad-hoc policy or reuse existing ClusterRoles by name.
+	// Exactly one must be set.
+	// +kubebuilder:validation:XOR=policy;clusterRoles
+	Policy       []Policy `json:"policy,omitempty"`
+	ClusterRoles []string `json:"clusterRoles,omitempty"`
+
+	// ClusterRoleScope binds spec.clusterRoles through RoleBindings in the selected namespaces
+	// instead of a cluster-wide ClusterRoleBinding. Only valid together with clusterRoles.
+	// +optional
+	ClusterRoleScope *NamespaceScope `json:"clusterRoleScope,omitempty"`
+
+	// Approval requirements.
+	// +optional
+	Approval *ApprovalSpec `json:"approval,omitempty"`
+
+	// Schedule defines the breakglass activation window with optional cron recurrence.
+	Schedule ScheduleSpec `json:"schedule"`
+
+	// A clear, human-readable justification is required.
+	// +kubebuilder:validation:MinLength=1
+	Justification string `json:"justification"`
+
+	// Optional external ticket identifier.
+	// +optional
+	TicketID string `json:"ticketID,omitempty"`
+
+	// DryRun renders the RBAC the request would create into status.plan without granting anything.
+	// The request stays Pending until dryRun is cleared.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Revocation ends access early. Setting it revokes any active access, stops future
+	// recurring activations and moves the request to Revoked. It cannot be removed once set.
+	// +optional
+	Revocation *RevocationSpec `json:"revocation,omitempty"`
+
+	// Extensions requests more time for the currently active window. Entries can only be
+	// appended while access is active and go through the approval rules again if the
+	// request required approval.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Extensions []ExtensionRequest `json:"extensions,omitempty"`
+}
+
+// ExtensionRequest asks to push the end of the active window further out.
+type ExtensionRequest struct {
+	// Name identifies the extension. BreakglassApprovals reference it via spec.extension.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Duration is added to the end of the active window.
+	Duration metav1.Duration `json:"duration"`
+
+	// Reason explains why more time is needed.
+	// +kubebuilder:validation:MinLength=1
+	Reason string `json:"reason"`
+
+	// RequestedBy is stamped by the admission webhook from the authenticated request.
+	// +optional
+	RequestedBy *UserIdentity `json:"requestedBy,omitempty"`
+}
+
+// RevocationSpec records a manual early revocation of a breakglass request.
+type RevocationSpec struct {
+	// Reason explains why access was revoked.
+	// +kubebuilder:validation:MinLength=1
+	Reason string `json:"reason"`
+
+	// RevokedBy is stamped by the admission webhook from the authenticated request.
+	// Any value supplied by the client is overwritten.
+	// +optional
+	RevokedBy *UserIdentity `json:"revokedBy,omitempty"`
+}
+
+// Policy defines RBAC rules with optional namespace scoping.
+type Policy struct {
+	// Namespace scope for this policy. Empty means cluster-scoped.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// RBAC policy rules.
+	// +kubebuilder:validation:MinItems=1
+	Rules []rbacv1.PolicyRule `json:"rules"`
+}
+
+// NamespaceScope selects the namespaces a ClusterRole is bound in.
+// The union of Namespaces and the namespaces matching Selector is used.
+type NamespaceScope struct {
+	// Namespaces lists namespaces by name.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector matches namespaces by label. It is evaluated each time access is granted.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// ApprovalSpec defines approval configuration for the breakglass request.
+type ApprovalSpec struct {
+	// Required indicates whether manual approval is required.
+	// +kubebuilder:default=true
+	Required bool `json:"required"`
+
+	// MinApprovals is the number of distinct approvers needed before access is granted.
+	// The requester never counts towards this number.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MinApprovals int32 `json:"minApprovals,omitempty"`
+
+	// Users that may approve or deny the request.
+	// If both Users and Groups are empty any authenticated user other than the requester may decide.
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Groups whose members may approve or deny the request.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ScheduleSpec defines the timing for breakglass activation.
+type ScheduleSpec struct {
+	// Start time (RFC3339 format) when schedule becomes active used for oneshots.
+	// +optional
+	Start metav1.Time `json:"start,omitempty"`
+
+	// Until ends the schedule. No window opens at or after it, and a window that is
+	// still active at Until is cut short.
+	// +optional
+	Until *metav1.Time `json:"until,omitempty"`
+
+	// Duration of each activation window. If omitted, a one-shot window lasts until
+	// Until, or until the request is revoked.
+	// +optional
+	Duration metav1.Duration `json:"duration,omitempty"`
+
+	// Optional cron schedule for recurring activations (min hour dom month ).
+	// If omitted, the schedule is a one-time activation.
+	// +optional
+	Cron string `json:"cron,omitempty"`
+
+	// Time zone location (IANA format, e.g., "America/New_York"). Defaults to UTC.
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Maximum activations. Schedule stops after reaching this count.
+	// Only applicable if Cron is set.
+	// +optional
+	MaxActivations *int32 `json:"maxActivations,omitempty"`
+}
+
+// UserIdentity is an authenticated Kubernetes user as seen by the API server.
+type UserIdentity struct {
+	// Username is the name of the authenticated user.
+	Username string `json:"username"`
+
+	// UID is a unique value that identifies the user across time.
+	// +optional
+	UID string `json:"uid,omitempty"`
+
+	// Groups the user belonged to when the request was admitted.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
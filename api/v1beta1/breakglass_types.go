@@ -0,0 +1,32 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Breakglass is the Schema for the breakglasses API
+type Breakglass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BreakglassSpec   `json:"spec,omitempty"`
+	Status BreakglassStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BreakglassList contains a list of Breakglass requests.
+type BreakglassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Breakglass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Breakglass{}, &BreakglassList{})
+}
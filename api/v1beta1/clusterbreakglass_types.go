@@ -0,0 +1,33 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterBreakglass is a cluster-scoped Breakglass request.
+type ClusterBreakglass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BreakglassSpec   `json:"spec,omitempty"`
+	Status BreakglassStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterBreakglassList contains a list of ClusterBreakglass requests.
+type ClusterBreakglassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterBreakglass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterBreakglass{}, &ClusterBreakglassList{})
+}
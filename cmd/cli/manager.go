@@ -29,6 +29,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -37,13 +38,17 @@ import (
 	"github.com/spf13/cobra"
 
 	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	accessv1beta1 "github.com/cloud-nimbus/firedoor/api/v1beta1"
 	"github.com/cloud-nimbus/firedoor/internal/clock"
 	"github.com/cloud-nimbus/firedoor/internal/config"
 	"github.com/cloud-nimbus/firedoor/internal/constants"
 	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass"
 	"github.com/cloud-nimbus/firedoor/internal/errors"
+	"github.com/cloud-nimbus/firedoor/internal/operator/rbac"
 	"github.com/cloud-nimbus/firedoor/internal/operator/recurring"
 	"github.com/cloud-nimbus/firedoor/internal/telemetry"
+	"github.com/cloud-nimbus/firedoor/internal/ticket"
+	webhookv1alpha1 "github.com/cloud-nimbus/firedoor/internal/webhook/v1alpha1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -62,6 +67,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(accessv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(accessv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -109,7 +115,11 @@ func runManager(ctx context.Context, cfg *config.Config, logLevel string) error
 		})
 	}
 
-	webhookServer := webhook.NewServer(webhook.Options{TLSOpts: tlsOpts})
+	webhookServer := webhook.NewServer(webhook.Options{
+		Port:    cfg.Webhook.Port,
+		CertDir: cfg.Webhook.CertDir,
+		TLSOpts: tlsOpts,
+	})
 	metricsServerOptions := metricsserver.Options{
 		BindAddress:   metricsAddr,
 		SecureServing: secureMetrics,
@@ -126,22 +136,77 @@ func runManager(ctx context.Context, cfg *config.Config, logLevel string) error
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       constants.LeaderElectionID,
+		// Only RBAC objects firedoor created are cached
+		Cache: cache.Options{ByObject: rbac.CacheByObject()},
 	})
 	if err != nil {
 		setupLog.Error(err, errors.ErrStartManager)
 		return err
 	}
 
+	tickets, err := ticket.New(cfg.Tickets)
+	if err != nil {
+		setupLog.Error(err, "unable to create ticket provider", "provider", cfg.Tickets.Provider)
+		return err
+	}
+
 	// Register the Breakglass controller
 	if err := breakglass.NewBreakglassReconciler(
 		mgr.GetClient(),
 		mgr.GetScheme(),
+		breakglass.WithConfig(cfg),
 		breakglass.WithRecurringManager(recurring.New(clock.SimpleClock{})),
+		breakglass.WithTicketProvider(tickets),
 	).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create Breakglass controller")
 		return err
 	}
 
+	// Register the ClusterBreakglass controller
+	if err := breakglass.NewClusterBreakglassReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		breakglass.WithConfig(cfg),
+		breakglass.WithRecurringManager(recurring.New(clock.SimpleClock{})),
+		breakglass.WithTicketProvider(tickets),
+	).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create ClusterBreakglass controller")
+		return err
+	}
+
+	// Collect RBAC left behind by force-deleted requests or partial revokes
+	if cfg.Controller.OrphanGCInterval > 0 {
+		if err := breakglass.NewOrphanCollector(
+			mgr.GetClient(),
+			cfg.Controller.OrphanGCInterval,
+			cfg.Controller.OrphanGCMinAge,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create orphaned RBAC collector")
+			return err
+		}
+	}
+
+	if cfg.Webhook.Enabled {
+		operator := rbac.New(
+			mgr.GetClient(),
+			rbac.WithPrivilegeEscalation(cfg.Controller.PrivilegeEscalation),
+			rbac.WithAdmissionWebhook(true),
+			rbac.WithReader(mgr.GetAPIReader()),
+		)
+		if err := webhookv1alpha1.SetupBreakglassWebhookWithManager(mgr, cfg, operator); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Breakglass")
+			return err
+		}
+		if err := webhookv1alpha1.SetupClusterBreakglassWebhookWithManager(mgr, cfg, operator); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterBreakglass")
+			return err
+		}
+		if err := webhookv1alpha1.SetupBreakglassApprovalWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BreakglassApproval")
+			return err
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, errors.ErrSetupHealthCheck)
 		return err
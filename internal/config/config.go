@@ -34,6 +34,10 @@ type Config struct {
 	Controller   ControllerConfig   `mapstructure:"controller"`
 	Server       ServerConfig       `mapstructure:"server"`
 	Alertmanager AlertmanagerConfig `mapstructure:"alertmanager"`
+	Webhook      WebhookConfig      `mapstructure:"webhook"`
+	Breakglass   BreakglassConfig   `mapstructure:"breakglass"`
+	Denylist     DenylistConfig     `mapstructure:"denylist"`
+	Tickets      TicketConfig       `mapstructure:"tickets"`
 }
 
 // OTelConfig holds OpenTelemetry configuration settings
@@ -75,12 +79,28 @@ type HTTPConfig struct {
 
 // ControllerConfig holds controller-specific configuration
 type ControllerConfig struct {
-	ReconcileTimeout    time.Duration `mapstructure:"reconcile_timeout"`
-	RetryDelay          time.Duration `mapstructure:"retry_delay"`
-	PrivilegeEscalation bool          `mapstructure:"privilege_escalation"`
-	Backoff             time.Duration `mapstructure:"backoff"`
+	ReconcileTimeout         time.Duration `mapstructure:"reconcile_timeout"`
+	RetryDelay               time.Duration `mapstructure:"retry_delay"`
+	PrivilegeEscalation      bool          `mapstructure:"privilege_escalation"`
+	Backoff                  time.Duration `mapstructure:"backoff"`
+	RestrictToNamespace      bool          `mapstructure:"restrict_to_namespace"`
+	ClusterApprovalNamespace string        `mapstructure:"cluster_approval_namespace"`
+	// DriftAction is what happens when granted RBAC objects are edited or deleted during an active
+	// window: "repair" restores them, "revoke" revokes the request as a security event.
+	DriftAction string `mapstructure:"drift_action"`
+	// OrphanGCInterval is how often RBAC objects left behind by deleted or inactive requests are
+	// collected. Zero disables the collector.
+	OrphanGCInterval time.Duration `mapstructure:"orphan_gc_interval"`
+	// OrphanGCMinAge spares objects younger than this, which may belong to a grant still in progress.
+	OrphanGCMinAge time.Duration `mapstructure:"orphan_gc_min_age"`
 }
 
+// Drift actions
+const (
+	DriftActionRepair = "repair"
+	DriftActionRevoke = "revoke"
+)
+
 // ServerConfig holds server-specific configuration
 type ServerConfig struct {
 	MetricsBindAddress     string `mapstructure:"metrics_bind_address"`
@@ -88,6 +108,98 @@ type ServerConfig struct {
 	LeaderElect            bool   `mapstructure:"leader_elect"`
 }
 
+// WebhookConfig holds admission webhook server configuration
+type WebhookConfig struct {
+	// Enabled registers the admission webhooks with the manager
+	Enabled bool `mapstructure:"enabled"`
+
+	// Port the webhook server listens on
+	Port int `mapstructure:"port"`
+
+	// CertDir contains tls.crt and tls.key for the webhook server
+	CertDir string `mapstructure:"cert_dir"`
+}
+
+// BreakglassConfig holds defaults applied to new Breakglass requests by the mutating webhook
+type BreakglassConfig struct {
+	// DefaultDuration is used when a request omits spec.schedule.duration. Zero leaves it unset.
+	DefaultDuration time.Duration `mapstructure:"default_duration"`
+
+	// DefaultLocation is used when a request omits spec.schedule.location.
+	DefaultLocation string `mapstructure:"default_location"`
+
+	// ApprovalRequired adds spec.approval.required=true to requests that omit spec.approval.
+	ApprovalRequired bool `mapstructure:"approval_required"`
+}
+
+// DenylistConfig lists ClusterRoles and subjects that are never bound unless the admitting
+// BreakglassPolicy sets allowProtected. Entries ending in "*" match by prefix.
+type DenylistConfig struct {
+	// ClusterRoles that may not be requested via spec.clusterRoles.
+	ClusterRoles []string `mapstructure:"cluster_roles"`
+
+	// Groups that may not be used as a Group subject.
+	Groups []string `mapstructure:"groups"`
+
+	// Users that may not be used as a User subject.
+	Users []string `mapstructure:"users"`
+
+	// AllowWildcardRules permits policy rules granting "*" verbs on "*" resources.
+	AllowWildcardRules bool `mapstructure:"allow_wildcard_rules"`
+}
+
+// TicketConfig configures the ticket system spec.ticketID is checked against
+type TicketConfig struct {
+	// Provider selects the ticket system: "http" or "file". Empty disables ticket checks.
+	Provider string `mapstructure:"provider"`
+
+	// Required denies requests that do not link a ticket.
+	Required bool `mapstructure:"required"`
+
+	// RevokeOnClose revokes active and scheduled access once the linked ticket is closed.
+	RevokeOnClose bool `mapstructure:"revoke_on_close"`
+
+	// PollInterval bounds how long a closed ticket goes unnoticed while access is active or scheduled.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// ClosedStatuses are the ticket statuses treated as closed, compared case-insensitively.
+	ClosedStatuses []string `mapstructure:"closed_statuses"`
+
+	// HTTP configures the generic HTTP/JSON provider.
+	HTTP TicketHTTPConfig `mapstructure:"http"`
+
+	// File configures the file provider.
+	File TicketFileConfig `mapstructure:"file"`
+}
+
+// TicketHTTPConfig configures a ticket system reached over a JSON API
+type TicketHTTPConfig struct {
+	// URL of a ticket, with {id} replaced by the escaped ticket ID.
+	URL string `mapstructure:"url"`
+
+	// CommentURL receives a POST of {"body": "..."} per comment, with {id} replaced by the escaped
+	// ticket ID. Empty disables comments.
+	CommentURL string `mapstructure:"comment_url"`
+
+	// StatusField is the dotted path of the status in the ticket JSON, e.g. "fields.status.name".
+	StatusField string `mapstructure:"status_field"`
+
+	// Timeout for ticket API requests
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// TokenFile contains a bearer token sent with every request.
+	TokenFile string `mapstructure:"token_file"`
+
+	// BasicAuth configuration, used when no token file is set
+	BasicAuth BasicAuthConfig `mapstructure:"basic_auth"`
+}
+
+// TicketFileConfig configures a ticket system backed by a local file, for tests and development
+type TicketFileConfig struct {
+	// Path of a YAML or JSON file mapping ticket IDs to their status. It is read on every lookup.
+	Path string `mapstructure:"path"`
+}
+
 // AlertmanagerConfig holds Alertmanager configuration
 type AlertmanagerConfig struct {
 	// Enabled determines if Alertmanager integration is active
@@ -222,6 +334,11 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("controller.retry_delay", defaults.Controller.RetryDelay)
 	v.SetDefault("controller.privilege_escalation", defaults.Controller.PrivilegeEscalation)
 	v.SetDefault("controller.backoff", 10*time.Second)
+	v.SetDefault("controller.restrict_to_namespace", defaults.Controller.RestrictToNamespace)
+	v.SetDefault("controller.cluster_approval_namespace", defaults.Controller.ClusterApprovalNamespace)
+	v.SetDefault("controller.drift_action", defaults.Controller.DriftAction)
+	v.SetDefault("controller.orphan_gc_interval", defaults.Controller.OrphanGCInterval)
+	v.SetDefault("controller.orphan_gc_min_age", defaults.Controller.OrphanGCMinAge)
 
 	// Server defaults
 	v.SetDefault("server.metrics_bind_address", defaults.Server.MetricsBindAddress)
@@ -236,6 +353,35 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("alertmanager.alert.severity", "warning")
 	v.SetDefault("alertmanager.alert.summary", "Breakglass access is active")
 	v.SetDefault("alertmanager.alert.description", "A breakglass access request is currently active")
+
+	// Webhook defaults
+	v.SetDefault("webhook.enabled", defaults.Webhook.Enabled)
+	v.SetDefault("webhook.port", defaults.Webhook.Port)
+	v.SetDefault("webhook.cert_dir", defaults.Webhook.CertDir)
+
+	// Breakglass request defaults
+	v.SetDefault("breakglass.default_duration", defaults.Breakglass.DefaultDuration)
+	v.SetDefault("breakglass.default_location", defaults.Breakglass.DefaultLocation)
+	v.SetDefault("breakglass.approval_required", defaults.Breakglass.ApprovalRequired)
+
+	// Denylist defaults
+	v.SetDefault("denylist.cluster_roles", defaults.Denylist.ClusterRoles)
+	v.SetDefault("denylist.groups", defaults.Denylist.Groups)
+	v.SetDefault("denylist.users", defaults.Denylist.Users)
+	v.SetDefault("denylist.allow_wildcard_rules", defaults.Denylist.AllowWildcardRules)
+
+	// Ticket system defaults
+	v.SetDefault("tickets.provider", defaults.Tickets.Provider)
+	v.SetDefault("tickets.required", defaults.Tickets.Required)
+	v.SetDefault("tickets.revoke_on_close", defaults.Tickets.RevokeOnClose)
+	v.SetDefault("tickets.poll_interval", defaults.Tickets.PollInterval)
+	v.SetDefault("tickets.closed_statuses", defaults.Tickets.ClosedStatuses)
+	v.SetDefault("tickets.http.url", "")
+	v.SetDefault("tickets.http.comment_url", "")
+	v.SetDefault("tickets.http.status_field", defaults.Tickets.StatusField)
+	v.SetDefault("tickets.http.timeout", defaults.Tickets.Timeout)
+	v.SetDefault("tickets.http.token_file", "")
+	v.SetDefault("tickets.file.path", "")
 }
 
 // Validate checks that all configuration values are valid
@@ -252,6 +398,35 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("metrics.duration_bucket_count must be greater than 0")
 	}
 
+	if c.Controller.ClusterApprovalNamespace == "" {
+		return fmt.Errorf("controller.cluster_approval_namespace must be set")
+	}
+
+	switch c.Controller.DriftAction {
+	case DriftActionRepair, DriftActionRevoke:
+	default:
+		return fmt.Errorf("controller.drift_action must be %q or %q, got %q",
+			DriftActionRepair, DriftActionRevoke, c.Controller.DriftAction)
+	}
+
+	if c.Controller.OrphanGCInterval < 0 || c.Controller.OrphanGCMinAge < 0 {
+		return fmt.Errorf("controller.orphan_gc_interval and controller.orphan_gc_min_age must not be negative")
+	}
+
+	if c.Breakglass.DefaultDuration < 0 {
+		return fmt.Errorf("breakglass.default_duration must not be negative")
+	}
+
+	if c.Breakglass.DefaultLocation != "" {
+		if _, err := time.LoadLocation(c.Breakglass.DefaultLocation); err != nil {
+			return fmt.Errorf("invalid breakglass.default_location: %w", err)
+		}
+	}
+
+	if err := c.Tickets.Validate(); err != nil {
+		return err
+	}
+
 	// Validate log level
 	if c.OTel.LogLevel != "" {
 		validLevels := map[string]bool{
@@ -269,6 +444,35 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// Validate checks that the selected ticket provider is fully configured
+func (c *TicketConfig) Validate() error {
+	switch c.Provider {
+	case "":
+		return nil
+	case "http":
+		if c.HTTP.URL == "" {
+			return fmt.Errorf("tickets.http.url must be set for the http provider")
+		}
+		if !strings.Contains(c.HTTP.URL, "{id}") {
+			return fmt.Errorf("tickets.http.url must contain {id}")
+		}
+		if c.HTTP.CommentURL != "" && !strings.Contains(c.HTTP.CommentURL, "{id}") {
+			return fmt.Errorf("tickets.http.comment_url must contain {id}")
+		}
+	case "file":
+		if c.File.Path == "" {
+			return fmt.Errorf("tickets.file.path must be set for the file provider")
+		}
+	default:
+		return fmt.Errorf("invalid tickets.provider: %s (valid providers: http, file)", c.Provider)
+	}
+
+	if c.PollInterval <= 0 {
+		return fmt.Errorf("tickets.poll_interval must be greater than 0")
+	}
+	return nil
+}
+
 // GetDurationBuckets returns the histogram buckets for duration metrics
 func (c *Config) GetDurationBuckets() []float64 {
 	buckets := make([]float64, c.Metrics.DurationBucketCount)
@@ -312,10 +516,15 @@ func NewDefaultConfig() *Config {
 			EnableHTTP2: defaults.HTTP.EnableHTTP2,
 		},
 		Controller: ControllerConfig{
-			ReconcileTimeout:    defaults.Controller.ReconcileTimeout,
-			RetryDelay:          defaults.Controller.RetryDelay,
-			PrivilegeEscalation: defaults.Controller.PrivilegeEscalation,
-			Backoff:             10 * time.Second,
+			ReconcileTimeout:         defaults.Controller.ReconcileTimeout,
+			RetryDelay:               defaults.Controller.RetryDelay,
+			PrivilegeEscalation:      defaults.Controller.PrivilegeEscalation,
+			Backoff:                  10 * time.Second,
+			RestrictToNamespace:      defaults.Controller.RestrictToNamespace,
+			ClusterApprovalNamespace: defaults.Controller.ClusterApprovalNamespace,
+			DriftAction:              defaults.Controller.DriftAction,
+			OrphanGCInterval:         defaults.Controller.OrphanGCInterval,
+			OrphanGCMinAge:           defaults.Controller.OrphanGCMinAge,
 		},
 		Server: ServerConfig{
 			MetricsBindAddress:     defaults.Server.MetricsBindAddress,
@@ -339,5 +548,32 @@ func NewDefaultConfig() *Config {
 				Description: "A breakglass access request is currently active",
 			},
 		},
+		Webhook: WebhookConfig{
+			Enabled: defaults.Webhook.Enabled,
+			Port:    defaults.Webhook.Port,
+			CertDir: defaults.Webhook.CertDir,
+		},
+		Breakglass: BreakglassConfig{
+			DefaultDuration:  defaults.Breakglass.DefaultDuration,
+			DefaultLocation:  defaults.Breakglass.DefaultLocation,
+			ApprovalRequired: defaults.Breakglass.ApprovalRequired,
+		},
+		Denylist: DenylistConfig{
+			ClusterRoles:       defaults.Denylist.ClusterRoles,
+			Groups:             defaults.Denylist.Groups,
+			Users:              defaults.Denylist.Users,
+			AllowWildcardRules: defaults.Denylist.AllowWildcardRules,
+		},
+		Tickets: TicketConfig{
+			Provider:       defaults.Tickets.Provider,
+			Required:       defaults.Tickets.Required,
+			RevokeOnClose:  defaults.Tickets.RevokeOnClose,
+			PollInterval:   defaults.Tickets.PollInterval,
+			ClosedStatuses: defaults.Tickets.ClosedStatuses,
+			HTTP: TicketHTTPConfig{
+				StatusField: defaults.Tickets.StatusField,
+				Timeout:     defaults.Tickets.Timeout,
+			},
+		},
 	}
 }
@@ -140,6 +140,44 @@ var _ = Describe("Config", func() {
 				Expect(cfg.Metrics.BindAddress).To(Equal(":8888"))
 			})
 		})
+
+		Context("with a ticket provider", func() {
+			It("should reject an http provider without a ticket URL", func() {
+				v := viper.New()
+				v.Set("tickets.provider", "http")
+
+				_, err := LoadWithViper(v)
+				Expect(err).To(MatchError(ContainSubstring("tickets.http.url")))
+			})
+
+			It("should load the file provider with defaults", func() {
+				v := viper.New()
+				v.Set("tickets.provider", "file")
+				v.Set("tickets.file.path", "/etc/firedoor/tickets.yaml")
+
+				cfg, err := LoadWithViper(v)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Tickets.RevokeOnClose).To(BeTrue())
+				Expect(cfg.Tickets.ClosedStatuses).To(ContainElement("resolved"))
+				Expect(cfg.Tickets.HTTP.StatusField).To(Equal("status"))
+			})
+		})
+
+		Context("with a drift action", func() {
+			It("should default to repairing drift", func() {
+				cfg, err := LoadWithViper(viper.New())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.Controller.DriftAction).To(Equal(DriftActionRepair))
+			})
+
+			It("should reject an unknown drift action", func() {
+				v := viper.New()
+				v.Set("controller.drift_action", "ignore")
+
+				_, err := LoadWithViper(v)
+				Expect(err).To(MatchError(ContainSubstring("controller.drift_action")))
+			})
+		})
 	})
 
 	Describe("OTelConfig", func() {
@@ -28,6 +28,10 @@ type Defaults struct {
 	Controller   ControllerDefaults
 	Server       ServerDefaults
 	Alertmanager AlertmanagerDefaults
+	Webhook      WebhookDefaults
+	Breakglass   BreakglassDefaults
+	Denylist     DenylistDefaults
+	Tickets      TicketDefaults
 }
 
 // OTelDefaults holds OpenTelemetry default values
@@ -74,10 +78,15 @@ type HTTPDefaults struct {
 
 // ControllerDefaults holds controller default values
 type ControllerDefaults struct {
-	ReconcileTimeout    time.Duration
-	RetryDelay          time.Duration
-	PrivilegeEscalation bool
-	Backoff             time.Duration
+	ReconcileTimeout         time.Duration
+	RetryDelay               time.Duration
+	PrivilegeEscalation      bool
+	Backoff                  time.Duration
+	RestrictToNamespace      bool
+	ClusterApprovalNamespace string
+	DriftAction              string
+	OrphanGCInterval         time.Duration
+	OrphanGCMinAge           time.Duration
 }
 
 // ServerDefaults holds server default values
@@ -93,6 +102,39 @@ type AlertmanagerDefaults struct {
 	Endpoint string
 }
 
+// WebhookDefaults holds admission webhook default values
+type WebhookDefaults struct {
+	Enabled bool
+	Port    int
+	CertDir string
+}
+
+// BreakglassDefaults holds Breakglass request default values
+type BreakglassDefaults struct {
+	DefaultDuration  time.Duration
+	DefaultLocation  string
+	ApprovalRequired bool
+}
+
+// DenylistDefaults holds the default protected roles and subjects
+type DenylistDefaults struct {
+	ClusterRoles       []string
+	Groups             []string
+	Users              []string
+	AllowWildcardRules bool
+}
+
+// TicketDefaults holds ticket system default values
+type TicketDefaults struct {
+	Provider       string
+	Required       bool
+	RevokeOnClose  bool
+	PollInterval   time.Duration
+	ClosedStatuses []string
+	StatusField    string
+	Timeout        time.Duration
+}
+
 // NewDefaults returns the default configuration values
 func NewDefaults() *Defaults {
 	return &Defaults{
@@ -126,10 +168,15 @@ func NewDefaults() *Defaults {
 			EnableHTTP2: false,
 		},
 		Controller: ControllerDefaults{
-			ReconcileTimeout:    30 * time.Second,
-			RetryDelay:          1 * time.Second,
-			PrivilegeEscalation: false,
-			Backoff:             10 * time.Second,
+			ReconcileTimeout:         30 * time.Second,
+			RetryDelay:               1 * time.Second,
+			PrivilegeEscalation:      false,
+			Backoff:                  10 * time.Second,
+			RestrictToNamespace:      false,
+			ClusterApprovalNamespace: "firedoor-system",
+			DriftAction:              DriftActionRepair,
+			OrphanGCInterval:         10 * time.Minute,
+			OrphanGCMinAge:           5 * time.Minute,
 		},
 		Server: ServerDefaults{
 			MetricsBindAddress:     ":8080",
@@ -140,5 +187,30 @@ func NewDefaults() *Defaults {
 			Enabled:  false,
 			Endpoint: "http://alertmanager.telemetry-system.svc.cluster.local:9093",
 		},
+		Webhook: WebhookDefaults{
+			Enabled: false,
+			Port:    9443,
+			CertDir: "/tmp/k8s-webhook-server/serving-certs",
+		},
+		Breakglass: BreakglassDefaults{
+			DefaultDuration:  0,
+			DefaultLocation:  "",
+			ApprovalRequired: false,
+		},
+		Denylist: DenylistDefaults{
+			ClusterRoles:       []string{"cluster-admin"},
+			Groups:             []string{"system:masters", "system:*"},
+			Users:              []string{"system:*"},
+			AllowWildcardRules: false,
+		},
+		Tickets: TicketDefaults{
+			Provider:       "", // Ticket checks are opt-in
+			Required:       false,
+			RevokeOnClose:  true,
+			PollInterval:   5 * time.Minute,
+			ClosedStatuses: []string{"closed", "resolved", "done", "cancelled"},
+			StatusField:    "status",
+			Timeout:        10 * time.Second,
+		},
 	}
 }
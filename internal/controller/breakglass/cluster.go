@@ -0,0 +1,242 @@
+package breakglass
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/handlers"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
+	"github.com/cloud-nimbus/firedoor/internal/operator/rbac"
+	"github.com/cloud-nimbus/firedoor/internal/policy"
+)
+
+// ClusterBreakglassReconciler watches ClusterBreakglass resources. Requests are reconciled by the
+// Breakglass reconciler and handlers, which see them as Breakglass objects without a namespace.
+type ClusterBreakglassReconciler struct {
+	*BreakglassReconciler
+}
+
+// +kubebuilder:rbac:groups=access.cloudnimbus.io,resources=clusterbreakglasses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=access.cloudnimbus.io,resources=clusterbreakglasses/status,verbs=get;update;patch
+
+// NewClusterBreakglassReconciler creates a new ClusterBreakglassReconciler with the given options.
+func NewClusterBreakglassReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	opts ...Option,
+) *ClusterBreakglassReconciler {
+	return &ClusterBreakglassReconciler{NewBreakglassReconciler(clusterClient{Client: c}, scheme, opts...)}
+}
+
+func (r *ClusterBreakglassReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.recorder == nil {
+		r.recorder = mgr.GetEventRecorderFor("clusterbreakglass-controller")
+	}
+	r.recorder = clusterRecorder{EventRecorder: r.recorder}
+	r.setupHandler(mgr, "clusterbreakglass-controller")
+	if err := mgr.Add(r.deadlines); err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&accessv1alpha1.ClusterBreakglass{}).
+		Watches(&accessv1alpha1.BreakglassApproval{}, handler.EnqueueRequestsFromMapFunc(approvalToClusterBreakglass)).
+		Watches(
+			&accessv1alpha1.BreakglassPolicy{},
+			handler.EnqueueRequestsFromMapFunc(policyToClusterBreakglass(r.Client)),
+		).
+		WatchesRawSource(r.deadlines.Source())
+	return watchGrantedRBAC(b, rbacToClusterBreakglass).Complete(r)
+}
+
+// approvalToClusterBreakglass maps a BreakglassApproval to the ClusterBreakglass it decides on.
+func approvalToClusterBreakglass(_ context.Context, obj client.Object) []reconcile.Request {
+	approval, ok := obj.(*accessv1alpha1.BreakglassApproval)
+	if !ok || approval.Spec.BreakglassRef == "" ||
+		handlers.ApprovalRefKind(approval) != accessv1alpha1.RefKindClusterBreakglass {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: approval.Spec.BreakglassRef}}}
+}
+
+// policyToClusterBreakglass maps a BreakglassPolicy that governs cluster-scoped requests to the unfinished
+// ClusterBreakglass requests.
+func policyToClusterBreakglass(reader client.Reader) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		p, ok := obj.(*accessv1alpha1.BreakglassPolicy)
+		if !ok || !policy.Governs(p, "") {
+			return nil
+		}
+		var list accessv1alpha1.ClusterBreakglassList
+		if err := reader.List(ctx, &list); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "unable to list cluster breakglass requests for policy", "policy", p.Name)
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range list.Items {
+			if usecases.IsTerminalPhase(usecases.CurrentPhase(list.Items[i].ToBreakglass())) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: list.Items[i].Name}})
+		}
+		return requests
+	}
+}
+
+// rbacToClusterBreakglass maps an RBAC object carrying breakglass labels without a namespace to the
+// ClusterBreakglass it was created for.
+func rbacToClusterBreakglass(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels[rbac.LabelUID] == "" || labels[rbac.LabelName] == "" || labels[rbac.LabelNamespace] != "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: labels[rbac.LabelName]}}}
+}
+
+// clusterClient reads and writes ClusterBreakglass objects on behalf of code that handles them as
+// Breakglass objects without a namespace. All other objects are passed through.
+type clusterClient struct {
+	client.Client
+}
+
+// Get implements client.Reader.
+func (c clusterClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	bg, ok := obj.(*accessv1alpha1.Breakglass)
+	if !ok || key.Namespace != "" {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	var cbg accessv1alpha1.ClusterBreakglass
+	if err := c.Client.Get(ctx, key, &cbg, opts...); err != nil {
+		return err
+	}
+	*bg = *cbg.ToBreakglass()
+	return nil
+}
+
+// Update implements client.Writer.
+func (c clusterClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	bg, ok := obj.(*accessv1alpha1.Breakglass)
+	if !ok || !bg.IsClusterScoped() {
+		return c.Client.Update(ctx, obj, opts...)
+	}
+	var cbg accessv1alpha1.ClusterBreakglass
+	cbg.FromBreakglass(bg)
+	if err := c.Client.Update(ctx, &cbg, opts...); err != nil {
+		return err
+	}
+	*bg = *cbg.ToBreakglass()
+	return nil
+}
+
+// Patch implements client.Writer.
+func (c clusterClient) Patch(
+	ctx context.Context,
+	obj client.Object,
+	patch client.Patch,
+	opts ...client.PatchOption,
+) error {
+	bg, ok := obj.(*accessv1alpha1.Breakglass)
+	if !ok || !bg.IsClusterScoped() {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	var cbg accessv1alpha1.ClusterBreakglass
+	cbg.FromBreakglass(bg)
+	if err := c.Client.Patch(ctx, &cbg, patch, opts...); err != nil {
+		return err
+	}
+	*bg = *cbg.ToBreakglass()
+	return nil
+}
+
+// Status implements client.StatusClient.
+func (c clusterClient) Status() client.SubResourceWriter {
+	return clusterStatusWriter{SubResourceWriter: c.Client.Status()}
+}
+
+// clusterStatusWriter writes the status of ClusterBreakglass objects handled as Breakglass objects.
+type clusterStatusWriter struct {
+	client.SubResourceWriter
+}
+
+// Update implements client.SubResourceWriter.
+func (w clusterStatusWriter) Update(
+	ctx context.Context,
+	obj client.Object,
+	opts ...client.SubResourceUpdateOption,
+) error {
+	bg, ok := obj.(*accessv1alpha1.Breakglass)
+	if !ok || !bg.IsClusterScoped() {
+		return w.SubResourceWriter.Update(ctx, obj, opts...)
+	}
+	var cbg accessv1alpha1.ClusterBreakglass
+	cbg.FromBreakglass(bg)
+	if err := w.SubResourceWriter.Update(ctx, &cbg, opts...); err != nil {
+		return err
+	}
+	*bg = *cbg.ToBreakglass()
+	return nil
+}
+
+// Patch implements client.SubResourceWriter.
+func (w clusterStatusWriter) Patch(
+	ctx context.Context,
+	obj client.Object,
+	patch client.Patch,
+	opts ...client.SubResourcePatchOption,
+) error {
+	bg, ok := obj.(*accessv1alpha1.Breakglass)
+	if !ok || !bg.IsClusterScoped() {
+		return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+	}
+	var cbg accessv1alpha1.ClusterBreakglass
+	cbg.FromBreakglass(bg)
+	if err := w.SubResourceWriter.Patch(ctx, &cbg, patch, opts...); err != nil {
+		return err
+	}
+	*bg = *cbg.ToBreakglass()
+	return nil
+}
+
+// clusterRecorder records events emitted for Breakglass objects without a namespace
+// against the ClusterBreakglass they were converted from.
+type clusterRecorder struct {
+	record.EventRecorder
+}
+
+// Event implements record.EventRecorder.
+func (r clusterRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.EventRecorder.Event(clusterObject(object), eventtype, reason, message)
+}
+
+// Eventf implements record.EventRecorder.
+func (r clusterRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	r.EventRecorder.Eventf(clusterObject(object), eventtype, reason, messageFmt, args...)
+}
+
+// AnnotatedEventf implements record.EventRecorder.
+func (r clusterRecorder) AnnotatedEventf(
+	object runtime.Object,
+	annotations map[string]string,
+	eventtype, reason, messageFmt string,
+	args ...any,
+) {
+	r.EventRecorder.AnnotatedEventf(clusterObject(object), annotations, eventtype, reason, messageFmt, args...)
+}
+
+// clusterObject returns the ClusterBreakglass for a Breakglass without a namespace, or object itself.
+func clusterObject(object runtime.Object) runtime.Object {
+	bg, ok := object.(*accessv1alpha1.Breakglass)
+	if !ok || !bg.IsClusterScoped() {
+		return object
+	}
+	var cbg accessv1alpha1.ClusterBreakglass
+	cbg.FromBreakglass(bg)
+	return &cbg
+}
@@ -0,0 +1,148 @@
+package breakglass
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/operator/rbac"
+)
+
+func TestClusterClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	cbg := &accessv1alpha1.ClusterBreakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec:       accessv1alpha1.BreakglassSpec{ClusterRoles: []string{"view"}},
+	}
+	namespaced := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: "default"},
+		Spec:       accessv1alpha1.BreakglassSpec{ClusterRoles: []string{"edit"}},
+	}
+	inner := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&accessv1alpha1.ClusterBreakglass{}, &accessv1alpha1.Breakglass{}).
+		WithObjects(cbg, namespaced).
+		Build()
+	c := clusterClient{Client: inner}
+	ctx := context.Background()
+
+	var bg accessv1alpha1.Breakglass
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "platform"}, &bg))
+	assert.True(t, bg.IsClusterScoped())
+	assert.Equal(t, []string{"view"}, bg.Spec.ClusterRoles)
+
+	bg.Finalizers = []string{finalizer}
+	require.NoError(t, c.Update(ctx, &bg))
+	bg.Status.ApprovedBy = "system"
+	require.NoError(t, c.Status().Update(ctx, &bg))
+
+	var stored accessv1alpha1.ClusterBreakglass
+	require.NoError(t, inner.Get(ctx, client.ObjectKey{Name: "platform"}, &stored))
+	assert.Equal(t, []string{finalizer}, stored.Finalizers)
+	assert.Equal(t, "system", stored.Status.ApprovedBy)
+	assert.Equal(t, stored.ResourceVersion, bg.ResourceVersion)
+
+	patch := client.MergeFrom(bg.DeepCopy())
+	bg.Labels = map[string]string{"team": "platform"}
+	require.NoError(t, c.Patch(ctx, &bg, patch))
+	patch = client.MergeFrom(bg.DeepCopy())
+	bg.Status.ApprovedBy = "alice"
+	require.NoError(t, c.Status().Patch(ctx, &bg, patch))
+	require.NoError(t, inner.Get(ctx, client.ObjectKey{Name: "platform"}, &stored))
+	assert.Equal(t, "platform", stored.Labels["team"])
+	assert.Equal(t, "alice", stored.Status.ApprovedBy)
+	assert.True(t, bg.IsClusterScoped())
+
+	// Namespaced requests are passed through untouched
+	var other accessv1alpha1.Breakglass
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "platform"}, &other))
+	assert.Equal(t, []string{"edit"}, other.Spec.ClusterRoles)
+}
+
+func TestClusterObject(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{ObjectMeta: metav1.ObjectMeta{Name: "platform", UID: "uid"}}
+	cbg, ok := clusterObject(bg).(*accessv1alpha1.ClusterBreakglass)
+	require.True(t, ok, "events for cluster-scoped requests are recorded on the ClusterBreakglass")
+	assert.Equal(t, types.UID("uid"), cbg.UID)
+
+	namespaced := &accessv1alpha1.Breakglass{ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default"}}
+	assert.Same(t, namespaced, clusterObject(namespaced))
+}
+
+func TestApprovalMapping(t *testing.T) {
+	approval := &accessv1alpha1.BreakglassApproval{
+		ObjectMeta: metav1.ObjectMeta{Name: "ok", Namespace: "firedoor-system"},
+		Spec:       accessv1alpha1.BreakglassApprovalSpec{BreakglassRef: "platform"},
+	}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: "firedoor-system", Name: "platform",
+	}}}, approvalToBreakglass(context.Background(), approval))
+	assert.Empty(t, approvalToClusterBreakglass(context.Background(), approval))
+
+	approval.Spec.BreakglassKind = accessv1alpha1.RefKindClusterBreakglass
+	assert.Empty(t, approvalToBreakglass(context.Background(), approval))
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "platform"}}},
+		approvalToClusterBreakglass(context.Background(), approval))
+}
+
+func TestRBACMapping(t *testing.T) {
+	rb := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+		Name:      "breakglass-01234567-rolebinding-0",
+		Namespace: "apps",
+		Labels:    map[string]string{rbac.LabelName: "bg", rbac.LabelNamespace: "team-a", rbac.LabelUID: "uid"},
+	}}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "bg"}}},
+		rbacToBreakglass(context.Background(), rb))
+	assert.Empty(t, rbacToClusterBreakglass(context.Background(), rb))
+
+	crb := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{
+		Name:   "breakglass-01234567-clusterrolebinding-view",
+		Labels: map[string]string{rbac.LabelName: "platform", rbac.LabelNamespace: "", rbac.LabelUID: "uid"},
+	}}
+	assert.Empty(t, rbacToBreakglass(context.Background(), crb))
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "platform"}}},
+		rbacToClusterBreakglass(context.Background(), crb))
+
+	assert.Empty(t, rbacToBreakglass(context.Background(), &rbacv1.ClusterRole{}), "unlabelled objects are ignored")
+}
+
+func TestPolicyMapping(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&accessv1alpha1.Breakglass{ObjectMeta: metav1.ObjectMeta{Name: "waiting", Namespace: "team-a"},
+			Status: accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhasePending}},
+		&accessv1alpha1.Breakglass{ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: "team-a"},
+			Status: accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhaseExpired}},
+		&accessv1alpha1.Breakglass{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-b"}},
+		&accessv1alpha1.ClusterBreakglass{ObjectMeta: metav1.ObjectMeta{Name: "platform"}},
+	).Build()
+	ctx := context.Background()
+
+	scoped := &accessv1alpha1.BreakglassPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+		Spec:       accessv1alpha1.BreakglassPolicySpec{Namespaces: []string{"team-a"}},
+	}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "waiting"}}},
+		policyToBreakglass(c)(ctx, scoped), "finished requests are not re-checked")
+	assert.Empty(t, policyToClusterBreakglass(c)(ctx, scoped), "namespace-scoped policies do not govern cluster requests")
+
+	global := &accessv1alpha1.BreakglassPolicy{ObjectMeta: metav1.ObjectMeta{Name: "global"}}
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "waiting"}},
+		{NamespacedName: types.NamespacedName{Namespace: "team-b", Name: "other"}},
+	}, policyToBreakglass(c)(ctx, global))
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "platform"}}},
+		policyToClusterBreakglass(c)(ctx, global))
+}
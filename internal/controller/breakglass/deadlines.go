@@ -0,0 +1,203 @@
+package breakglass
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+)
+
+// Deadlines wakes requests exactly when their next activation or expiry is due instead of polling them.
+// Deadlines are kept in a min-heap; one timer runs for the earliest and due requests are sent to the
+// controller through a channel source. Deadlines live in memory only: every request is reconciled when
+// the controller starts, which schedules them again.
+type Deadlines struct {
+	clock controller.Clock
+	// timer starts a timer and returns its channel and stop function
+	timer  func(time.Duration) (<-chan time.Time, func() bool)
+	events chan event.TypedGenericEvent[types.NamespacedName]
+	// wake interrupts the wait when the earliest deadline moved
+	wake chan struct{}
+
+	mu    sync.Mutex
+	queue deadlineQueue
+	index map[types.NamespacedName]*deadline
+}
+
+var _ controller.DeadlineScheduler = &Deadlines{}
+
+// NewDeadlines creates an empty Deadlines reading the time from clock.
+func NewDeadlines(clock controller.Clock) *Deadlines {
+	return &Deadlines{
+		clock:  clock,
+		timer:  newTimer,
+		events: make(chan event.TypedGenericEvent[types.NamespacedName], 64),
+		wake:   make(chan struct{}, 1),
+		index:  make(map[types.NamespacedName]*deadline),
+	}
+}
+
+// Source returns the source enqueueing requests as their deadlines pass.
+func (d *Deadlines) Source() source.Source {
+	return source.Channel(d.events, handler.TypedEnqueueRequestsFromMapFunc(
+		func(_ context.Context, key types.NamespacedName) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: key}}
+		},
+	))
+}
+
+// Schedule implements controller.DeadlineScheduler.
+func (d *Deadlines) Schedule(key types.NamespacedName, at time.Time) {
+	d.mu.Lock()
+	first := d.schedule(key, at)
+	d.mu.Unlock()
+	if first {
+		d.notify()
+	}
+}
+
+// Forget implements controller.DeadlineScheduler.
+func (d *Deadlines) Forget(key types.NamespacedName) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if dl, ok := d.index[key]; ok {
+		heap.Remove(&d.queue, dl.pos)
+		delete(d.index, key)
+	}
+}
+
+// Len returns the number of pending deadlines.
+func (d *Deadlines) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.queue)
+}
+
+// Start implements manager.Runnable. It sends every request whose deadline passed and then sleeps until
+// the earliest remaining one, or until an earlier deadline is scheduled.
+func (d *Deadlines) Start(ctx context.Context) error {
+	for {
+		d.mu.Lock()
+		due := d.popDue(d.clock.Now())
+		next, pending := d.next()
+		d.mu.Unlock()
+
+		for _, key := range due {
+			select {
+			case d.events <- event.TypedGenericEvent[types.NamespacedName]{Object: key}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		if len(due) > 0 {
+			continue
+		}
+
+		var (
+			fire <-chan time.Time
+			stop func() bool
+		)
+		if pending {
+			fire, stop = d.timer(d.clock.Until(next))
+		}
+		select {
+		case <-ctx.Done():
+		case <-d.wake:
+		case <-fire:
+		}
+		if stop != nil {
+			stop()
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// newTimer starts a real timer firing after wait.
+func newTimer(wait time.Duration) (<-chan time.Time, func() bool) {
+	t := time.NewTimer(wait)
+	return t.C, t.Stop
+}
+
+// notify wakes Start without blocking; one pending wake-up is enough.
+func (d *Deadlines) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// schedule sets the deadline of key and reports whether it is now the earliest. d.mu must be held.
+func (d *Deadlines) schedule(key types.NamespacedName, at time.Time) bool {
+	if dl, ok := d.index[key]; ok {
+		dl.at = at
+		heap.Fix(&d.queue, dl.pos)
+	} else {
+		dl = &deadline{key: key, at: at}
+		heap.Push(&d.queue, dl)
+		d.index[key] = dl
+	}
+	return d.queue[0].key == key
+}
+
+// popDue removes and returns the keys whose deadline is not after now, earliest first. d.mu must be held.
+func (d *Deadlines) popDue(now time.Time) []types.NamespacedName {
+	var due []types.NamespacedName
+	for len(d.queue) > 0 && !d.queue[0].at.After(now) {
+		dl := heap.Pop(&d.queue).(*deadline)
+		delete(d.index, dl.key)
+		due = append(due, dl.key)
+	}
+	return due
+}
+
+// next returns the earliest deadline, if any. d.mu must be held.
+func (d *Deadlines) next() (time.Time, bool) {
+	if len(d.queue) == 0 {
+		return time.Time{}, false
+	}
+	return d.queue[0].at, true
+}
+
+// deadline is the time a request is due, and its position in the heap.
+type deadline struct {
+	key types.NamespacedName
+	at  time.Time
+	pos int
+}
+
+// deadlineQueue implements heap.Interface ordered by time.
+type deadlineQueue []*deadline
+
+func (q deadlineQueue) Len() int           { return len(q) }
+func (q deadlineQueue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+
+func (q deadlineQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].pos = i
+	q[j].pos = j
+}
+
+func (q *deadlineQueue) Push(x any) {
+	dl := x.(*deadline)
+	dl.pos = len(*q)
+	*q = append(*q, dl)
+}
+
+func (q *deadlineQueue) Pop() any {
+	old := *q
+	n := len(old)
+	dl := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return dl
+}
@@ -0,0 +1,259 @@
+package breakglass
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/cloud-nimbus/firedoor/internal/clock"
+)
+
+func TestDeadlines_PopDue(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := types.NamespacedName{Namespace: "default", Name: "a"}
+	b := types.NamespacedName{Namespace: "default", Name: "b"}
+	c := types.NamespacedName{Name: "c"}
+
+	d := NewDeadlines(clock.SimpleClock{})
+	d.Schedule(a, now.Add(time.Hour))
+	d.Schedule(b, now.Add(2*time.Minute))
+	d.Schedule(c, now.Add(time.Minute))
+	d.Schedule(a, now.Add(90*time.Second)) // rescheduling replaces the earlier deadline
+	assert.Equal(t, 3, d.Len())
+
+	d.Forget(b)
+	d.Forget(b)
+	assert.Equal(t, 2, d.Len())
+
+	assert.Empty(t, d.popDue(now))
+	assert.Equal(t, []types.NamespacedName{c, a}, d.popDue(now.Add(2*time.Minute)))
+	_, pending := d.next()
+	assert.False(t, pending)
+}
+
+func TestDeadlines_Start(t *testing.T) {
+	d := NewDeadlines(clock.SimpleClock{})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- d.Start(ctx) }()
+
+	later := types.NamespacedName{Namespace: "default", Name: "later"}
+	soon := types.NamespacedName{Namespace: "default", Name: "soon"}
+	d.Schedule(later, time.Now().Add(time.Hour))
+	// An earlier deadline must interrupt the wait for the later one
+	d.Schedule(soon, time.Now().Add(20*time.Millisecond))
+
+	select {
+	case ev := <-d.events:
+		assert.Equal(t, soon, ev.Object)
+	case <-time.After(5 * time.Second):
+		t.Fatal("deadline did not fire")
+	}
+	assert.Equal(t, 1, d.Len(), "the later deadline is still pending")
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return after the context was cancelled")
+	}
+}
+
+// manualClock is a clock that only moves when set.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) Until(t time.Time) time.Duration { return max(t.Sub(c.Now()), 0) }
+
+func (c *manualClock) IsExpired(t time.Time) bool { return !t.IsZero() && c.Now().After(t) }
+
+// manualTimer is a timer Start waits on until the test fires it.
+type manualTimer struct {
+	wait time.Duration
+	fire chan time.Time
+}
+
+func TestDeadlines_FiresAtDeadline(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	due := start.Add(5 * time.Minute)
+	key := types.NamespacedName{Namespace: "default", Name: "bg"}
+
+	clk := &manualClock{now: start}
+	timers := make(chan manualTimer)
+	d := NewDeadlines(clk)
+	d.timer = func(wait time.Duration) (<-chan time.Time, func() bool) {
+		timer := manualTimer{wait: wait, fire: make(chan time.Time, 1)}
+		timers <- timer
+		return timer.fire, func() bool { return true }
+	}
+	// Set before Start runs, so no wake-up is pending
+	d.schedule(key, due)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = d.Start(ctx) }()
+
+	nextTimer := func() manualTimer {
+		select {
+		case timer := <-timers:
+			return timer
+		case <-time.After(5 * time.Second):
+			t.Fatal("Start did not wait for the next deadline")
+			return manualTimer{}
+		}
+	}
+	assertNoEvent := func() {
+		select {
+		case ev := <-d.events:
+			t.Fatalf("%v enqueued at %v, before its deadline %v", ev.Object, clk.Now(), due)
+		default:
+		}
+	}
+
+	timer := nextTimer()
+	assert.Equal(t, 5*time.Minute, timer.wait, "the timer is set for the deadline")
+
+	// A timer firing early, e.g. after the wall clock jumped, does not enqueue the request
+	clk.set(due.Add(-time.Second))
+	timer.fire <- clk.Now()
+	timer = nextTimer()
+	assert.Equal(t, time.Second, timer.wait)
+	assertNoEvent()
+
+	clk.set(due)
+	timer.fire <- clk.Now()
+	select {
+	case ev := <-d.events:
+		assert.Equal(t, key, ev.Object)
+	case <-time.After(5 * time.Second):
+		t.Fatal("deadline did not fire")
+	}
+	assert.Zero(t, d.Len())
+
+	// The deadline is consumed: nothing is enqueued again until the request is scheduled anew
+	clk.set(due.Add(time.Hour))
+	d.Schedule(types.NamespacedName{Namespace: "default", Name: "other"}, due.Add(2*time.Hour))
+	assert.Equal(t, time.Hour, nextTimer().wait)
+	assertNoEvent()
+}
+
+// fleetRequest is a simulated request whose state next changes on its own at transition, or that waits for
+// approval when transition is zero.
+type fleetRequest struct {
+	key        types.NamespacedName
+	transition time.Duration
+}
+
+// simulatedFleet returns n requests with activations and expiries spread over a day, a tenth of them
+// pending approval.
+func simulatedFleet(n int) []fleetRequest {
+	rng := rand.New(rand.NewSource(1))
+	fleet := make([]fleetRequest, n)
+	for i := range fleet {
+		fleet[i].key = types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("bg-%d", i)}
+		if i%10 != 0 {
+			fleet[i].transition = time.Duration(rng.Int63n(int64(24 * time.Hour)))
+		}
+	}
+	return fleet
+}
+
+// BenchmarkWakeups compares the reconciles a fleet of 3000 requests causes over a day, and how late
+// transitions are noticed, when requests are polled as before and when woken by Deadlines.
+func BenchmarkWakeups(b *testing.B) {
+	const day = 24 * time.Hour
+	fleet := simulatedFleet(3000)
+
+	b.Run("polling", func(b *testing.B) {
+		var reconciles int
+		var maxLag time.Duration
+		for i := 0; i < b.N; i++ {
+			reconciles, maxLag = 0, 0
+			for _, r := range fleet {
+				if r.transition == 0 {
+					// Pending requests are looked at every 30 seconds
+					reconciles += int(day / (30 * time.Second))
+					continue
+				}
+				at := time.Duration(0)
+				for {
+					reconciles++
+					if at >= r.transition {
+						maxLag = max(maxLag, at-r.transition)
+						break
+					}
+					at += clampDuration(r.transition-at, 30*time.Second, time.Hour)
+				}
+			}
+		}
+		b.ReportMetric(float64(reconciles), "reconciles/day")
+		b.ReportMetric(maxLag.Seconds(), "max-lag-s")
+	})
+
+	b.Run("deadlines", func(b *testing.B) {
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		var reconciles int
+		var maxLag time.Duration
+		for i := 0; i < b.N; i++ {
+			reconciles, maxLag = 0, 0
+			d := NewDeadlines(clock.SimpleClock{})
+			due := make(map[types.NamespacedName]time.Time, len(fleet))
+			for _, r := range fleet {
+				// Pending requests are woken by the approval watch, not a deadline
+				if r.transition > 0 {
+					d.schedule(r.key, start.Add(r.transition))
+					due[r.key] = start.Add(r.transition)
+				}
+			}
+			for now := start; now.Before(start.Add(day)); now = now.Add(time.Second) {
+				for _, key := range d.popDue(now) {
+					reconciles++
+					maxLag = max(maxLag, now.Sub(due[key]))
+				}
+			}
+		}
+		b.ReportMetric(float64(reconciles), "reconciles/day")
+		b.ReportMetric(maxLag.Seconds(), "max-lag-s")
+	})
+}
+
+// BenchmarkDeadlines_Schedule measures rescheduling one request among 3000 pending deadlines.
+func BenchmarkDeadlines_Schedule(b *testing.B) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fleet := simulatedFleet(3000)
+	d := NewDeadlines(clock.SimpleClock{})
+	for _, r := range fleet {
+		d.Schedule(r.key, start.Add(r.transition))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := fleet[i%len(fleet)]
+		d.Schedule(r.key, start.Add(r.transition+time.Duration(i)*time.Millisecond))
+	}
+}
+
+// clampDuration bounds d the way handlers bound requeues when no Deadlines are set.
+func clampDuration(d, lo, hi time.Duration) time.Duration {
+	return min(max(d, lo), hi)
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+// ApprovalBreakglassRefField is the field index used to look up approvals by the Breakglass they reference.
+const ApprovalBreakglassRefField = "spec.breakglassRef"
+
+const (
+	BreakglassDeniedMsgFmt          = "Breakglass request denied by %s: %s"
+	BreakglassPendingApprovalMsgFmt = "Breakglass request is pending approval (%s)"
+	ApprovalsUnverifiedMsg          = "Approval is required but the admission webhook that stamps approver " +
+		"identities is disabled, so approvals cannot be trusted"
+	RequesterUnknownMsg = "Approval is required but the request has no " + accessv1alpha1.RequestedByAnnotation +
+		" annotation, so self-approvals cannot be excluded"
+)
+
+// ApprovalBreakglassRefIndexer extracts the ApprovalBreakglassRefField index value from a BreakglassApproval.
+func ApprovalBreakglassRefIndexer(obj client.Object) []string {
+	approval, ok := obj.(*accessv1alpha1.BreakglassApproval)
+	if !ok || approval.Spec.BreakglassRef == "" {
+		return nil
+	}
+	return []string{approval.Spec.BreakglassRef}
+}
+
+// ApprovalRefKind returns the kind of request approval decides on, defaulting to Breakglass.
+func ApprovalRefKind(approval *accessv1alpha1.BreakglassApproval) accessv1alpha1.BreakglassRefKind {
+	if approval.Spec.BreakglassKind == "" {
+		return accessv1alpha1.RefKindBreakglass
+	}
+	return approval.Spec.BreakglassKind
+}
+
+// listApprovals returns the admitted approvals for bg ordered by creation time.
+// Approvals for a ClusterBreakglass are read from the ClusterApprovalNamespace.
+// Approvals without a stamped approver identity are ignored, as are approvals admitted for an earlier
+// request with the same name.
+func (h *Handler) listApprovals(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+) ([]accessv1alpha1.BreakglassApproval, error) {
+	namespace, kind := bg.Namespace, accessv1alpha1.RefKindBreakglass
+	if bg.IsClusterScoped() {
+		if h.ClusterApprovalNamespace == "" {
+			return nil, nil
+		}
+		namespace, kind = h.ClusterApprovalNamespace, accessv1alpha1.RefKindClusterBreakglass
+	}
+
+	var list accessv1alpha1.BreakglassApprovalList
+	if err := h.Client.List(ctx, &list,
+		client.InNamespace(namespace),
+		client.MatchingFields{ApprovalBreakglassRefField: bg.Name},
+	); err != nil {
+		return nil, fmt.Errorf("list approvals: %w", err)
+	}
+
+	approvals := make([]accessv1alpha1.BreakglassApproval, 0, len(list.Items))
+	for i := range list.Items {
+		approval := list.Items[i]
+		if !approval.DeletionTimestamp.IsZero() || ApprovalRefKind(&approval) != kind {
+			continue
+		}
+		if approval.Spec.Approver == nil || approval.Spec.Approver.Username == "" {
+			ctrl.LoggerFrom(ctx).Info("ignoring approval without approver identity", "approval", approval.Name)
+			continue
+		}
+		if approval.Spec.BreakglassUID != bg.UID || approval.CreationTimestamp.Before(&bg.CreationTimestamp) {
+			ctrl.LoggerFrom(ctx).Info("ignoring approval for an earlier request with the same name",
+				"approval", approval.Name, "uid", approval.Spec.BreakglassUID)
+			continue
+		}
+		approvals = append(approvals, approval)
+	}
+	sort.SliceStable(approvals, func(i, j int) bool {
+		return approvals[i].CreationTimestamp.Before(&approvals[j].CreationTimestamp)
+	})
+	return approvals, nil
+}
+
+// approvalTally is the outcome of evaluating the approvals of a Breakglass.
+type approvalTally struct {
+	// denial is the earliest eligible denial, if any.
+	denial *accessv1alpha1.BreakglassApproval
+	// approvals holds one approval per distinct eligible approver, in admission order.
+	approvals []accessv1alpha1.BreakglassApproval
+	// required is the number of distinct approvals needed.
+	required int
+}
+
+// approved reports whether the quorum has been reached.
+func (t approvalTally) approved() bool {
+	return t.denial == nil && len(t.approvals) >= t.required
+}
+
+// progress renders the quorum progress, e.g. "1/2 approvals".
+func (t approvalTally) progress() string {
+	return fmt.Sprintf("%d/%d approvals", len(t.approvals), t.required)
+}
+
+// approvers returns the usernames that completed the quorum.
+func (t approvalTally) approvers() []string {
+	n := min(t.required, len(t.approvals))
+	approvers := make([]string, 0, n)
+	for _, approval := range t.approvals[:n] {
+		approvers = append(approvers, approval.Spec.Approver.Username)
+	}
+	return approvers
+}
+
+// requiresApproval reports whether bg needs a manual approval quorum.
+func requiresApproval(bg *accessv1alpha1.Breakglass) bool {
+	return bg.Spec.Approval != nil && bg.Spec.Approval.Required
+}
+
+// approvalBlocker returns the reason and message why no approval for bg can be counted, if any.
+// Approver identities are only trustworthy when the admission webhook stamped them, and without a
+// stamped requester a self-approval would pass as anyone else's.
+func (h *Handler) approvalBlocker(bg *accessv1alpha1.Breakglass) (accessv1alpha1.BreakglassConditionReason, string) {
+	if !h.ApprovalWebhook {
+		return accessv1alpha1.ReasonApprovalsUnverified, ApprovalsUnverifiedMsg
+	}
+	if bg.Annotations[accessv1alpha1.RequestedByAnnotation] == "" {
+		return accessv1alpha1.ReasonRequesterUnknown, RequesterUnknownMsg
+	}
+	return "", ""
+}
+
+// tallyApprovals evaluates the approvals for bg against its ApprovalSpec.
+// When extension is set only decisions on that extension are considered, otherwise only
+// decisions on the request itself. Decisions from the requester or from users outside the
+// allowed approvers are ignored, and each approver is counted at most once.
+// A denial always takes precedence. Nothing is counted while approvalBlocker objects.
+func (h *Handler) tallyApprovals(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+	extension *accessv1alpha1.ExtensionRequest,
+) (approvalTally, error) {
+	tally := approvalTally{required: requiredApprovals(bg.Spec.Approval)}
+	if reason, _ := h.approvalBlocker(bg); reason != "" {
+		return tally, nil
+	}
+
+	approvals, err := h.listApprovals(ctx, bg)
+	if err != nil {
+		return tally, err
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	target := ""
+	requesters := []string{bg.Annotations[accessv1alpha1.RequestedByAnnotation]}
+	if extension != nil {
+		target = extension.Name
+		if extension.RequestedBy != nil {
+			requesters = append(requesters, extension.RequestedBy.Username)
+		}
+	}
+	seen := make(map[string]struct{}, len(approvals))
+	for i := range approvals {
+		if approvals[i].Spec.Extension != target {
+			continue
+		}
+		approver := approvals[i].Spec.Approver
+		if slices.Contains(requesters, approver.Username) {
+			log.Info("ignoring self-approval", "approval", approvals[i].Name, "approver", approver.Username)
+			continue
+		}
+		if !isEligibleApprover(bg.Spec.Approval, approver) {
+			log.Info("ignoring approval from ineligible approver",
+				"approval", approvals[i].Name, "approver", approver.Username)
+			continue
+		}
+
+		switch approvals[i].Spec.Decision {
+		case accessv1alpha1.DecisionDeny:
+			if tally.denial == nil {
+				tally.denial = &approvals[i]
+			}
+		case accessv1alpha1.DecisionApprove:
+			if _, ok := seen[approver.Username]; ok {
+				continue
+			}
+			seen[approver.Username] = struct{}{}
+			tally.approvals = append(tally.approvals, approvals[i])
+		}
+	}
+	return tally, nil
+}
+
+// requiredApprovals returns the quorum size for spec, defaulting to one approval.
+func requiredApprovals(spec *accessv1alpha1.ApprovalSpec) int {
+	if spec == nil || spec.MinApprovals < 1 {
+		return 1
+	}
+	return int(spec.MinApprovals)
+}
+
+// isEligibleApprover reports whether approver is allowed to decide on a request with the given spec.
+func isEligibleApprover(spec *accessv1alpha1.ApprovalSpec, approver *accessv1alpha1.UserIdentity) bool {
+	if spec == nil || (len(spec.Users) == 0 && len(spec.Groups) == 0) {
+		return true
+	}
+	if slices.Contains(spec.Users, approver.Username) {
+		return true
+	}
+	for _, group := range approver.Groups {
+		if slices.Contains(spec.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordApprovals copies the counted approvals onto the status.
+// Once the quorum is reached ApprovedBy and ApprovedAt are set from the approvals that completed it.
+func recordApprovals(bg *accessv1alpha1.Breakglass, tally approvalTally) {
+	records := make([]accessv1alpha1.ApprovalRecord, 0, len(tally.approvals))
+	for _, approval := range tally.approvals {
+		records = append(records, accessv1alpha1.ApprovalRecord{
+			Approver:    approval.Spec.Approver.Username,
+			ApprovalRef: approval.Name,
+			ApprovedAt:  approval.CreationTimestamp,
+		})
+	}
+	bg.Status.Approvals = records
+
+	if !tally.approved() {
+		return
+	}
+	at := tally.approvals[tally.required-1].CreationTimestamp
+	bg.Status.ApprovedBy = strings.Join(tally.approvers(), ",")
+	bg.Status.ApprovedAt = &at
+}
+
+// Deny moves the breakglass into the terminal Denied condition.
+func (h *Handler) Deny(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+	approval *accessv1alpha1.BreakglassApproval,
+) (ctrl.Result, error) {
+	at := approval.CreationTimestamp
+	bg.Status.DeniedBy = approval.Spec.Approver.Username
+	bg.Status.DeniedAt = &at
+
+	msg := fmt.Sprintf(BreakglassDeniedMsgFmt, bg.Status.DeniedBy, approval.Spec.Reason)
+	if err := h.updateStatus(
+		ctx,
+		bg,
+		accessv1alpha1.ConditionDenied,
+		accessv1alpha1.ReasonAccessDenied,
+		msg,
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	h.emitAccessDeniedEvent(bg, approval)
+	return ctrl.Result{}, nil
+}
+
+// emitAccessDeniedEvent emits a Kubernetes event when a request is denied
+func (h *Handler) emitAccessDeniedEvent(bg *accessv1alpha1.Breakglass, approval *accessv1alpha1.BreakglassApproval) {
+	if h.recorder == nil {
+		return
+	}
+
+	h.recorder.Eventf(bg, "Warning", "AccessDenied",
+		BreakglassDeniedMsgFmt, approval.Spec.Approver.Username, approval.Spec.Reason)
+}
+
+// emitAccessApprovedEvent emits a Kubernetes event when a request is approved
+func (h *Handler) emitAccessApprovedEvent(bg *accessv1alpha1.Breakglass) {
+	if h.recorder == nil {
+		return
+	}
+
+	h.recorder.Eventf(bg, "Normal", "AccessApproved", "Breakglass request approved by %s", bg.Status.ApprovedBy)
+}
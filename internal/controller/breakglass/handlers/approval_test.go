@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/operator/rbac"
+)
+
+// testBreakglassUID is the UID newApproval stamps, as the admission webhook would.
+const testBreakglassUID = types.UID("test-breakglass-uid")
+
+func newApproval(
+	name string,
+	decision accessv1alpha1.ApprovalDecision,
+	approver string,
+	created time.Time,
+) *accessv1alpha1.BreakglassApproval {
+	approval := &accessv1alpha1.BreakglassApproval{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: accessv1alpha1.BreakglassApprovalSpec{
+			BreakglassRef: "test-breakglass",
+			BreakglassUID: testBreakglassUID,
+			Decision:      decision,
+			Reason:        "reason",
+		},
+	}
+	if approver != "" {
+		approval.Spec.Approver = &accessv1alpha1.UserIdentity{Username: approver}
+	}
+	return approval
+}
+
+func newApprovalTestHandler(objs ...client.Object) *Handler {
+	scheme := runtime.NewScheme()
+	_ = accessv1alpha1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&accessv1alpha1.Breakglass{}).
+		WithIndex(&accessv1alpha1.BreakglassApproval{}, ApprovalBreakglassRefField, ApprovalBreakglassRefIndexer).
+		WithObjects(objs...).
+		Build()
+	return &Handler{Client: fakeClient, Operator: rbac.NoopOperator{}, ApprovalWebhook: true}
+}
+
+func TestHandler_TallyApprovals(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		approval     *accessv1alpha1.ApprovalSpec
+		approvals    []client.Object
+		wantApproved bool
+		wantDenial   string
+		wantCounted  []string
+	}{
+		{
+			name:     "no approvals",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true},
+		},
+		{
+			name:     "single approval reaches default quorum",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true},
+			approvals: []client.Object{
+				newApproval("second", accessv1alpha1.DecisionApprove, "bob", now.Add(time.Minute)),
+				newApproval("first", accessv1alpha1.DecisionApprove, "alice", now),
+			},
+			wantApproved: true,
+			wantCounted:  []string{"first", "second"},
+		},
+		{
+			name:     "same approver counted once",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true, MinApprovals: 2},
+			approvals: []client.Object{
+				newApproval("first", accessv1alpha1.DecisionApprove, "alice", now),
+				newApproval("again", accessv1alpha1.DecisionApprove, "alice", now.Add(time.Minute)),
+			},
+			wantCounted: []string{"first"},
+		},
+		{
+			name:     "requester cannot approve",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true},
+			approvals: []client.Object{
+				newApproval("self", accessv1alpha1.DecisionApprove, "requester", now),
+			},
+		},
+		{
+			name:     "only allowed approvers are counted",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true, MinApprovals: 2, Users: []string{"alice", "bob"}},
+			approvals: []client.Object{
+				newApproval("alice", accessv1alpha1.DecisionApprove, "alice", now),
+				newApproval("mallory", accessv1alpha1.DecisionApprove, "mallory", now.Add(time.Minute)),
+				newApproval("bob", accessv1alpha1.DecisionApprove, "bob", now.Add(2*time.Minute)),
+			},
+			wantApproved: true,
+			wantCounted:  []string{"alice", "bob"},
+		},
+		{
+			name:     "deny takes precedence",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true},
+			approvals: []client.Object{
+				newApproval("approve", accessv1alpha1.DecisionApprove, "alice", now),
+				newApproval("deny", accessv1alpha1.DecisionDeny, "bob", now.Add(time.Minute)),
+			},
+			wantDenial:  "deny",
+			wantCounted: []string{"approve"},
+		},
+		{
+			name:     "approval without approver is ignored",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true},
+			approvals: []client.Object{
+				newApproval("unstamped", accessv1alpha1.DecisionApprove, "", now),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-breakglass",
+					UID:         testBreakglassUID,
+					Namespace:   "default",
+					Annotations: map[string]string{accessv1alpha1.RequestedByAnnotation: "requester"},
+				},
+				Spec: accessv1alpha1.BreakglassSpec{Approval: tt.approval},
+			}
+			handler := newApprovalTestHandler(append(tt.approvals, bg)...)
+
+			tally, err := handler.tallyApprovals(context.Background(), bg, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantApproved, tally.approved())
+
+			counted := []string{}
+			for _, approval := range tally.approvals {
+				counted = append(counted, approval.Name)
+			}
+			assert.ElementsMatch(t, tt.wantCounted, counted)
+
+			if tt.wantDenial == "" {
+				assert.Nil(t, tally.denial)
+			} else {
+				require.NotNil(t, tally.denial)
+				assert.Equal(t, tt.wantDenial, tally.denial.Name)
+			}
+		})
+	}
+}
+
+func TestHandler_TallyApprovals_ClusterBreakglass(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	namespaced := newApproval("namespaced", accessv1alpha1.DecisionApprove, "alice", now)
+	cluster := newApproval("cluster", accessv1alpha1.DecisionApprove, "bob", now)
+	cluster.Namespace = "firedoor-system"
+	cluster.Spec.BreakglassKind = accessv1alpha1.RefKindClusterBreakglass
+	elsewhere := newApproval("elsewhere", accessv1alpha1.DecisionApprove, "carol", now)
+	elsewhere.Spec.BreakglassKind = accessv1alpha1.RefKindClusterBreakglass
+
+	handler := newApprovalTestHandler(namespaced, cluster, elsewhere)
+	handler.ClusterApprovalNamespace = "firedoor-system"
+	approval := &accessv1alpha1.ApprovalSpec{Required: true, MinApprovals: 3}
+
+	// A ClusterBreakglass only counts cluster approvals from the configured namespace
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-breakglass",
+			UID:         testBreakglassUID,
+			Annotations: map[string]string{accessv1alpha1.RequestedByAnnotation: "requester"},
+		},
+		Spec: accessv1alpha1.BreakglassSpec{Approval: approval},
+	}
+	tally, err := handler.tallyApprovals(context.Background(), bg, nil)
+	require.NoError(t, err)
+	require.Len(t, tally.approvals, 1)
+	assert.Equal(t, "cluster", tally.approvals[0].Name)
+
+	// A namespaced Breakglass ignores approvals meant for a ClusterBreakglass
+	bg.Namespace = "default"
+	tally, err = handler.tallyApprovals(context.Background(), bg, nil)
+	require.NoError(t, err)
+	require.Len(t, tally.approvals, 1)
+	assert.Equal(t, "namespaced", tally.approvals[0].Name)
+}
+
+func TestHandler_TallyApprovals_RecreatedRequest(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// approvals of a deleted request with the same name must not carry over
+	stale := newApproval("stale", accessv1alpha1.DecisionApprove, "alice", now)
+	stale.Spec.BreakglassUID = "deleted-uid"
+	early := newApproval("early", accessv1alpha1.DecisionApprove, "bob", now.Add(-time.Minute))
+	current := newApproval("current", accessv1alpha1.DecisionApprove, "carol", now.Add(time.Minute))
+
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-breakglass",
+			Namespace:         "default",
+			UID:               testBreakglassUID,
+			CreationTimestamp: metav1.NewTime(now),
+			Annotations:       map[string]string{accessv1alpha1.RequestedByAnnotation: "requester"},
+		},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Approval: &accessv1alpha1.ApprovalSpec{Required: true, MinApprovals: 2},
+		},
+	}
+	handler := newApprovalTestHandler(stale, early, current, bg)
+
+	tally, err := handler.tallyApprovals(context.Background(), bg, nil)
+	require.NoError(t, err)
+	require.Len(t, tally.approvals, 1)
+	assert.Equal(t, "current", tally.approvals[0].Name)
+	assert.False(t, tally.approved())
+}
+
+func TestIsEligibleApprover_Groups(t *testing.T) {
+	spec := &accessv1alpha1.ApprovalSpec{Groups: []string{"sre"}}
+
+	assert.True(t, isEligibleApprover(spec, &accessv1alpha1.UserIdentity{Username: "a", Groups: []string{"dev", "sre"}}))
+	assert.False(t, isEligibleApprover(spec, &accessv1alpha1.UserIdentity{Username: "b", Groups: []string{"dev"}}))
+}
+
+func TestPendingCondition_QuorumProgress(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-breakglass",
+			UID:         testBreakglassUID,
+			Namespace:   "default",
+			Annotations: map[string]string{accessv1alpha1.RequestedByAnnotation: "requester"},
+		},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Approval: &accessv1alpha1.ApprovalSpec{Required: true, MinApprovals: 2},
+		},
+		Status: accessv1alpha1.BreakglassStatus{
+			Conditions: []metav1.Condition{{
+				Type:   string(accessv1alpha1.ConditionPending),
+				Status: metav1.ConditionTrue,
+				Reason: string(accessv1alpha1.ReasonWaitingForApproval),
+			}},
+		},
+	}
+	approve := newApproval("alice", accessv1alpha1.DecisionApprove, "alice", now)
+	handler := newApprovalTestHandler(bg, approve)
+
+	result, err := NewPendingCondition(handler).Handle(context.Background(), bg)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, result.RequeueAfter)
+
+	require.Len(t, bg.Status.Approvals, 1)
+	assert.Equal(t, "alice", bg.Status.Approvals[0].Approver)
+	assert.Empty(t, bg.Status.ApprovedBy)
+	cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionPending))
+	require.NotNil(t, cond)
+	assert.Contains(t, cond.Message, "1/2 approvals")
+}
+
+func TestPendingCondition_Denied(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-breakglass",
+			UID:         testBreakglassUID,
+			Namespace:   "default",
+			Annotations: map[string]string{accessv1alpha1.RequestedByAnnotation: "requester"},
+		},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Approval: &accessv1alpha1.ApprovalSpec{Required: true},
+		},
+		Status: accessv1alpha1.BreakglassStatus{
+			Conditions: []metav1.Condition{{
+				Type:   string(accessv1alpha1.ConditionPending),
+				Status: metav1.ConditionTrue,
+				Reason: string(accessv1alpha1.ReasonWaitingForApproval),
+			}},
+		},
+	}
+	deny := newApproval("deny", accessv1alpha1.DecisionDeny, "bob", now)
+	handler := newApprovalTestHandler(bg, deny)
+
+	result, err := NewPendingCondition(handler).Handle(context.Background(), bg)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	assert.Equal(t, "bob", bg.Status.DeniedBy)
+	require.NotNil(t, bg.Status.DeniedAt)
+	cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionDenied))
+	require.NotNil(t, cond)
+	assert.Equal(t, string(accessv1alpha1.ReasonAccessDenied), cond.Reason)
+	assert.Contains(t, cond.Message, "bob")
+}
+
+func TestPendingCondition_ApprovalsNotCountable(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		webhook     bool
+		annotations map[string]string
+		wantReason  accessv1alpha1.BreakglassConditionReason
+		wantMsg     string
+	}{
+		{
+			// Without the webhook nothing vouches for the approver
+			name:        "webhook disabled",
+			annotations: map[string]string{accessv1alpha1.RequestedByAnnotation: "requester"},
+			wantReason:  accessv1alpha1.ReasonApprovalsUnverified,
+			wantMsg:     ApprovalsUnverifiedMsg,
+		},
+		{
+			// Without a requester a self-approval cannot be excluded
+			name:       "requester annotation missing",
+			webhook:    true,
+			wantReason: accessv1alpha1.ReasonRequesterUnknown,
+			wantMsg:    RequesterUnknownMsg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-breakglass", Namespace: "default", UID: testBreakglassUID, Annotations: tt.annotations,
+				},
+				Spec: accessv1alpha1.BreakglassSpec{
+					Approval: &accessv1alpha1.ApprovalSpec{Required: true},
+				},
+				Status: accessv1alpha1.BreakglassStatus{
+					Phase: accessv1alpha1.PhasePending,
+					Conditions: []metav1.Condition{{
+						Type:   string(accessv1alpha1.ConditionPending),
+						Status: metav1.ConditionTrue,
+						Reason: string(accessv1alpha1.ReasonWaitingForApproval),
+					}},
+				},
+			}
+			// A well-formed approval is still not counted
+			approve := newApproval("alice", accessv1alpha1.DecisionApprove, "alice", now)
+			handler := newApprovalTestHandler(bg, approve)
+			handler.ApprovalWebhook = tt.webhook
+
+			tally, err := handler.tallyApprovals(context.Background(), bg, nil)
+			require.NoError(t, err)
+			assert.False(t, tally.approved())
+			assert.Empty(t, tally.approvals)
+
+			result, err := NewPendingCondition(handler).Handle(context.Background(), bg)
+			require.NoError(t, err)
+			assert.Zero(t, result)
+
+			assert.Equal(t, accessv1alpha1.PhaseFailed, bg.Status.Phase)
+			assert.Empty(t, bg.Status.ApprovedBy)
+			cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionFailed))
+			require.NotNil(t, cond)
+			assert.Equal(t, string(tt.wantReason), cond.Reason)
+			assert.Equal(t, tt.wantMsg, cond.Message)
+		})
+	}
+}
+
+func TestPendingCondition_PolicyViolation(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default", UID: testBreakglassUID},
+		Spec: accessv1alpha1.BreakglassSpec{
+			ClusterRoles: []string{"cluster-admin"},
+			Approval:     &accessv1alpha1.ApprovalSpec{Required: true},
+		},
+		Status: accessv1alpha1.BreakglassStatus{
+			Conditions: []metav1.Condition{{
+				Type:   string(accessv1alpha1.ConditionPending),
+				Status: metav1.ConditionTrue,
+				Reason: string(accessv1alpha1.ReasonWaitingForApproval),
+			}},
+		},
+	}
+	guardrail := &accessv1alpha1.BreakglassPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "view-only"},
+		Spec:       accessv1alpha1.BreakglassPolicySpec{AllowedClusterRoles: []string{"view"}, AllowClusterScope: true},
+	}
+	handler := newApprovalTestHandler(bg, guardrail)
+
+	result, err := NewPendingCondition(handler).Handle(context.Background(), bg)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionDenied))
+	require.NotNil(t, cond)
+	assert.Equal(t, string(accessv1alpha1.ReasonPolicyViolation), cond.Reason)
+	assert.Contains(t, cond.Message, `clusterRole "cluster-admin" is not allowed`)
+}
+
+func TestPendingCondition_DryRun(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default", UID: testBreakglassUID, Generation: 2},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Approval: &accessv1alpha1.ApprovalSpec{Required: true},
+			DryRun:   true,
+		},
+		Status: accessv1alpha1.BreakglassStatus{
+			Phase: accessv1alpha1.PhasePending,
+			Conditions: []metav1.Condition{{
+				Type:   string(accessv1alpha1.ConditionPending),
+				Status: metav1.ConditionTrue,
+				Reason: string(accessv1alpha1.ReasonWaitingForApproval),
+			}},
+		},
+	}
+	// An approval does not turn a dry run into a grant
+	approve := newApproval("alice", accessv1alpha1.DecisionApprove, "alice", now)
+	handler := newApprovalTestHandler(bg, approve)
+
+	result, err := NewPendingCondition(handler).Handle(context.Background(), bg)
+	require.NoError(t, err)
+	assert.Zero(t, result)
+
+	assert.Equal(t, accessv1alpha1.PhasePending, bg.Status.Phase)
+	assert.Empty(t, bg.Status.ApprovedBy)
+	require.NotNil(t, bg.Status.Plan)
+	assert.Equal(t, int64(2), bg.Status.Plan.ObservedGeneration)
+	cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionPending))
+	require.NotNil(t, cond)
+	assert.Equal(t, string(accessv1alpha1.ReasonDryRun), cond.Reason)
+}
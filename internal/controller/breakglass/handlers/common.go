@@ -46,6 +46,14 @@ type Handler struct {
 	Alerts                    controller.AlertService
 	Clock                     controller.Clock
 	Backoff                   time.Duration
+	ClusterApprovalNamespace  string
+	Tickets                   controller.TicketProvider
+	TicketRequired            bool
+	ApprovalWebhook           bool
+	RevokeOnTicketClose       bool
+	TicketPollInterval        time.Duration
+	RevokeOnDrift             bool
+	Deadlines                 controller.DeadlineScheduler
 	recorder                  record.EventRecorder
 	recurringPendingCondition *RecurringPendingCondition
 	recurringActiveCondition  *RecurringActiveCondition
@@ -93,7 +101,9 @@ func (h *Handler) RecurringActiveCondition() *RecurringActiveCondition {
 	return h.recurringActiveCondition
 }
 
-// updateStatus updates the breakglass status with the given condition and reason using a status patch
+// updateStatus moves the breakglass to the phase for condition, records the condition and reason,
+// and writes the status. Transitions the phase table does not allow are refused with a TransitionError
+// and nothing is written.
 func (h *Handler) updateStatus(
 	ctx context.Context,
 	bg *accessv1alpha1.Breakglass,
@@ -101,19 +111,48 @@ func (h *Handler) updateStatus(
 	reason accessv1alpha1.BreakglassConditionReason,
 	message string,
 ) error {
+	now := metav1.Now()
+	to := usecases.PhaseForCondition(condition)
+	if err := usecases.Transition(bg, to, string(reason), transitionActor(bg, to), now.Time); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "refusing phase transition", "condition", condition, "reason", reason)
+		return err
+	}
+
 	bg.Status.ObservedGeneration = bg.Generation
 	conditionObj := metav1.Condition{
 		Type:               string(condition),
 		Status:             metav1.ConditionTrue,
 		Reason:             string(reason),
 		Message:            message,
-		LastTransitionTime: metav1.Now(),
+		LastTransitionTime: now,
 		ObservedGeneration: bg.Generation,
 	}
 	meta.SetStatusCondition(&bg.Status.Conditions, conditionObj)
 
-	return h.Client.Status().Update(ctx, bg)
+	return controller.PatchStatus(ctx, h.Client, bg)
+
+}
 
+// transitionActor returns the user responsible for moving bg to phase to, or DefaultApprover when
+// the controller moved it on its own.
+func transitionActor(bg *accessv1alpha1.Breakglass, to accessv1alpha1.BreakglassPhase) string {
+	actor := ""
+	switch to {
+	case accessv1alpha1.PhaseDenied:
+		actor = bg.Status.DeniedBy
+	case accessv1alpha1.PhaseRevoked:
+		actor = bg.Status.RevokedBy
+	case accessv1alpha1.PhaseApproved, accessv1alpha1.PhaseScheduled, accessv1alpha1.PhaseActive:
+		// Only the first step out of Pending is the approvers' doing
+		switch usecases.CurrentPhase(bg) {
+		case "", accessv1alpha1.PhasePending, accessv1alpha1.PhaseApproved:
+			actor = bg.Status.ApprovedBy
+		}
+	}
+	if actor == "" {
+		return DefaultApprover
+	}
+	return actor
 }
 
 // GrantAndActivate grants access, sets ApprovedBy, updates status, and handles backoff.
@@ -130,6 +169,54 @@ func (h *Handler) GrantAndActivate(ctx context.Context, bg *accessv1alpha1.Break
 			return ctrl.Result{RequeueAfter: h.Backoff}, nil
 		}
 
+		// The requester asked for permissions they do not hold themselves
+		var escErr *internalerrors.EscalationError
+		if errors.As(err, &escErr) {
+			h.emitErrorEvent(bg, "PrivilegeEscalationDenied", "%s", escErr.Error())
+			if err := h.updateStatus(
+				ctx,
+				bg,
+				accessv1alpha1.ConditionDenied,
+				accessv1alpha1.ReasonRequesterLacksPermissions,
+				escErr.Error(),
+			); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+
+		// The request targets roles or subjects the operator is configured never to bind
+		var denyErr *internalerrors.DenylistError
+		if errors.As(err, &denyErr) {
+			h.emitErrorEvent(bg, "ProtectedAccessRefused", "%s", denyErr.Error())
+			if err := h.updateStatus(
+				ctx,
+				bg,
+				accessv1alpha1.ConditionDenied,
+				accessv1alpha1.ReasonProtectedAccess,
+				denyErr.Error(),
+			); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+
+		// The request grants access outside its own namespace without an allowance
+		var nsErr *internalerrors.NamespaceRestrictionError
+		if errors.As(err, &nsErr) {
+			h.emitErrorEvent(bg, "NamespaceRestricted", "%s", nsErr.Error())
+			if err := h.updateStatus(
+				ctx,
+				bg,
+				accessv1alpha1.ConditionDenied,
+				accessv1alpha1.ReasonNamespaceRestricted,
+				nsErr.Error(),
+			); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+
 		// Emit error event for permanent failures
 		h.emitAccessGrantFailedEvent(bg, err)
 
@@ -175,6 +262,7 @@ func (h *Handler) GrantAndActivate(ctx context.Context, bg *accessv1alpha1.Break
 			return ctrl.Result{}, err
 		}
 	}
+	usecases.RecordActivation(bg, now)
 
 	// Update status to active
 	if err := h.updateStatus(
@@ -189,12 +277,11 @@ func (h *Handler) GrantAndActivate(ctx context.Context, bg *accessv1alpha1.Break
 
 	// Emit event for successful access grant
 	h.emitAccessGrantedEvent(bg)
+	h.commentOnTicket(ctx, bg, fmt.Sprintf(BreakglassTicketGrantedCommentFmt, displayName(bg), bg.Status.ApprovedBy))
 	// Requeue based on expiration if set
 	if hasWindow {
-		timeUntil := h.Clock.Until(window.End)
-		timeUntil = clampRequeueDuration(timeUntil, 30*time.Second, time.Hour)
-		log.V(1).Info("requeuing until expiration", "after", timeUntil)
-		return ctrl.Result{RequeueAfter: timeUntil}, nil
+		log.V(1).Info("requeuing until expiration", "expiresAt", window.End)
+		return h.requeueAt(bg, window.End), nil
 	}
 
 	return ctrl.Result{}, nil
@@ -246,10 +333,21 @@ func (h *Handler) RevokeAndExpire(ctx context.Context, bg *accessv1alpha1.Breakg
 }
 
 func (h *Handler) postRevokeTransition(ctx context.Context, bg *accessv1alpha1.Breakglass) (ctrl.Result, error) {
+	usecases.CloseActivation(bg, h.Clock.Now())
+	var (
+		result ctrl.Result
+		err    error
+	)
 	if usecases.HasFutureActivations(bg) {
-		return h.transitionToRecurringPending(ctx, bg)
+		result, err = h.transitionToRecurringPending(ctx, bg)
+	} else {
+		result, err = h.markExpired(ctx, bg)
+	}
+	if err == nil {
+		h.commentOnTicket(ctx, bg,
+			fmt.Sprintf(BreakglassTicketRevokedCommentFmt, displayName(bg), DefaultApprover, BreakglassWindowEndedMsg))
 	}
-	return h.markExpired(ctx, bg)
+	return result, err
 }
 
 func (h *Handler) transitionToRecurringPending(ctx context.Context, bg *accessv1alpha1.Breakglass) (ctrl.Result, error) {
@@ -278,12 +376,10 @@ func (h *Handler) transitionToRecurringPending(ctx context.Context, bg *accessv1
 
 	h.emitAccessRevokedEvent(bg)
 
-	requeue := 30 * time.Second
 	if bg.Status.NextActivationAt != nil {
-		requeue = clampRequeueDuration(h.Clock.Until(bg.Status.NextActivationAt.Time), 30*time.Second, time.Hour)
+		return h.requeueAt(bg, bg.Status.NextActivationAt.Time), nil
 	}
-
-	return ctrl.Result{RequeueAfter: requeue}, nil
+	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 }
 
 // emitAccessGrantedEvent emits a Kubernetes event when access is successfully granted
@@ -328,6 +424,20 @@ func (h *Handler) emitAccessRevokeFailedEvent(bg *accessv1alpha1.Breakglass, err
 	h.emitErrorEvent(bg, "AccessRevokeFailed", "Failed to revoke breakglass access: %v", err)
 }
 
+// requeueAt wakes bg at t, or earlier when its ticket is due to be polled. With Deadlines set bg is woken
+// exactly then; otherwise it is requeued after the time left, bounded to between 30 seconds and an hour.
+func (h *Handler) requeueAt(bg *accessv1alpha1.Breakglass, t time.Time) ctrl.Result {
+	until := h.Clock.Until(t)
+	if h.Deadlines == nil {
+		return ctrl.Result{RequeueAfter: h.ticketRequeue(bg, clampRequeueDuration(until, 30*time.Second, time.Hour))}
+	}
+	if poll := h.ticketRequeue(bg, until); poll < until {
+		t = t.Add(poll - until)
+	}
+	h.Deadlines.Schedule(client.ObjectKeyFromObject(bg), t)
+	return ctrl.Result{}
+}
+
 func clampRequeueDuration(d, min, max time.Duration) time.Duration {
 	if d < min {
 		return min
@@ -13,6 +13,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -109,6 +110,7 @@ func TestHandler_GrantAndActivate(t *testing.T) {
 						},
 					},
 				},
+				Status: accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhaseScheduled},
 			}
 
 			scheme := runtime.NewScheme()
@@ -156,10 +158,88 @@ func TestHandler_GrantAndActivate(t *testing.T) {
 				t.Errorf("GrantAndActivate() RequeueAfter = %v, want at least %v", gotAfter, tt.wantAfter)
 			}
 
+			if len(bg.Status.ActivationHistory) != 1 {
+				t.Fatalf("GrantAndActivate() ActivationHistory = %v, want one record", bg.Status.ActivationHistory)
+			}
+			record := bg.Status.ActivationHistory[0]
+			if !record.GrantedAt.Time.Equal(now) || record.RevokedAt != nil {
+				t.Errorf("GrantAndActivate() activation record = %+v, want open window granted at %v", record, now)
+			}
+			if record.ApprovedBy != DefaultApprover {
+				t.Errorf("GrantAndActivate() activation ApprovedBy = %q, want %q", record.ApprovedBy, DefaultApprover)
+			}
+
 		})
 	}
 }
 
+func TestHandler_GrantAndActivate_RequesterLacksPermissions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+	mockOperator.EXPECT().GrantAccess(gomock.Any(), gomock.Any()).Return(
+		internalerrors.NewEscalationError("alice", []string{`clusterRole "edit".rules[0]: cannot delete pods cluster-wide`}))
+
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default"},
+	}
+	scheme := runtime.NewScheme()
+	_ = accessv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&accessv1alpha1.Breakglass{}).
+		WithObjects(bg).
+		Build()
+	handler := &Handler{Client: fakeClient, Operator: mockOperator}
+
+	got, err := handler.GrantAndActivate(context.TODO(), bg)
+	if err != nil {
+		t.Fatalf("GrantAndActivate() error = %v", err)
+	}
+	if got.RequeueAfter != 0 {
+		t.Errorf("GrantAndActivate() RequeueAfter = %v, want 0", got.RequeueAfter)
+	}
+	last := bg.Status.Conditions[len(bg.Status.Conditions)-1]
+	if last.Type != string(accessv1alpha1.ConditionDenied) ||
+		last.Reason != string(accessv1alpha1.ReasonRequesterLacksPermissions) {
+		t.Errorf("GrantAndActivate() condition = %s/%s, want Denied/RequesterLacksPermissions", last.Type, last.Reason)
+	}
+	if bg.Status.GrantedAt != nil {
+		t.Errorf("GrantAndActivate() GrantedAt = %v, want nil", bg.Status.GrantedAt)
+	}
+}
+
+func TestHandler_GrantAndActivate_ProtectedAccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+	mockOperator.EXPECT().GrantAccess(gomock.Any(), gomock.Any()).Return(
+		internalerrors.NewDenylistError([]string{`clusterRole "cluster-admin" is protected`}))
+
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default"},
+	}
+	scheme := runtime.NewScheme()
+	_ = accessv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&accessv1alpha1.Breakglass{}).
+		WithObjects(bg).
+		Build()
+	handler := &Handler{Client: fakeClient, Operator: mockOperator}
+
+	got, err := handler.GrantAndActivate(context.TODO(), bg)
+	if err != nil {
+		t.Fatalf("GrantAndActivate() error = %v", err)
+	}
+	if got.RequeueAfter != 0 {
+		t.Errorf("GrantAndActivate() RequeueAfter = %v, want 0", got.RequeueAfter)
+	}
+	last := bg.Status.Conditions[len(bg.Status.Conditions)-1]
+	if last.Type != string(accessv1alpha1.ConditionDenied) ||
+		last.Reason != string(accessv1alpha1.ReasonProtectedAccess) {
+		t.Errorf("GrantAndActivate() condition = %s/%s, want Denied/ProtectedAccess", last.Type, last.Reason)
+	}
+}
+
 func TestHandler_RevokeAndExpire(t *testing.T) {
 	mock_controller := gomock.NewController(t)
 	defer mock_controller.Finish()
@@ -189,7 +269,7 @@ func TestHandler_RevokeAndExpire(t *testing.T) {
 				expires := granted.Add(30 * time.Minute)
 				bg.Spec.Schedule.Duration = metav1.Duration{Duration: 30 * time.Minute}
 				bg.Status.GrantedAt = &metav1.Time{Time: granted}
-				clock.EXPECT().Now().Return(expires)
+				clock.EXPECT().Now().Return(expires).Times(2)
 			},
 		},
 		// TODO:Consider making the a conditional type of error to distinguish retryable vs permanent errors
@@ -214,6 +294,7 @@ func TestHandler_RevokeAndExpire(t *testing.T) {
 					Duration: metav1.Duration{Duration: 15 * time.Minute},
 				}
 				bg.Status.NextActivationAt = &metav1.Time{Time: next}
+				clock.EXPECT().Now().Return(next.Add(-45 * time.Minute))
 				clock.EXPECT().Until(timeEqual(next)).Return(45 * time.Minute)
 			},
 		},
@@ -234,6 +315,15 @@ func TestHandler_RevokeAndExpire(t *testing.T) {
 						Required: false,
 					},
 				},
+				Status: accessv1alpha1.BreakglassStatus{
+					Phase:            accessv1alpha1.PhaseActive,
+					CreatedResources: []string{"breakglass-role"},
+					ActivationHistory: []accessv1alpha1.ActivationRecord{{
+						Activation:       1,
+						GrantedAt:        metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)),
+						CreatedResources: []string{"breakglass-role"},
+					}},
+				},
 			}
 			scheme := runtime.NewScheme()
 			_ = accessv1alpha1.AddToScheme(scheme)
@@ -293,6 +383,17 @@ func TestHandler_RevokeAndExpire(t *testing.T) {
 				}
 			}
 
+			if tt.wantCond != "" {
+				record := fresh.Status.ActivationHistory[0]
+				if record.RevokedAt == nil {
+					t.Errorf("expected activation record to be closed, got %+v", record)
+				}
+				if len(record.CreatedResources) != 1 || len(fresh.Status.CreatedResources) != 0 {
+					t.Errorf("expected resources to move to the activation record, got record %v and status %v",
+						record.CreatedResources, fresh.Status.CreatedResources)
+				}
+			}
+
 			if tt.wantCond == accessv1alpha1.ConditionRecurringPending && fresh.Status.ExpiresAt != nil {
 				t.Errorf("expected ExpiresAt to be cleared for recurring revoke, got %v", fresh.Status.ExpiresAt)
 			}
@@ -300,6 +401,65 @@ func TestHandler_RevokeAndExpire(t *testing.T) {
 	}
 }
 
+// recordedDeadlines records the deadlines a handler schedules
+type recordedDeadlines map[types.NamespacedName]time.Time
+
+func (d recordedDeadlines) Schedule(key types.NamespacedName, at time.Time) { d[key] = at }
+func (d recordedDeadlines) Forget(key types.NamespacedName)                 { delete(d, key) }
+
+func TestHandler_RequeueAt(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	key := types.NamespacedName{Namespace: "default", Name: "test-breakglass"}
+
+	tests := []struct {
+		name        string
+		until       time.Duration
+		scheduler   bool
+		ticketPoll  time.Duration
+		wantRequeue time.Duration
+		wantAt      time.Time
+	}{
+		{name: "polling clamps to the minimum", until: 5 * time.Second, wantRequeue: 30 * time.Second},
+		{name: "polling clamps to the maximum", until: 3 * time.Hour, wantRequeue: time.Hour},
+		{name: "deadline is exact", until: 5 * time.Second, scheduler: true, wantAt: now.Add(5 * time.Second)},
+		{name: "deadline is not capped", until: 3 * time.Hour, scheduler: true, wantAt: now.Add(3 * time.Hour)},
+		{
+			name: "ticket poll comes first", until: 3 * time.Hour, scheduler: true, ticketPoll: 10 * time.Minute,
+			wantAt: now.Add(10 * time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClock := mocks.NewMockClock(ctrl)
+			mockClock.EXPECT().Until(timeEqual(now.Add(tt.until))).Return(tt.until)
+
+			deadlines := recordedDeadlines{}
+			handler := &Handler{Clock: mockClock, TicketPollInterval: tt.ticketPoll}
+			if tt.scheduler {
+				handler.Deadlines = deadlines
+			}
+			if tt.ticketPoll > 0 {
+				handler.Tickets = newTicketProvider(t, "OPS-1: open\n")
+				handler.RevokeOnTicketClose = true
+			}
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec:       accessv1alpha1.BreakglassSpec{TicketID: "OPS-1"},
+			}
+
+			result := handler.requeueAt(bg, now.Add(tt.until))
+			if result.RequeueAfter != tt.wantRequeue {
+				t.Errorf("expected RequeueAfter %v, got %v", tt.wantRequeue, result.RequeueAfter)
+			}
+			if at, ok := deadlines[key]; ok != tt.scheduler || !at.Equal(tt.wantAt) {
+				t.Errorf("expected deadline %v (scheduled %v), got %v (scheduled %v)", tt.wantAt, tt.scheduler, at, ok)
+			}
+		})
+	}
+}
+
 func TestEmitAccessGrantedEvent(t *testing.T) {
 	// Test that the function doesn't panic when recorder is nil
 	bg := &accessv1alpha1.Breakglass{
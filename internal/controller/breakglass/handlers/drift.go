@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	"github.com/cloud-nimbus/firedoor/internal/telemetry/metrics"
+)
+
+// BreakglassDriftRevokedMsgFmt is the Revoked message when tampering with granted RBAC revokes access
+const BreakglassDriftRevokedMsgFmt = "Access revoked after granted RBAC objects were tampered with: %s"
+
+// Drift actions recorded in the drift metric
+const (
+	driftActionRepair = "repair"
+	driftActionRevoke = "revoke"
+	driftActionRemove = "remove"
+)
+
+// checkDrift compares the live RBAC of bg, which is active, with what it grants.
+// Edited, deleted or stray objects are repaired, or revoke bg as a security event when RevokeOnDrift
+// is set. It reports whether bg was revoked, in which case the result is that of the revocation.
+func (h *Handler) checkDrift(ctx context.Context, bg *accessv1alpha1.Breakglass) (bool, ctrl.Result, error) {
+	drift, err := h.Operator.DetectDrift(ctx, bg, true)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to check granted RBAC for drift")
+		return false, ctrl.Result{}, err
+	}
+	if len(drift) == 0 {
+		return false, ctrl.Result{}, nil
+	}
+
+	summary := driftSummary(drift)
+	if h.RevokeOnDrift {
+		ctrl.LoggerFrom(ctx).Info("granted RBAC was tampered with, revoking access", "drift", summary)
+		recordDrift(drift, driftActionRevoke)
+		h.emitErrorEvent(bg, "RBACDrift", "Granted RBAC was tampered with, revoking access: %s", summary)
+		result, err := h.revoke(ctx, bg, DefaultApprover, accessv1alpha1.ReasonRBACDrift,
+			fmt.Sprintf(BreakglassDriftRevokedMsgFmt, summary))
+		return true, result, err
+	}
+
+	ctrl.LoggerFrom(ctx).Info("granted RBAC was tampered with, repairing", "drift", summary)
+	if err := h.Operator.RepairDrift(ctx, bg, drift); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to repair granted RBAC")
+		return false, ctrl.Result{}, err
+	}
+	recordDrift(drift, driftActionRepair)
+	h.emitErrorEvent(bg, "RBACDrift", "Granted RBAC was tampered with and has been repaired: %s", summary)
+	return false, ctrl.Result{}, nil
+}
+
+// migrateLegacyNames moves RBAC granted to active bg by an operator that generated different names onto
+// the current names and writes the renamed status.createdResources, so the objects are neither reported
+// as tampering nor mistaken for a grant change.
+func (h *Handler) migrateLegacyNames(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	migrated, err := h.Operator.MigrateLegacyNames(ctx, bg)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to migrate RBAC granted under legacy names")
+		return err
+	}
+	if !migrated {
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("migrated RBAC granted under legacy names", "resources", bg.Status.CreatedResources)
+	if err := controller.PatchStatus(ctx, h.Client, bg); err != nil {
+		return err
+	}
+	if h.recorder != nil {
+		h.recorder.Eventf(bg, "Normal", "RBACMigrated", "Moved granted RBAC to current names: %s",
+			strings.Join(bg.Status.CreatedResources, ", "))
+	}
+	return nil
+}
+
+// removeStray deletes RBAC objects labelled for bg while it is outside an active window.
+func (h *Handler) removeStray(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	drift, err := h.Operator.DetectDrift(ctx, bg, false)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to look for stray RBAC")
+		return err
+	}
+	if len(drift) == 0 {
+		return nil
+	}
+
+	summary := driftSummary(drift)
+	ctrl.LoggerFrom(ctx).Info("removing RBAC outside an active window", "drift", summary)
+	if err := h.Operator.RepairDrift(ctx, bg, drift); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to remove stray RBAC")
+		return err
+	}
+	recordDrift(drift, driftActionRemove)
+	h.emitErrorEvent(bg, "StrayRBACRemoved", "Removed RBAC objects outside an active window: %s", summary)
+	return nil
+}
+
+func driftSummary(drift []controller.Drift) string {
+	parts := make([]string, 0, len(drift))
+	for _, d := range drift {
+		parts = append(parts, d.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+func recordDrift(drift []controller.Drift, action string) {
+	for _, d := range drift {
+		metrics.RecordRBACDrift(d.Kind, string(d.Change), action)
+	}
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	"github.com/cloud-nimbus/firedoor/internal/controller/mocks"
+)
+
+func TestHandler_CheckDrift(t *testing.T) {
+	drift := []controller.Drift{{
+		Kind:      accessv1alpha1.KindRoleBinding,
+		Namespace: "apps",
+		Name:      "breakglass-01234567-rolebinding-0",
+		Change:    controller.DriftModified,
+	}}
+
+	tests := []struct {
+		name        string
+		revoke      bool
+		wantRevoked bool
+	}{
+		{name: "repairs tampering"},
+		{name: "revokes on tampering", revoke: true, wantRevoked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockClock := mocks.NewMockClock(mockCtrl)
+			mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+			mockClock.EXPECT().Now().Return(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)).AnyTimes()
+			mockOperator.EXPECT().DetectDrift(gomock.Any(), gomock.Any(), true).Return(drift, nil)
+			if tt.revoke {
+				mockOperator.EXPECT().RevokeAccess(gomock.Any(), gomock.Any()).Return(nil)
+			} else {
+				mockOperator.EXPECT().RepairDrift(gomock.Any(), gomock.Any(), drift).Return(nil)
+			}
+
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default"},
+				Status:     accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhaseActive},
+			}
+			handler := newApprovalTestHandler(bg)
+			handler.Clock = mockClock
+			handler.Operator = mockOperator
+			handler.RevokeOnDrift = tt.revoke
+
+			revoked, _, err := handler.checkDrift(context.Background(), bg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRevoked, revoked)
+			if !tt.wantRevoked {
+				assert.Equal(t, accessv1alpha1.PhaseActive, bg.Status.Phase)
+				return
+			}
+
+			assert.Equal(t, accessv1alpha1.PhaseRevoked, bg.Status.Phase)
+			cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionRevoked))
+			require.NotNil(t, cond)
+			assert.Equal(t, string(accessv1alpha1.ReasonRBACDrift), cond.Reason)
+			assert.Contains(t, cond.Message, "RoleBinding apps/breakglass-01234567-rolebinding-0 modified")
+		})
+	}
+}
+
+func TestRecurringActiveCondition_DriftWithoutWindow(t *testing.T) {
+	drift := []controller.Drift{{
+		Kind:   accessv1alpha1.KindClusterRoleBinding,
+		Name:   "breakglass-clusterrolebinding-view-0123456789abcdef",
+		Change: controller.DriftMissing,
+	}}
+	mockCtrl := gomock.NewController(t)
+	mockClock := mocks.NewMockClock(mockCtrl)
+	mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+	mockClock.EXPECT().Now().Return(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)).AnyTimes()
+	mockOperator.EXPECT().MigrateLegacyNames(gomock.Any(), gomock.Any()).Return(false, nil)
+	mockOperator.EXPECT().DetectDrift(gomock.Any(), gomock.Any(), true).Return(drift, nil)
+	mockOperator.EXPECT().RevokeAccess(gomock.Any(), gomock.Any()).Return(nil)
+
+	// A one-shot without duration or until stays active with no window to expire
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			ClusterRoles: []string{"view"},
+			Schedule:     accessv1alpha1.ScheduleSpec{Start: metav1.NewTime(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))},
+		},
+		Status: accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhaseActive},
+	}
+	handler := newApprovalTestHandler(bg)
+	handler.Clock = mockClock
+	handler.Operator = mockOperator
+	handler.RevokeOnDrift = true
+
+	result, err := NewRecurringActiveCondition(handler).Handle(context.Background(), bg)
+	require.NoError(t, err)
+	assert.Zero(t, result)
+
+	assert.Equal(t, accessv1alpha1.PhaseRevoked, bg.Status.Phase)
+	cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionRevoked))
+	require.NotNil(t, cond)
+	assert.Equal(t, string(accessv1alpha1.ReasonRBACDrift), cond.Reason)
+}
+
+func TestHandler_MigrateLegacyNames(t *testing.T) {
+	current := "breakglass-clusterrolebinding-view-0123456789abcdef"
+	mockCtrl := gomock.NewController(t)
+	mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+	mockOperator.EXPECT().MigrateLegacyNames(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, bg *accessv1alpha1.Breakglass) (bool, error) {
+			bg.Status.CreatedResources = []string{current}
+			return true, nil
+		})
+
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default"},
+		Status: accessv1alpha1.BreakglassStatus{
+			Phase:            accessv1alpha1.PhaseActive,
+			CreatedResources: []string{"breakglass-01234567-clusterrolebinding-view"},
+		},
+	}
+	handler := newApprovalTestHandler(bg)
+	handler.Operator = mockOperator
+	recorder := record.NewFakeRecorder(10)
+	handler.recorder = recorder
+
+	require.NoError(t, handler.migrateLegacyNames(context.Background(), bg))
+
+	var fresh accessv1alpha1.Breakglass
+	require.NoError(t, handler.Client.Get(context.Background(), client.ObjectKeyFromObject(bg), &fresh))
+	assert.Equal(t, []string{current}, fresh.Status.CreatedResources)
+	assert.Contains(t, <-recorder.Events, "Normal RBACMigrated")
+}
+
+func TestTerminalCondition_RemovesStrayRBAC(t *testing.T) {
+	stray := []controller.Drift{{
+		Kind:   accessv1alpha1.KindClusterRoleBinding,
+		Name:   "breakglass-01234567-clusterrolebinding-view",
+		Change: controller.DriftStray,
+	}}
+	mockCtrl := gomock.NewController(t)
+	mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+	mockOperator.EXPECT().DetectDrift(gomock.Any(), gomock.Any(), false).Return(stray, nil)
+	mockOperator.EXPECT().RepairDrift(gomock.Any(), gomock.Any(), stray).Return(nil)
+
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default"},
+		Status:     accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhaseExpired},
+	}
+	handler := newApprovalTestHandler(bg)
+	handler.Operator = mockOperator
+
+	result, err := NewTerminalCondition(handler, accessv1alpha1.PhaseExpired).Handle(context.Background(), bg)
+	require.NoError(t, err)
+	assert.Zero(t, result)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
+)
+
+const (
+	BreakglassExtendedMsgFmt        = "Breakglass access extended by %s until %s"
+	BreakglassExtensionDeniedMsgFmt = "Breakglass extension %s denied by %s: %s"
+	BreakglassExtensionCappedMsgFmt = "Breakglass access extended by %s until %s, cut from %s by schedule.until"
+)
+
+// processExtensions decides every extension request that has no recorded outcome yet and
+// persists the updated history. Approved extensions push the end of the current window out
+// by their duration; usecases.CurrentWindow picks the new end up from the status.
+func (h *Handler) processExtensions(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	log := ctrl.LoggerFrom(ctx)
+	changed := false
+
+	for i := range bg.Spec.Extensions {
+		ext := &bg.Spec.Extensions[i]
+		if hasExtensionRecord(bg, ext.Name) {
+			continue
+		}
+
+		now := h.Clock.Now()
+		window, ok := usecases.CurrentWindow(bg, now)
+		if !ok {
+			h.recordExtensionDenied(bg, ext, DefaultApprover, metav1.NewTime(now), "no finite active window to extend")
+			changed = true
+			continue
+		}
+
+		decidedBy := DefaultApprover
+		decidedAt := metav1.NewTime(now)
+		if requiresApproval(bg) {
+			if reason, msg := h.approvalBlocker(bg); reason != "" {
+				h.recordExtensionDenied(bg, ext, DefaultApprover, decidedAt, msg)
+				changed = true
+				continue
+			}
+			tally, err := h.tallyApprovals(ctx, bg, ext)
+			if err != nil {
+				return err
+			}
+			if tally.denial != nil {
+				h.recordExtensionDenied(bg, ext, tally.denial.Spec.Approver.Username,
+					tally.denial.CreationTimestamp, tally.denial.Spec.Reason)
+				changed = true
+				continue
+			}
+			if !tally.approved() {
+				log.V(1).Info("extension waiting for approval", "extension", ext.Name, "progress", tally.progress())
+				continue
+			}
+			decidedBy = strings.Join(tally.approvers(), ",")
+			decidedAt = tally.approvals[tally.required-1].CreationTimestamp
+		}
+
+		start := metav1.NewTime(window.Start)
+		end, capped := usecases.ExtendedEnd(bg, window, ext.Duration.Duration)
+		until := metav1.NewTime(end)
+		msg := fmt.Sprintf(BreakglassExtendedMsgFmt, decidedBy, until.UTC().Format(time.RFC3339))
+		if capped {
+			requested := window.End.Add(ext.Duration.Duration).UTC().Format(time.RFC3339)
+			msg = fmt.Sprintf(BreakglassExtensionCappedMsgFmt, decidedBy, until.UTC().Format(time.RFC3339), requested)
+			log.Info("extension cut at schedule.until", "extension", ext.Name, "until", until)
+			h.emitErrorEvent(bg, "ExtensionCapped", "%s", msg)
+		}
+		bg.Status.Extensions = append(bg.Status.Extensions, accessv1alpha1.ExtensionRecord{
+			Name:          ext.Name,
+			Duration:      ext.Duration,
+			Decision:      accessv1alpha1.DecisionApprove,
+			DecidedBy:     decidedBy,
+			DecidedAt:     decidedAt,
+			WindowStart:   &start,
+			ExtendedUntil: &until,
+			Message:       msg,
+		})
+		log.Info("breakglass window extended", "extension", ext.Name, "extendedUntil", until)
+		h.emitAccessExtendedEvent(bg, msg)
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return controller.PatchStatus(ctx, h.Client, bg)
+}
+
+// recordExtensionDenied appends a denied extension to the history.
+func (h *Handler) recordExtensionDenied(
+	bg *accessv1alpha1.Breakglass,
+	ext *accessv1alpha1.ExtensionRequest,
+	deniedBy string,
+	at metav1.Time,
+	reason string,
+) {
+	msg := fmt.Sprintf(BreakglassExtensionDeniedMsgFmt, ext.Name, deniedBy, reason)
+	bg.Status.Extensions = append(bg.Status.Extensions, accessv1alpha1.ExtensionRecord{
+		Name:      ext.Name,
+		Duration:  ext.Duration,
+		Decision:  accessv1alpha1.DecisionDeny,
+		DecidedBy: deniedBy,
+		DecidedAt: at,
+		Message:   msg,
+	})
+	h.emitErrorEvent(bg, "ExtensionDenied", "%s", msg)
+}
+
+// hasExtensionRecord reports whether the extension has already been decided.
+func hasExtensionRecord(bg *accessv1alpha1.Breakglass, name string) bool {
+	for _, record := range bg.Status.Extensions {
+		if record.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// emitAccessExtendedEvent emits a Kubernetes event when the active window is extended
+func (h *Handler) emitAccessExtendedEvent(bg *accessv1alpha1.Breakglass, msg string) {
+	if h.recorder == nil {
+		return
+	}
+
+	h.recorder.Event(bg, "Normal", "AccessExtended", msg)
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller/mocks"
+)
+
+func TestHandler_ProcessExtensions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	extensionApproval := func(name string, decision accessv1alpha1.ApprovalDecision, approver string) client.Object {
+		approval := newApproval(name, decision, approver, now)
+		approval.Spec.Extension = "more-time"
+		return approval
+	}
+
+	tests := []struct {
+		name         string
+		approval     *accessv1alpha1.ApprovalSpec
+		approvals    []client.Object
+		noWebhook    bool
+		until        time.Time
+		wantDecision accessv1alpha1.ApprovalDecision
+		wantUntil    time.Time
+		wantBy       string
+		wantCapped   bool
+	}{
+		{
+			name:         "auto-approved without approval rules",
+			wantDecision: accessv1alpha1.DecisionApprove,
+			wantUntil:    start.Add(2 * time.Hour),
+			wantBy:       DefaultApprover,
+		},
+		{
+			name:         "cut at schedule.until",
+			until:        start.Add(90 * time.Minute),
+			wantDecision: accessv1alpha1.DecisionApprove,
+			wantUntil:    start.Add(90 * time.Minute),
+			wantBy:       DefaultApprover,
+			wantCapped:   true,
+		},
+		{
+			name:     "waits for approval",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true},
+			approvals: []client.Object{
+				newApproval("original", accessv1alpha1.DecisionApprove, "bob", now),
+			},
+		},
+		{
+			name:     "approved by quorum",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true},
+			approvals: []client.Object{
+				extensionApproval("self", accessv1alpha1.DecisionApprove, "alice"),
+				extensionApproval("ext", accessv1alpha1.DecisionApprove, "bob"),
+			},
+			wantDecision: accessv1alpha1.DecisionApprove,
+			wantUntil:    start.Add(2 * time.Hour),
+			wantBy:       "bob",
+		},
+		{
+			name:     "denied",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true},
+			approvals: []client.Object{
+				extensionApproval("ext", accessv1alpha1.DecisionDeny, "bob"),
+			},
+			wantDecision: accessv1alpha1.DecisionDeny,
+			wantBy:       "bob",
+		},
+		{
+			name:     "denied without the admission webhook",
+			approval: &accessv1alpha1.ApprovalSpec{Required: true},
+			approvals: []client.Object{
+				extensionApproval("ext", accessv1alpha1.DecisionApprove, "bob"),
+			},
+			noWebhook:    true,
+			wantDecision: accessv1alpha1.DecisionDeny,
+			wantBy:       DefaultApprover,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockClock := mocks.NewMockClock(mockCtrl)
+			mockClock.EXPECT().Now().Return(now).AnyTimes()
+
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-breakglass",
+					UID:         testBreakglassUID,
+					Namespace:   "default",
+					Annotations: map[string]string{accessv1alpha1.RequestedByAnnotation: "carol"},
+				},
+				Spec: accessv1alpha1.BreakglassSpec{
+					Approval: tt.approval,
+					Schedule: accessv1alpha1.ScheduleSpec{
+						Start:    metav1.NewTime(start),
+						Duration: metav1.Duration{Duration: time.Hour},
+					},
+					Extensions: []accessv1alpha1.ExtensionRequest{{
+						Name:        "more-time",
+						Duration:    metav1.Duration{Duration: time.Hour},
+						Reason:      "still debugging",
+						RequestedBy: &accessv1alpha1.UserIdentity{Username: "alice"},
+					}},
+				},
+			}
+			if !tt.until.IsZero() {
+				until := metav1.NewTime(tt.until)
+				bg.Spec.Schedule.Until = &until
+			}
+			handler := newApprovalTestHandler(append(tt.approvals, bg)...)
+			handler.Clock = mockClock
+			handler.ApprovalWebhook = !tt.noWebhook
+			recorder := record.NewFakeRecorder(10)
+			handler.recorder = recorder
+
+			require.NoError(t, handler.processExtensions(context.Background(), bg))
+
+			if tt.wantDecision == "" {
+				assert.Empty(t, bg.Status.Extensions)
+				return
+			}
+			require.Len(t, bg.Status.Extensions, 1)
+			record := bg.Status.Extensions[0]
+			assert.Equal(t, tt.wantDecision, record.Decision)
+			assert.Equal(t, tt.wantBy, record.DecidedBy)
+			if tt.wantUntil.IsZero() {
+				assert.Nil(t, record.ExtendedUntil)
+				return
+			}
+			require.NotNil(t, record.ExtendedUntil)
+			assert.True(t, tt.wantUntil.Equal(record.ExtendedUntil.Time))
+			assert.True(t, start.Equal(record.WindowStart.Time))
+			if tt.wantCapped {
+				assert.Contains(t, record.Message, "cut from 2024-01-01T14:00:00Z by schedule.until")
+				assert.Contains(t, <-recorder.Events, "Warning ExtensionCapped")
+			} else {
+				assert.NotContains(t, record.Message, "schedule.until")
+			}
+
+			// Already decided extensions are not processed again.
+			require.NoError(t, handler.processExtensions(context.Background(), bg))
+			assert.Len(t, bg.Status.Extensions, 1)
+		})
+	}
+}
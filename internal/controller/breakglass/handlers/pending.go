@@ -18,6 +18,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -41,6 +42,12 @@ func NewPendingCondition(handler *Handler) *PendingCondition {
 func (h *PendingCondition) Handle(ctx context.Context, bg *accessv1alpha1.Breakglass) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
+	// Invalid requests are not retried until their spec changes
+	if awaitingSpecChange(bg) {
+		log.V(1).Info("invalid request, waiting for spec change")
+		return ctrl.Result{}, nil
+	}
+
 	// initialize Pending
 	if len(bg.Status.Conditions) == 0 {
 		if err := h.handler.updateStatus(
@@ -55,22 +62,66 @@ func (h *PendingCondition) Handle(ctx context.Context, bg *accessv1alpha1.Breakg
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// A spec changed since the request was validated is validated again
+	if bg.Status.ObservedGeneration != bg.Generation {
+		if valid, err := h.handler.validateRequest(ctx, bg); err != nil || !valid {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Guardrails are checked on every pass so a policy change before activation takes effect
+	if admitted, err := h.handler.enforcePolicy(ctx, bg); err != nil || !admitted {
+		return ctrl.Result{}, err
+	}
+
+	if bg.Spec.DryRun {
+		log.V(1).Info("dry run, rendering plan only")
+		return h.handler.DryRun(ctx, bg)
+	}
+
+	if open, err := h.handler.checkTicket(ctx, bg); err != nil || !open {
+		return ctrl.Result{}, err
+	}
+
 	// Approval-required path
-	if bg.Spec.Approval != nil && bg.Spec.Approval.Required {
-		if bg.Status.ApprovedBy == "" {
-			log.V(1).Info("waiting for approval")
+	if requiresApproval(bg) {
+		if reason, msg := h.handler.approvalBlocker(bg); reason != "" {
+			log.Info("approvals cannot be counted", "reason", reason)
+			return ctrl.Result{}, h.handler.updateStatus(ctx, bg, accessv1alpha1.ConditionFailed, reason, msg)
+		}
+		tally, err := h.handler.tallyApprovals(ctx, bg, nil)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if tally.denial != nil {
+			log.Info("breakglass request denied",
+				"approval", tally.denial.Name, "deniedBy", tally.denial.Spec.Approver.Username)
+			return h.handler.Deny(ctx, bg, tally.denial)
+		}
+		recordApprovals(bg, tally)
+		if !tally.approved() {
+			log.V(1).Info("waiting for approval", "progress", tally.progress())
+			// The plan is advisory; failing to render it does not hold up the request
+			if err := h.handler.renderPlan(ctx, bg); err != nil {
+				log.Error(err, "failed to render RBAC plan for approvers")
+			}
 			if err := h.handler.updateStatus(
 				ctx,
 				bg,
 				accessv1alpha1.ConditionPending,
 				accessv1alpha1.ReasonWaitingForApproval,
-				"Breakglass request is pending approval",
+				fmt.Sprintf(BreakglassPendingApprovalMsgFmt, tally.progress()),
 			); err != nil {
 				return ctrl.Result{}, err
 			}
+			// Approvals and policies are watched, so only a polling controller needs to look again
+			if h.handler.Deadlines != nil {
+				return ctrl.Result{}, nil
+			}
 			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 		}
-		log.V(1).Info("approval received, processing schedule")
+		h.handler.emitAccessApprovedEvent(bg)
+		log.V(1).Info("approval received, processing schedule", "approvedBy", bg.Status.ApprovedBy)
 		return h.handler.RecurringPendingCondition().Handle(ctx, bg)
 	}
 
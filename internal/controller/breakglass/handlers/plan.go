@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+// BreakglassDryRunMsgFmt is the Pending message of a dry run
+const BreakglassDryRunMsgFmt = "Dry run: %d RBAC objects planned, nothing was granted"
+
+// renderPlan stores the RBAC bg would create in status.plan. The plan is only rendered again when
+// the spec has changed since. The caller writes the status.
+func (h *Handler) renderPlan(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	if bg.Status.Plan != nil && bg.Status.Plan.ObservedGeneration == bg.Generation {
+		return nil
+	}
+
+	plan, err := h.Operator.PlanAccess(ctx, bg)
+	if err != nil {
+		return err
+	}
+	plan.ObservedGeneration = bg.Generation
+	plan.RenderedAt = metav1.Now()
+	bg.Status.Plan = plan
+	ctrl.LoggerFrom(ctx).V(1).Info("rendered RBAC plan", "objects", len(plan.Objects), "warnings", plan.Warnings)
+	return nil
+}
+
+// DryRun renders the plan of a spec.dryRun request and keeps it Pending without granting anything.
+// Clearing spec.dryRun changes the generation, which reconciles the request again.
+func (h *Handler) DryRun(ctx context.Context, bg *accessv1alpha1.Breakglass) (ctrl.Result, error) {
+	if err := h.renderPlan(ctx, bg); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to render RBAC plan")
+		return ctrl.Result{}, err
+	}
+
+	if err := h.updateStatus(
+		ctx,
+		bg,
+		accessv1alpha1.ConditionPending,
+		accessv1alpha1.ReasonDryRun,
+		fmt.Sprintf(BreakglassDryRunMsgFmt, len(bg.Status.Plan.Objects)),
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/policy"
+)
+
+// enforcePolicy re-evaluates the BreakglassPolicies before access is granted, since policies may
+// have changed since admission. It records the admitting policy on the status and returns false
+// after moving bg to Denied when no policy admits it.
+func (h *Handler) enforcePolicy(ctx context.Context, bg *accessv1alpha1.Breakglass) (bool, error) {
+	admitted, err := policy.Admit(ctx, h.Client, bg)
+	if err == nil {
+		bg.Status.AdmittedByPolicy = ""
+		if admitted != nil {
+			bg.Status.AdmittedByPolicy = admitted.Name
+		}
+		return true, nil
+	}
+
+	var violation *policy.ViolationError
+	if !errors.As(err, &violation) {
+		return false, err
+	}
+
+	ctrl.LoggerFrom(ctx).Info("breakglass request violates policy", "reason", err.Error())
+	if err := h.updateStatus(
+		ctx,
+		bg,
+		accessv1alpha1.ConditionDenied,
+		accessv1alpha1.ReasonPolicyViolation,
+		violation.Error(),
+	); err != nil {
+		return false, err
+	}
+	h.emitErrorEvent(bg, "PolicyViolation", "%s", violation.Error())
+	return false, nil
+}
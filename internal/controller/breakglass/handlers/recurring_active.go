@@ -24,6 +24,16 @@ func NewRecurringActiveCondition(handler *Handler) *RecurringActiveCondition {
 func (h *RecurringActiveCondition) Handle(ctx context.Context, bg *accessv1alpha1.Breakglass) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
+	// Apply any decided extensions before checking expiry so the extended end is honoured
+	if err := h.handler.processExtensions(ctx, bg); err != nil {
+		log.Error(err, "failed to process breakglass extensions")
+		return ctrl.Result{}, err
+	}
+
+	if h.handler.ticketClosed(ctx, bg) {
+		return h.handler.RevokeForClosedTicket(ctx, bg)
+	}
+
 	now := h.handler.Clock.Now()
 	window, hasWindow := usecases.CurrentWindow(bg, now)
 	// Check if the current access period has expired
@@ -32,51 +42,33 @@ func (h *RecurringActiveCondition) Handle(ctx context.Context, bg *accessv1alpha
 		return h.handler.RevokeAndExpire(ctx, bg)
 	}
 
-	// Process schedule logic to check for next activation
-	if err := h.handler.RecurringManager.ProcessRecurring(ctx, bg); err != nil {
-		log.Error(err, "failed to process recurring breakglass")
+	// RBAC granted before an upgrade is moved to the current names before it is compared with the spec
+	if err := h.handler.migrateLegacyNames(ctx, bg); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// If the condition changed to RecurringPending, requeue to handle it
-	if len(bg.Status.Conditions) > 0 {
-		lastCond := bg.Status.Conditions[len(bg.Status.Conditions)-1]
-		if lastCond.Type == string(accessv1alpha1.ConditionRecurringPending) {
-			return ctrl.Result{Requeue: true}, nil
-		}
+	// Spec changes reach the live RBAC before it is checked for tampering
+	if stop, result, err := h.handler.syncGrants(ctx, bg); stop || err != nil {
+		return result, err
 	}
 
-	// Calculate requeue time based on expiration or next activation
-	if hasWindow {
-		untilExpiry := h.handler.Clock.Until(window.End)
-
-		// Cap the requeue time to a reasonable maximum (1 hour)
-		maxInterval := time.Hour
-		if untilExpiry > maxInterval {
-			untilExpiry = maxInterval
-		}
-
-		// Ensure minimum requeue time (30 seconds)
-		minInterval := 30 * time.Second
-		if untilExpiry < minInterval {
-			untilExpiry = minInterval
-		}
+	// Granted RBAC must match the request for as long as it is active, with or without a finite window
+	if revoked, result, err := h.handler.checkDrift(ctx, bg); revoked || err != nil {
+		return result, err
+	}
 
-		return ctrl.Result{RequeueAfter: untilExpiry}, nil
+	// Process schedule logic to check for next activation
+	if err := h.handler.RecurringManager.ProcessRecurring(ctx, bg); err != nil {
+		log.Error(err, "failed to process recurring breakglass")
+		return ctrl.Result{}, err
 	}
 
+	// Wake up for the expiry, or the next activation
+	if hasWindow {
+		return h.handler.requeueAt(bg, window.End), nil
+	}
 	if bg.Status.NextActivationAt != nil {
-		untilNext := h.handler.Clock.Until(bg.Status.NextActivationAt.Time)
-		// ensure sane bounds similar to recurring pending
-		maxInterval := time.Hour
-		if untilNext > maxInterval {
-			untilNext = maxInterval
-		}
-		minInterval := 30 * time.Second
-		if untilNext < minInterval {
-			untilNext = minInterval
-		}
-		return ctrl.Result{RequeueAfter: untilNext}, nil
+		return h.handler.requeueAt(bg, bg.Status.NextActivationAt.Time), nil
 	}
 
 	// Fallback requeue
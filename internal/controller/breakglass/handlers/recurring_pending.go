@@ -7,6 +7,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
 )
 
 // RecurringPendingCondition handles breakglass requests in the recurring pending condition
@@ -23,8 +24,8 @@ func NewRecurringPendingCondition(handler *Handler) *RecurringPendingCondition {
 func (h *RecurringPendingCondition) Handle(ctx context.Context, bg *accessv1alpha1.Breakglass) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	// Set RecurringPending condition if not already set
-	if len(bg.Status.Conditions) == 0 || bg.Status.Conditions[len(bg.Status.Conditions)-1].Type != string(accessv1alpha1.ConditionRecurringPending) {
+	// Move to Scheduled if not already there
+	if usecases.CurrentPhase(bg) != accessv1alpha1.PhaseScheduled {
 		if err := h.handler.updateStatus(
 			ctx,
 			bg,
@@ -38,34 +39,36 @@ func (h *RecurringPendingCondition) Handle(ctx context.Context, bg *accessv1alph
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// A closed ticket stops further windows from opening
+	if h.handler.ticketClosed(ctx, bg) {
+		return h.handler.RevokeForClosedTicket(ctx, bg)
+	}
+
 	if err := h.handler.RecurringManager.ProcessRecurring(ctx, bg); err != nil {
 		log.Error(err, "failed to process recurring breakglass")
 		return ctrl.Result{}, err
 	}
 
+	// The schedule can run out while waiting for the next window
+	if usecases.ScheduleEnded(bg, h.handler.Clock.Now()) {
+		log.Info("no activations left in schedule, expiring")
+		return h.handler.markExpired(ctx, bg)
+	}
+
 	// Check if it's time to activate
 	if h.handler.RecurringManager.ShouldActivate(ctx, bg) {
 		log.Info("recurring breakglass activation due, granting access")
 		return h.handler.GrantAndActivate(ctx, bg)
 	}
 
-	// Calculate requeue time based on next activation
-	if bg.Status.NextActivationAt != nil {
-		untilNext := h.handler.Clock.Until(bg.Status.NextActivationAt.Time)
-
-		// Cap the requeue time to a reasonable maximum (1 hour)
-		maxInterval := time.Hour
-		if untilNext > maxInterval {
-			untilNext = maxInterval
-		}
-
-		// Ensure minimum requeue time (30 seconds)
-		minInterval := 30 * time.Second
-		if untilNext < minInterval {
-			untilNext = minInterval
-		}
+	// Nothing is granted between windows
+	if err := h.handler.removeStray(ctx, bg); err != nil {
+		return ctrl.Result{}, err
+	}
 
-		return ctrl.Result{RequeueAfter: untilNext}, nil
+	// Wake up for the next activation
+	if bg.Status.NextActivationAt != nil {
+		return h.handler.requeueAt(bg, bg.Status.NextActivationAt.Time), nil
 	}
 
 	// Fallback requeue
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+const BreakglassRevokedMsgFmt = "Breakglass access revoked by %s: %s"
+
+// Revoke handles a manual early revocation requested through spec.revocation.
+// It removes any granted RBAC, stops future recurring activations and moves the
+// request to the terminal Revoked condition. The object itself is kept for audit.
+func (h *Handler) Revoke(ctx context.Context, bg *accessv1alpha1.Breakglass) (ctrl.Result, error) {
+	revocation := bg.Spec.Revocation
+	revoker := DefaultApprover
+	if revocation.RevokedBy != nil && revocation.RevokedBy.Username != "" {
+		revoker = revocation.RevokedBy.Username
+	}
+	ctrl.LoggerFrom(ctx).Info("revoking breakglass access on request", "revokedBy", revoker, "reason", revocation.Reason)
+	return h.revoke(ctx, bg, revoker, accessv1alpha1.ReasonAccessRevoked, revocation.Reason)
+}
+
+// revoke removes any granted RBAC and moves bg to Revoked on behalf of revoker.
+func (h *Handler) revoke(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+	revoker string,
+	condReason accessv1alpha1.BreakglassConditionReason,
+	reason string,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if err := h.Operator.RevokeAccess(ctx, bg); err != nil {
+		var rbacErr *internalerrors.RBACError
+		switch {
+		case errors.As(err, &rbacErr) && rbacErr.IsRetryable():
+			log.Info("retryable RBAC error, will retry", "operation", rbacErr.Operation, "resource", rbacErr.Resource)
+			return ctrl.Result{RequeueAfter: h.Backoff}, nil
+		case rbacErr != nil && internalerrors.IsNotFoundError(rbacErr.Err):
+			log.Info("RBAC resources already deleted, proceeding with revocation")
+		default:
+			log.Error(err, "revoke failed")
+			h.emitAccessRevokeFailedEvent(bg, err)
+			_ = h.updateStatus(ctx, bg,
+				accessv1alpha1.ConditionFailed,
+				accessv1alpha1.ReasonRevokeFailed,
+				err.Error(),
+			)
+			return ctrl.Result{}, err
+		}
+	}
+
+	now := metav1.NewTime(h.Clock.Now())
+	bg.Status.RevokedBy = revoker
+	bg.Status.RevokedAt = &now
+	bg.Status.ExpiresAt = &now
+	bg.Status.NextActivationAt = nil
+	usecases.CloseActivation(bg, now.Time)
+
+	if err := h.updateStatus(
+		ctx,
+		bg,
+		accessv1alpha1.ConditionRevoked,
+		condReason,
+		fmt.Sprintf(BreakglassRevokedMsgFmt, revoker, reason),
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	h.emitManualRevocationEvent(bg, revoker, reason)
+	h.commentOnTicket(ctx, bg, fmt.Sprintf(BreakglassTicketRevokedCommentFmt, displayName(bg), revoker, reason))
+	return ctrl.Result{}, nil
+}
+
+// emitManualRevocationEvent emits a Kubernetes event when access is revoked on request
+func (h *Handler) emitManualRevocationEvent(bg *accessv1alpha1.Breakglass, revoker, reason string) {
+	if h.recorder == nil {
+		return
+	}
+
+	h.recorder.Eventf(bg, "Normal", "AccessRevoked", BreakglassRevokedMsgFmt, revoker, reason)
+}
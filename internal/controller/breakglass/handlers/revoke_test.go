@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller/mocks"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+func TestHandler_Revoke(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	next := metav1.NewTime(now.Add(24 * time.Hour))
+
+	tests := []struct {
+		name        string
+		revokeErr   error
+		wantRequeue bool
+		wantRevoked bool
+	}{
+		{
+			name:        "revokes active access",
+			wantRevoked: true,
+		},
+		{
+			name: "resources already gone",
+			revokeErr: internalerrors.NewRBACError("revoke", "mock-role", accessv1alpha1.ReasonRevokeFailed,
+				apierrors.NewNotFound(schema.GroupResource{Resource: "rolebindings"}, "mock-role"), false),
+			wantRevoked: true,
+		},
+		{
+			name: "retryable error requeues",
+			revokeErr: internalerrors.NewRetryableRBACError("revoke", "mock-role", accessv1alpha1.ReasonRBACTimeout,
+				errors.New("simulated RBAC revoke error")),
+			wantRequeue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockClock := mocks.NewMockClock(mockCtrl)
+			mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+			mockClock.EXPECT().Now().Return(now).AnyTimes()
+			mockOperator.EXPECT().RevokeAccess(gomock.Any(), gomock.Any()).Return(tt.revokeErr)
+
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default"},
+				Spec: accessv1alpha1.BreakglassSpec{
+					Schedule: accessv1alpha1.ScheduleSpec{Cron: "0 9 * * *"},
+					Revocation: &accessv1alpha1.RevocationSpec{
+						Reason:    "incident closed",
+						RevokedBy: &accessv1alpha1.UserIdentity{Username: "bob"},
+					},
+				},
+				Status: accessv1alpha1.BreakglassStatus{
+					NextActivationAt: &next,
+					Conditions: []metav1.Condition{{
+						Type:   string(accessv1alpha1.ConditionRecurringActive),
+						Status: metav1.ConditionTrue,
+						Reason: string(accessv1alpha1.ReasonRecurringActivated),
+					}},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			_ = accessv1alpha1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(&accessv1alpha1.Breakglass{}).
+				WithObjects(bg).
+				Build()
+			handler := &Handler{
+				Client:   fakeClient,
+				Clock:    mockClock,
+				Operator: mockOperator,
+				Backoff:  DefaultBackoff,
+			}
+
+			result, err := handler.Revoke(context.Background(), bg)
+			require.NoError(t, err)
+
+			if tt.wantRequeue {
+				assert.Equal(t, DefaultBackoff, result.RequeueAfter)
+				assert.Empty(t, bg.Status.RevokedBy)
+				return
+			}
+
+			cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionRevoked))
+			require.NotNil(t, cond)
+			assert.Equal(t, string(accessv1alpha1.ReasonAccessRevoked), cond.Reason)
+			assert.Contains(t, cond.Message, "incident closed")
+			assert.Equal(t, "bob", bg.Status.RevokedBy)
+			require.NotNil(t, bg.Status.RevokedAt)
+			assert.True(t, bg.Status.RevokedAt.Time.Equal(now))
+			assert.Nil(t, bg.Status.NextActivationAt, "future activations are stopped")
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+	"github.com/cloud-nimbus/firedoor/internal/policy"
+)
+
+// BreakglassGrantsImmutableMsg is the Revoked message when the grants of active access changed while no
+// policy allows it
+const BreakglassGrantsImmutableMsg = "subjects or roles changed but no BreakglassPolicy allows grant changes"
+
+// driftActionSync is recorded in the drift metric for changes made to apply a new spec
+const driftActionSync = "sync"
+
+// syncGrants applies a spec change of active bg to its live RBAC. It only runs when the generation moved
+// since the status was last written, and only acts when the subjects, clusterRoles, clusterRoleScope or
+// policy differ from those the window was granted from, as recorded in status.grantsHash. Live objects
+// that differ from an unchanged spec are left to drift repair. bg is revoked when the admitting policy
+// does not set allowGrantChanges or the new spec is refused. It reports whether reconciling should stop
+// here, in which case the result is the one to return.
+func (h *Handler) syncGrants(ctx context.Context, bg *accessv1alpha1.Breakglass) (bool, ctrl.Result, error) {
+	if bg.Status.ObservedGeneration == bg.Generation {
+		return false, ctrl.Result{}, nil
+	}
+
+	changed, summary, err := h.grantsChanged(ctx, bg)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to compare granted RBAC with the spec")
+		return false, ctrl.Result{}, err
+	}
+	if changed {
+		if stop, result, err := h.applyGrantChanges(ctx, bg, summary); stop || err != nil {
+			return true, result, err
+		}
+	}
+
+	bg.Status.ObservedGeneration = bg.Generation
+	if bg.Status.GrantsHash == "" {
+		// The live RBAC matched the spec, so it is what the window now counts as granted from
+		bg.Status.GrantsHash = usecases.GrantsHash(&bg.Spec)
+	}
+	if err := controller.PatchStatus(ctx, h.Client, bg); err != nil {
+		return false, ctrl.Result{}, err
+	}
+	return false, ctrl.Result{}, nil
+}
+
+// grantsChanged reports whether the grants in the spec of bg differ from those its open window was
+// granted from, with a summary for events. Windows granted before status.grantsHash was recorded are
+// compared against the live RBAC instead.
+func (h *Handler) grantsChanged(ctx context.Context, bg *accessv1alpha1.Breakglass) (bool, string, error) {
+	if bg.Status.GrantsHash != "" {
+		if bg.Status.GrantsHash == usecases.GrantsHash(&bg.Spec) {
+			return false, "", nil
+		}
+		return true, "subjects, clusterRoles, clusterRoleScope or policy changed", nil
+	}
+
+	drift, err := h.Operator.DetectDrift(ctx, bg, true)
+	if err != nil {
+		return false, "", err
+	}
+	return len(drift) > 0, driftSummary(drift), nil
+}
+
+// applyGrantChanges converges the live RBAC of bg on its changed spec, or revokes bg when the change
+// is not allowed. It reports whether reconciling should stop here.
+func (h *Handler) applyGrantChanges(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+	summary string,
+) (bool, ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	admitted, err := policy.Admit(ctx, h.Client, bg)
+	var violation *policy.ViolationError
+	switch {
+	case errors.As(err, &violation):
+		log.Info("changed spec violates policy, revoking access", "reason", violation.Error())
+		h.emitErrorEvent(bg, "PolicyViolation", "%s", violation.Error())
+		result, err := h.revoke(ctx, bg, DefaultApprover, accessv1alpha1.ReasonPolicyViolation, violation.Error())
+		return true, result, err
+	case err != nil:
+		return false, ctrl.Result{}, err
+	case admitted == nil || !admitted.Spec.AllowGrantChanges:
+		log.Info("grants of active access changed without a policy allowing it, revoking access", "change", summary)
+		h.emitErrorEvent(bg, "GrantsImmutable", "Subjects or roles changed while access was active: %s", summary)
+		result, err := h.revoke(ctx, bg, DefaultApprover, accessv1alpha1.ReasonGrantsImmutable,
+			BreakglassGrantsImmutableMsg)
+		return true, result, err
+	}
+
+	changes, err := h.Operator.SyncAccess(ctx, bg)
+	if err != nil {
+		var rbacErr *internalerrors.RBACError
+		if errors.As(err, &rbacErr) && rbacErr.IsRetryable() {
+			log.Info("retryable RBAC error, will retry", "operation", rbacErr.Operation, "resource", rbacErr.Resource)
+			return true, ctrl.Result{RequeueAfter: h.Backoff}, nil
+		}
+		log.Error(err, "changed spec cannot be granted, revoking access")
+		h.emitErrorEvent(bg, "GrantUpdateRefused", "%s", err.Error())
+		result, err := h.revoke(ctx, bg, DefaultApprover, refusalReason(err), err.Error())
+		return true, result, err
+	}
+
+	usecases.RecordActivation(bg, h.Clock.Now())
+	recordDrift(changes, driftActionSync)
+	log.Info("applied spec changes to granted RBAC", "changes", driftSummary(changes))
+	if h.recorder != nil {
+		h.recorder.Eventf(bg, "Normal", "AccessUpdated", "Applied spec changes to granted RBAC: %s",
+			driftSummary(changes))
+	}
+	return false, ctrl.Result{}, nil
+}
+
+// refusalReason returns the condition reason for an error GrantAccess or SyncAccess refused a spec with.
+func refusalReason(err error) accessv1alpha1.BreakglassConditionReason {
+	var (
+		escErr  *internalerrors.EscalationError
+		denyErr *internalerrors.DenylistError
+		nsErr   *internalerrors.NamespaceRestrictionError
+		rbacErr *internalerrors.RBACError
+	)
+	switch {
+	case errors.As(err, &escErr):
+		return accessv1alpha1.ReasonRequesterLacksPermissions
+	case errors.As(err, &denyErr):
+		return accessv1alpha1.ReasonProtectedAccess
+	case errors.As(err, &nsErr):
+		return accessv1alpha1.ReasonNamespaceRestricted
+	case errors.As(err, &rbacErr) && rbacErr.Condition != "":
+		return rbacErr.Condition
+	}
+	return accessv1alpha1.ReasonRBACCreationFailed
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
+	"github.com/cloud-nimbus/firedoor/internal/controller/mocks"
+)
+
+func TestHandler_SyncGrants(t *testing.T) {
+	drift := []controller.Drift{{
+		Kind:   accessv1alpha1.KindClusterRoleBinding,
+		Name:   "breakglass-clusterrolebinding-edit-0f1e",
+		Change: controller.DriftMissing,
+	}, {
+		Kind:   accessv1alpha1.KindClusterRoleBinding,
+		Name:   "breakglass-clusterrolebinding-view-9a8b",
+		Change: controller.DriftStray,
+	}}
+	mutable := &accessv1alpha1.BreakglassPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "ops"},
+		Spec: accessv1alpha1.BreakglassPolicySpec{
+			AllowedClusterRoles: []string{"*"},
+			AllowClusterScope:   true,
+			AllowGrantChanges:   true,
+		},
+	}
+	immutable := mutable.DeepCopy()
+	immutable.Spec.AllowGrantChanges = false
+
+	spec := accessv1alpha1.BreakglassSpec{
+		Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		ClusterRoles: []string{"edit"},
+	}
+	previous := spec.DeepCopy()
+	previous.ClusterRoles = []string{"view"}
+	unchanged, changed := usecases.GrantsHash(&spec), usecases.GrantsHash(previous)
+
+	tests := []struct {
+		name        string
+		generation  int64
+		grantsHash  string
+		policy      *accessv1alpha1.BreakglassPolicy
+		drift       []controller.Drift
+		wantSync    bool
+		wantRevoked bool
+	}{
+		{name: "generation unchanged", generation: 1, grantsHash: changed},
+		// live objects differing from an unchanged spec are drift repair's business
+		{name: "grants unchanged", generation: 2, grantsHash: unchanged, policy: immutable},
+		{name: "policy allows grant changes", generation: 2, grantsHash: changed, policy: mutable, wantSync: true},
+		{name: "policy forbids grant changes", generation: 2, grantsHash: changed, policy: immutable, wantRevoked: true},
+		{name: "no policy", generation: 2, grantsHash: changed, wantRevoked: true},
+		{name: "granted before grants were recorded, without drift", generation: 2, policy: immutable},
+		{name: "granted before grants were recorded", generation: 2, policy: mutable, drift: drift, wantSync: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockClock := mocks.NewMockClock(mockCtrl)
+			mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+			mockClock.EXPECT().Now().Return(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)).AnyTimes()
+			if tt.generation != 1 && tt.grantsHash == "" {
+				mockOperator.EXPECT().DetectDrift(gomock.Any(), gomock.Any(), true).Return(tt.drift, nil)
+			}
+			if tt.wantSync {
+				mockOperator.EXPECT().SyncAccess(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, bg *accessv1alpha1.Breakglass) ([]controller.Drift, error) {
+						bg.Status.CreatedResources = []string{"breakglass-clusterrolebinding-edit-0f1e"}
+						return drift, nil
+					})
+			}
+			if tt.wantRevoked {
+				mockOperator.EXPECT().RevokeAccess(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default", Generation: tt.generation},
+				Spec:       *spec.DeepCopy(),
+				Status: accessv1alpha1.BreakglassStatus{
+					Phase:              accessv1alpha1.PhaseActive,
+					ObservedGeneration: 1,
+					GrantsHash:         tt.grantsHash,
+					CreatedResources:   []string{"breakglass-clusterrolebinding-view-9a8b"},
+					ActivationHistory: []accessv1alpha1.ActivationRecord{{
+						Activation:       1,
+						CreatedResources: []string{"breakglass-clusterrolebinding-view-9a8b"},
+					}},
+				},
+			}
+			objs := []client.Object{bg}
+			if tt.policy != nil {
+				objs = append(objs, tt.policy)
+			}
+			handler := newApprovalTestHandler(objs...)
+			handler.Clock = mockClock
+			handler.Operator = mockOperator
+
+			stop, _, err := handler.syncGrants(context.Background(), bg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRevoked, stop)
+			if tt.wantRevoked {
+				assert.Equal(t, accessv1alpha1.PhaseRevoked, bg.Status.Phase)
+				cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionRevoked))
+				require.NotNil(t, cond)
+				assert.Equal(t, string(accessv1alpha1.ReasonGrantsImmutable), cond.Reason)
+				return
+			}
+
+			assert.Equal(t, accessv1alpha1.PhaseActive, bg.Status.Phase)
+			assert.Equal(t, tt.generation, bg.Status.ObservedGeneration)
+			if tt.generation != 1 {
+				assert.Equal(t, unchanged, bg.Status.GrantsHash, "the spec now granted is recorded")
+			}
+			if tt.wantSync {
+				assert.Equal(t, []string{"breakglass-clusterrolebinding-edit-0f1e"},
+					bg.Status.ActivationHistory[0].CreatedResources)
+			}
+		})
+	}
+}
@@ -9,16 +9,21 @@ import (
 )
 
 type TerminalCondition struct {
-	handler   *Handler
-	condition accessv1alpha1.BreakglassCondition
+	handler *Handler
+	phase   accessv1alpha1.BreakglassPhase
 }
 
-func NewTerminalCondition(h *Handler, c accessv1alpha1.BreakglassCondition) *TerminalCondition {
-	return &TerminalCondition{handler: h, condition: c}
+func NewTerminalCondition(h *Handler, p accessv1alpha1.BreakglassPhase) *TerminalCondition {
+	return &TerminalCondition{handler: h, phase: p}
 }
 
 func (t *TerminalCondition) Handle(ctx context.Context, bg *accessv1alpha1.Breakglass) (ctrl.Result, error) {
-	log := ctrl.LoggerFrom(ctx).WithValues("condition", t.condition)
+	log := ctrl.LoggerFrom(ctx).WithValues("phase", t.phase)
 	log.V(1).Info("terminal state reached")
+
+	// RBAC showing up for a finished request is removed again
+	if err := t.handler.removeStray(ctx, bg); err != nil {
+		return ctrl.Result{}, err
+	}
 	return ctrl.Result{}, nil
 }
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+const (
+	// BreakglassTicketGrantedCommentFmt is the ticket comment posted when access is granted
+	BreakglassTicketGrantedCommentFmt = "Breakglass access %s granted by %s"
+	// BreakglassTicketRevokedCommentFmt is the ticket comment posted when access is revoked
+	BreakglassTicketRevokedCommentFmt = "Breakglass access %s revoked by %s: %s"
+	// BreakglassTicketClosedMsg is the revocation reason when the linked ticket was closed
+	BreakglassTicketClosedMsg = "linked ticket was closed"
+	// BreakglassWindowEndedMsg is the revocation reason when an activation window ends
+	BreakglassWindowEndedMsg = "access window ended"
+	// BreakglassTicketRequiredMsg is the denial message for requests without a ticket
+	BreakglassTicketRequiredMsg = "spec.ticketID is required"
+)
+
+// checkTicket verifies the ticket linked by spec.ticketID exists and is open before access is granted.
+// It returns false after moving bg to Denied when the ticket is missing or closed, or when a ticket is
+// required and none is linked. Lookup failures are returned so the request is retried.
+func (h *Handler) checkTicket(ctx context.Context, bg *accessv1alpha1.Breakglass) (bool, error) {
+	if h.Tickets == nil {
+		return true, nil
+	}
+
+	id := bg.Spec.TicketID
+	var reason string
+	if id == "" {
+		if !h.TicketRequired {
+			return true, nil
+		}
+		reason = BreakglassTicketRequiredMsg
+	} else {
+		open, err := h.Tickets.IsOpen(ctx, id)
+		switch {
+		case internalerrors.IsTicketNotFoundError(err):
+			reason = err.Error()
+		case err != nil:
+			ctrl.LoggerFrom(ctx).Error(err, "failed to look up ticket", "ticket", id)
+			return false, err
+		case !open:
+			reason = fmt.Sprintf("ticket %s is closed", id)
+		default:
+			return true, nil
+		}
+	}
+
+	ctrl.LoggerFrom(ctx).Info("breakglass request has no open ticket", "ticket", id, "reason", reason)
+	if err := h.updateStatus(
+		ctx,
+		bg,
+		accessv1alpha1.ConditionDenied,
+		accessv1alpha1.ReasonTicketInvalid,
+		reason,
+	); err != nil {
+		return false, err
+	}
+	h.emitErrorEvent(bg, "TicketInvalid", "%s", reason)
+	return false, nil
+}
+
+// ticketClosed reports whether the ticket linked to bg has been closed or deleted since access was
+// approved. Lookup failures are logged and treated as open so an outage of the ticket system does not
+// revoke access.
+func (h *Handler) ticketClosed(ctx context.Context, bg *accessv1alpha1.Breakglass) bool {
+	if !h.watchesTicket(bg) {
+		return false
+	}
+	open, err := h.Tickets.IsOpen(ctx, bg.Spec.TicketID)
+	if internalerrors.IsTicketNotFoundError(err) {
+		return true
+	}
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to look up ticket", "ticket", bg.Spec.TicketID)
+		return false
+	}
+	return !open
+}
+
+// RevokeForClosedTicket revokes access because the ticket linked to bg was closed.
+func (h *Handler) RevokeForClosedTicket(ctx context.Context, bg *accessv1alpha1.Breakglass) (ctrl.Result, error) {
+	ctrl.LoggerFrom(ctx).Info("linked ticket was closed, revoking access", "ticket", bg.Spec.TicketID)
+	return h.revoke(ctx, bg, DefaultApprover, accessv1alpha1.ReasonTicketClosed, BreakglassTicketClosedMsg)
+}
+
+// commentOnTicket posts body to the ticket linked to bg. Failures are logged and reported as an event
+// but never fail the reconcile, since access has already changed by the time a comment is posted.
+func (h *Handler) commentOnTicket(ctx context.Context, bg *accessv1alpha1.Breakglass, body string) {
+	if h.Tickets == nil || bg.Spec.TicketID == "" {
+		return
+	}
+	if err := h.Tickets.Comment(ctx, bg.Spec.TicketID, body); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to comment on ticket", "ticket", bg.Spec.TicketID)
+		h.emitErrorEvent(bg, "TicketCommentFailed", "Failed to comment on ticket %s: %v", bg.Spec.TicketID, err)
+	}
+}
+
+// ticketRequeue caps d at the ticket poll interval while the ticket linked to bg is watched for closure.
+func (h *Handler) ticketRequeue(bg *accessv1alpha1.Breakglass, d time.Duration) time.Duration {
+	if h.watchesTicket(bg) && h.TicketPollInterval > 0 && d > h.TicketPollInterval {
+		return h.TicketPollInterval
+	}
+	return d
+}
+
+func (h *Handler) watchesTicket(bg *accessv1alpha1.Breakglass) bool {
+	return h.Tickets != nil && h.RevokeOnTicketClose && bg.Spec.TicketID != ""
+}
+
+// displayName names bg in ticket comments.
+func displayName(bg *accessv1alpha1.Breakglass) string {
+	if bg.Namespace == "" {
+		return bg.Name
+	}
+	return bg.Namespace + "/" + bg.Name
+}
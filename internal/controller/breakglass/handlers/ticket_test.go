@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller/mocks"
+	"github.com/cloud-nimbus/firedoor/internal/ticket"
+)
+
+func newTicketProvider(t *testing.T, tickets string) *ticket.FileProvider {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tickets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(tickets), 0o600))
+	return ticket.NewFileProvider(path, []string{"closed", "resolved"})
+}
+
+func TestPendingCondition_TicketInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		ticketID string
+		required bool
+		wantMsg  string
+	}{
+		{name: "unknown ticket", ticketID: "INC-404", wantMsg: "ticket INC-404 does not exist"},
+		{name: "closed ticket", ticketID: "INC-2", wantMsg: "ticket INC-2 is closed"},
+		{name: "missing required ticket", required: true, wantMsg: BreakglassTicketRequiredMsg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default"},
+				Spec: accessv1alpha1.BreakglassSpec{
+					TicketID: tt.ticketID,
+					Approval: &accessv1alpha1.ApprovalSpec{Required: true},
+				},
+				Status: accessv1alpha1.BreakglassStatus{
+					Phase: accessv1alpha1.PhasePending,
+					Conditions: []metav1.Condition{{
+						Type:   string(accessv1alpha1.ConditionPending),
+						Status: metav1.ConditionTrue,
+						Reason: string(accessv1alpha1.ReasonWaitingForApproval),
+					}},
+				},
+			}
+			handler := newApprovalTestHandler(bg)
+			handler.Tickets = newTicketProvider(t, "INC-1: open\nINC-2: resolved\n")
+			handler.TicketRequired = tt.required
+
+			result, err := NewPendingCondition(handler).Handle(context.Background(), bg)
+			require.NoError(t, err)
+			assert.Zero(t, result)
+
+			assert.Equal(t, accessv1alpha1.PhaseDenied, bg.Status.Phase)
+			cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionDenied))
+			require.NotNil(t, cond)
+			assert.Equal(t, string(accessv1alpha1.ReasonTicketInvalid), cond.Reason)
+			assert.Equal(t, tt.wantMsg, cond.Message)
+		})
+	}
+}
+
+func TestRecurringActiveCondition_TicketClosed(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockCtrl := gomock.NewController(t)
+	mockClock := mocks.NewMockClock(mockCtrl)
+	mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+	mockClock.EXPECT().Now().Return(now)
+	mockOperator.EXPECT().RevokeAccess(gomock.Any(), gomock.Any()).Return(nil)
+
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			TicketID: "INC-1",
+			Schedule: accessv1alpha1.ScheduleSpec{Cron: "0 9 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+		},
+		Status: accessv1alpha1.BreakglassStatus{
+			Phase:            accessv1alpha1.PhaseActive,
+			CreatedResources: []string{"breakglass-role"},
+		},
+	}
+	handler := newApprovalTestHandler(bg)
+	handler.Clock = mockClock
+	handler.Operator = mockOperator
+	tickets := newTicketProvider(t, "INC-1: closed\n")
+	handler.Tickets = tickets
+	handler.RevokeOnTicketClose = true
+
+	result, err := NewRecurringActiveCondition(handler).Handle(context.Background(), bg)
+	require.NoError(t, err)
+	assert.Zero(t, result)
+
+	assert.Equal(t, accessv1alpha1.PhaseRevoked, bg.Status.Phase)
+	assert.Equal(t, DefaultApprover, bg.Status.RevokedBy)
+	assert.Empty(t, bg.Status.CreatedResources)
+	cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionRevoked))
+	require.NotNil(t, cond)
+	assert.Equal(t, string(accessv1alpha1.ReasonTicketClosed), cond.Reason)
+	assert.Equal(t, []string{"Breakglass access default/test-breakglass revoked by system: linked ticket was closed"},
+		tickets.Comments("INC-1"))
+}
+
+func TestTicketRequeue(t *testing.T) {
+	handler := &Handler{
+		Tickets:             newTicketProvider(t, "INC-1: open\n"),
+		RevokeOnTicketClose: true,
+		TicketPollInterval:  5 * time.Minute,
+	}
+	bg := &accessv1alpha1.Breakglass{Spec: accessv1alpha1.BreakglassSpec{TicketID: "INC-1"}}
+
+	assert.Equal(t, 5*time.Minute, handler.ticketRequeue(bg, time.Hour))
+	assert.Equal(t, time.Minute, handler.ticketRequeue(bg, time.Minute))
+
+	bg.Spec.TicketID = ""
+	assert.Equal(t, time.Hour, handler.ticketRequeue(bg, time.Hour), "requests without a ticket are not polled")
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+// ValidatingCondition checks new breakglass requests before they wait for approval
+type ValidatingCondition struct {
+	handler *Handler
+}
+
+// NewValidatingCondition creates a new ValidatingCondition
+func NewValidatingCondition(handler *Handler) *ValidatingCondition {
+	return &ValidatingCondition{handler: handler}
+}
+
+// Handle validates a new breakglass request and moves it to Pending, or to Failed when it can never
+// be granted as written
+func (h *ValidatingCondition) Handle(ctx context.Context, bg *accessv1alpha1.Breakglass) (ctrl.Result, error) {
+	// Record Validating first so the phase is visible while the checks run
+	if usecases.CurrentPhase(bg) != accessv1alpha1.PhaseValidating {
+		if err := h.handler.updateStatus(
+			ctx,
+			bg,
+			accessv1alpha1.ConditionValidating,
+			accessv1alpha1.ReasonNewResource,
+			"Breakglass request is being validated",
+		); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if valid, err := h.handler.validateRequest(ctx, bg); err != nil || !valid {
+		return ctrl.Result{}, err
+	}
+
+	if err := h.handler.updateStatus(
+		ctx,
+		bg,
+		accessv1alpha1.ConditionPending,
+		accessv1alpha1.ReasonNewResource,
+		"Breakglass request is pending approval",
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// validateRequest runs the operator's pre-flight checks on bg. It returns false after moving bg to
+// Failed with reason InvalidRequest when the request references something that does not exist or its
+// schedule can no longer open a window. Failures reading the cluster are returned so the check is retried.
+func (h *Handler) validateRequest(ctx context.Context, bg *accessv1alpha1.Breakglass) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+	err := h.Operator.ValidateAccess(ctx, bg)
+	if err == nil {
+		return true, nil
+	}
+
+	var invalid *internalerrors.ValidationError
+	if !errors.As(err, &invalid) {
+		log.Error(err, "failed to validate breakglass request")
+		return false, err
+	}
+
+	log.Info("breakglass request is invalid", "reasons", invalid.Reasons)
+	if err := h.updateStatus(
+		ctx,
+		bg,
+		accessv1alpha1.ConditionFailed,
+		accessv1alpha1.ReasonInvalidRequest,
+		invalid.Error(),
+	); err != nil {
+		return false, err
+	}
+	h.emitErrorEvent(bg, "InvalidRequest", "%s", invalid.Error())
+	return false, nil
+}
+
+// awaitingSpecChange reports whether bg failed validation and its spec has not changed since, in
+// which case retrying cannot succeed.
+func awaitingSpecChange(bg *accessv1alpha1.Breakglass) bool {
+	return usecases.FailedValidation(bg) && bg.Status.ObservedGeneration == bg.Generation
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller/mocks"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+func TestValidatingCondition(t *testing.T) {
+	tests := []struct {
+		name        string
+		validateErr error
+		wantPhase   accessv1alpha1.BreakglassPhase
+	}{
+		{name: "valid request waits for approval", wantPhase: accessv1alpha1.PhasePending},
+		{
+			name:        "invalid request fails",
+			validateErr: internalerrors.NewValidationError([]string{`clusterRole "edit" does not exist`}),
+			wantPhase:   accessv1alpha1.PhaseFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+			mockOperator.EXPECT().ValidateAccess(gomock.Any(), gomock.Any()).Return(tt.validateErr)
+
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default", Generation: 1},
+			}
+			handler := newApprovalTestHandler(bg)
+			handler.Operator = mockOperator
+			validating := NewValidatingCondition(handler)
+
+			// the first pass only records the Validating phase
+			result, err := validating.Handle(context.Background(), bg)
+			require.NoError(t, err)
+			assert.True(t, result.Requeue)
+			assert.Equal(t, accessv1alpha1.PhaseValidating, bg.Status.Phase)
+
+			_, err = validating.Handle(context.Background(), bg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPhase, bg.Status.Phase)
+			if tt.validateErr == nil {
+				return
+			}
+
+			cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionFailed))
+			require.NotNil(t, cond)
+			assert.Equal(t, string(accessv1alpha1.ReasonInvalidRequest), cond.Reason)
+			assert.Equal(t, `invalid request: clusterRole "edit" does not exist`, cond.Message)
+
+			// Failed requests are retried from Pending, but not while the invalid spec is unchanged
+			result, err = NewPendingCondition(handler).Handle(context.Background(), bg)
+			require.NoError(t, err)
+			assert.Zero(t, result)
+			assert.Equal(t, accessv1alpha1.PhaseFailed, bg.Status.Phase)
+		})
+	}
+}
+
+func TestPendingCondition_RevalidatesChangedSpec(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockOperator := mocks.NewMockBreakglassOperator(mockCtrl)
+	mockOperator.EXPECT().ValidateAccess(gomock.Any(), gomock.Any()).
+		Return(internalerrors.NewValidationError([]string{`namespace "staging" does not exist`}))
+
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-breakglass", Namespace: "default", Generation: 2},
+		Spec:       accessv1alpha1.BreakglassSpec{Approval: &accessv1alpha1.ApprovalSpec{Required: true}},
+		Status: accessv1alpha1.BreakglassStatus{
+			ObservedGeneration: 1,
+			Phase:              accessv1alpha1.PhasePending,
+			Conditions: []metav1.Condition{{
+				Type:   string(accessv1alpha1.ConditionPending),
+				Status: metav1.ConditionTrue,
+				Reason: string(accessv1alpha1.ReasonWaitingForApproval),
+			}},
+		},
+	}
+	handler := newApprovalTestHandler(bg)
+	handler.Operator = mockOperator
+
+	_, err := NewPendingCondition(handler).Handle(context.Background(), bg)
+	require.NoError(t, err)
+	assert.Equal(t, accessv1alpha1.PhaseFailed, bg.Status.Phase)
+	assert.Equal(t, int64(2), bg.Status.ObservedGeneration)
+}
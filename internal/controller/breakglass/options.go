@@ -3,6 +3,7 @@ package breakglass
 import (
 	"k8s.io/client-go/tools/record"
 
+	"github.com/cloud-nimbus/firedoor/internal/config"
 	"github.com/cloud-nimbus/firedoor/internal/controller"
 )
 
@@ -44,9 +45,23 @@ func WithTelemetry(telemetry controller.TelemetrySink) Option {
 	}
 }
 
+// WithTicketProvider injects the TicketProvider spec.ticketID is checked against.
+func WithTicketProvider(tickets controller.TicketProvider) Option {
+	return func(r *BreakglassReconciler) {
+		r.Tickets = tickets
+	}
+}
+
 // WithEventRecorder injects an EventRecorder implementation.
 func WithEventRecorder(recorder record.EventRecorder) Option {
 	return func(r *BreakglassReconciler) {
 		r.recorder = recorder
 	}
 }
+
+// WithConfig injects the operator configuration.
+func WithConfig(cfg *config.Config) Option {
+	return func(r *BreakglassReconciler) {
+		r.Config = cfg
+	}
+}
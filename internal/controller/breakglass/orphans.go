@@ -0,0 +1,255 @@
+package breakglass
+
+import (
+	"context"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/clock"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
+	"github.com/cloud-nimbus/firedoor/internal/operator/rbac"
+	"github.com/cloud-nimbus/firedoor/internal/telemetry/metrics"
+)
+
+// Owner states reported for removed orphans
+const (
+	orphanOwnerMissing  = "missing"
+	orphanOwnerInactive = "inactive"
+)
+
+// OrphanCollector periodically deletes RBAC objects labelled for a Breakglass or ClusterBreakglass that
+// no longer exists or is outside an active window. Such objects are left behind when a request is
+// force-deleted with its finalizer stripped, or when a revoke only partially succeeds.
+type OrphanCollector struct {
+	Client client.Client
+	// Reader looks up owners. The manager's API reader is used so a stale cache never makes a live
+	// request look deleted.
+	Reader   client.Reader
+	Clock    controller.Clock
+	Interval time.Duration
+	// MinAge spares objects younger than this, which may belong to a grant whose status is not
+	// written yet.
+	MinAge   time.Duration
+	recorder record.EventRecorder
+}
+
+// NewOrphanCollector creates an OrphanCollector sweeping every interval.
+func NewOrphanCollector(c client.Client, interval, minAge time.Duration) *OrphanCollector {
+	return &OrphanCollector{Client: c, Interval: interval, MinAge: minAge}
+}
+
+// SetupWithManager fills in unset dependencies and runs the collector with the manager. Only the
+// leader sweeps.
+func (g *OrphanCollector) SetupWithManager(mgr ctrl.Manager) error {
+	if g.Reader == nil {
+		g.Reader = mgr.GetAPIReader()
+	}
+	if g.Clock == nil {
+		g.Clock = clock.SimpleClock{}
+	}
+	if g.recorder == nil {
+		g.recorder = mgr.GetEventRecorderFor("firedoor-orphan-collector")
+	}
+	return mgr.Add(g)
+}
+
+// Start implements manager.Runnable. It sweeps once straight away, then every Interval until ctx is done.
+func (g *OrphanCollector) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("orphan-collector")
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	ticker := time.NewTicker(g.Interval)
+	defer ticker.Stop()
+	for {
+		if removed, err := g.Sweep(ctx); err != nil {
+			log.Error(err, "orphaned RBAC sweep failed", "removed", removed)
+		} else if removed > 0 {
+			log.Info("removed orphaned RBAC", "removed", removed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep deletes every orphaned RBAC object once and returns how many were removed. It carries on past
+// objects it fails to check or delete and returns the last error.
+func (g *OrphanCollector) Sweep(ctx context.Context) (int, error) {
+	objects, err := g.labelledObjects(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := g.Clock.Now()
+	owners := make(map[types.UID]*ownerState)
+	removed := 0
+	var lastErr error
+	for _, obj := range objects {
+		if now.Sub(obj.GetCreationTimestamp().Time) < g.MinAge {
+			continue
+		}
+
+		uid := types.UID(obj.GetLabels()[rbac.LabelUID])
+		owner, ok := owners[uid]
+		if !ok {
+			if owner, err = g.lookupOwner(ctx, obj.GetLabels(), uid, now); err != nil {
+				lastErr = err
+				continue
+			}
+			owners[uid] = owner
+		}
+		if owner.active {
+			continue
+		}
+
+		if err := client.IgnoreNotFound(g.Client.Delete(ctx, obj)); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "failed to delete orphaned RBAC",
+				"kind", orphanKind(obj), "namespace", obj.GetNamespace(), "name", obj.GetName())
+			lastErr = err
+			continue
+		}
+		removed++
+		g.reportRemoval(ctx, obj, owner)
+	}
+	return removed, lastErr
+}
+
+// ownerState is the Breakglass an orphan candidate is labelled for, as seen by the collector.
+type ownerState struct {
+	// object is the Breakglass or ClusterBreakglass, nil when it no longer exists
+	object runtime.Object
+	active bool
+}
+
+// lookupOwner reads the request labels point at. A request with the same name but another UID is a
+// later incarnation and does not own the object.
+func (g *OrphanCollector) lookupOwner(
+	ctx context.Context,
+	labels map[string]string,
+	uid types.UID,
+	now time.Time,
+) (*ownerState, error) {
+	key := client.ObjectKey{Namespace: labels[rbac.LabelNamespace], Name: labels[rbac.LabelName]}
+
+	var (
+		bg     *accessv1alpha1.Breakglass
+		object runtime.Object
+	)
+	if key.Namespace == "" {
+		var cbg accessv1alpha1.ClusterBreakglass
+		if err := g.Reader.Get(ctx, key, &cbg); err != nil {
+			return ownerOrError(err)
+		}
+		bg, object = cbg.ToBreakglass(), &cbg
+	} else {
+		bg = &accessv1alpha1.Breakglass{}
+		if err := g.Reader.Get(ctx, key, bg); err != nil {
+			return ownerOrError(err)
+		}
+		object = bg
+	}
+
+	if bg.UID != uid {
+		return &ownerState{}, nil
+	}
+	return &ownerState{object: object, active: usecases.InActiveWindow(bg, now)}, nil
+}
+
+func ownerOrError(err error) (*ownerState, error) {
+	if apierrors.IsNotFound(err) {
+		return &ownerState{}, nil
+	}
+	return nil, err
+}
+
+// labelledObjects lists every Role, RoleBinding, ClusterRole and ClusterRoleBinding carrying a
+// breakglass UID label.
+func (g *OrphanCollector) labelledObjects(ctx context.Context) ([]client.Object, error) {
+	hasUID := client.HasLabels{rbac.LabelUID}
+	var objects []client.Object
+
+	var rbList rbacv1.RoleBindingList
+	if err := g.Client.List(ctx, &rbList, hasUID); err != nil {
+		return nil, err
+	}
+	for i := range rbList.Items {
+		objects = append(objects, &rbList.Items[i])
+	}
+
+	var roleList rbacv1.RoleList
+	if err := g.Client.List(ctx, &roleList, hasUID); err != nil {
+		return nil, err
+	}
+	for i := range roleList.Items {
+		objects = append(objects, &roleList.Items[i])
+	}
+
+	var crbList rbacv1.ClusterRoleBindingList
+	if err := g.Client.List(ctx, &crbList, hasUID); err != nil {
+		return nil, err
+	}
+	for i := range crbList.Items {
+		objects = append(objects, &crbList.Items[i])
+	}
+
+	var crList rbacv1.ClusterRoleList
+	if err := g.Client.List(ctx, &crList, hasUID); err != nil {
+		return nil, err
+	}
+	for i := range crList.Items {
+		objects = append(objects, &crList.Items[i])
+	}
+
+	return objects, nil
+}
+
+// reportRemoval logs, counts and records an event for a removed orphan. The event goes to the owning
+// request when it still exists, and to the removed object otherwise.
+func (g *OrphanCollector) reportRemoval(ctx context.Context, obj client.Object, owner *ownerState) {
+	kind := orphanKind(obj)
+	state := orphanOwnerMissing
+	if owner.object != nil {
+		state = orphanOwnerInactive
+	}
+	ctrl.LoggerFrom(ctx).Info("deleted orphaned RBAC",
+		"kind", kind, "namespace", obj.GetNamespace(), "name", obj.GetName(), "owner", state)
+	metrics.RecordRBACOrphanRemoved(kind, state)
+
+	if g.recorder == nil {
+		return
+	}
+	if owner.object != nil {
+		g.recorder.Eventf(owner.object, "Warning", "OrphanedRBACRemoved",
+			"Removed %s %s left behind outside an active window", kind, client.ObjectKeyFromObject(obj))
+		return
+	}
+	g.recorder.Eventf(obj, "Warning", "OrphanedRBACRemoved",
+		"Removed %s whose Breakglass %s no longer exists", kind, obj.GetLabels()[rbac.LabelName])
+}
+
+// orphanKind returns the kind of a listed RBAC object, whose TypeMeta is not always populated.
+func orphanKind(obj client.Object) string {
+	switch obj.(type) {
+	case *rbacv1.Role:
+		return accessv1alpha1.KindRole
+	case *rbacv1.RoleBinding:
+		return accessv1alpha1.KindRoleBinding
+	case *rbacv1.ClusterRole:
+		return accessv1alpha1.KindClusterRole
+	case *rbacv1.ClusterRoleBinding:
+		return accessv1alpha1.KindClusterRoleBinding
+	}
+	return obj.GetObjectKind().GroupVersionKind().Kind
+}
@@ -0,0 +1,97 @@
+package breakglass
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller/mocks"
+	"github.com/cloud-nimbus/firedoor/internal/operator/rbac"
+)
+
+func TestOrphanCollector_Sweep(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	old := metav1.NewTime(now.Add(-time.Hour))
+
+	binding := func(name, namespace, owner, ownerNamespace, uid string, created metav1.Time) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: created,
+			Labels: map[string]string{
+				rbac.LabelName: owner, rbac.LabelNamespace: ownerNamespace, rbac.LabelUID: uid,
+			},
+		}}
+	}
+	active := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "active", Namespace: "default", UID: "active-uid"},
+		Spec: accessv1alpha1.BreakglassSpec{Schedule: accessv1alpha1.ScheduleSpec{
+			Start:    metav1.NewTime(now.Add(-30 * time.Minute)),
+			Duration: metav1.Duration{Duration: time.Hour},
+		}},
+		Status: accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhaseActive},
+	}
+	expired := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "expired", Namespace: "default", UID: "expired-uid"},
+		Status:     accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhaseExpired},
+	}
+	recreated := &accessv1alpha1.ClusterBreakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform", UID: "new-uid"},
+		Status:     accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhaseActive},
+	}
+	unlabelled := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "admins", CreationTimestamp: old}}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		active, expired, recreated, unlabelled,
+		binding("kept", "apps", "active", "default", "active-uid", old),
+		binding("ended", "apps", "expired", "default", "expired-uid", old),
+		binding("deleted", "apps", "gone", "default", "gone-uid", old),
+		binding("in-progress", "apps", "gone", "default", "gone-uid", metav1.NewTime(now.Add(-time.Minute))),
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{
+			Name:              "previous-incarnation",
+			CreationTimestamp: old,
+			Labels:            map[string]string{rbac.LabelName: "platform", rbac.LabelNamespace: "", rbac.LabelUID: "old-uid"},
+		}},
+	).Build()
+
+	mockClock := mocks.NewMockClock(gomock.NewController(t))
+	mockClock.EXPECT().Now().Return(now)
+	recorder := record.NewFakeRecorder(10)
+	collector := NewOrphanCollector(c, 10*time.Minute, 5*time.Minute)
+	collector.Reader = c
+	collector.Clock = mockClock
+	collector.recorder = recorder
+
+	removed, err := collector.Sweep(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, removed)
+
+	var rbs rbacv1.RoleBindingList
+	require.NoError(t, c.List(context.Background(), &rbs))
+	var names []string
+	for _, rb := range rbs.Items {
+		names = append(names, rb.Name)
+	}
+	assert.ElementsMatch(t, []string{"kept", "in-progress"}, names)
+
+	var crbs rbacv1.ClusterRoleBindingList
+	require.NoError(t, c.List(context.Background(), &crbs))
+	require.Len(t, crbs.Items, 1, "objects without breakglass labels are never touched")
+	assert.Equal(t, "admins", crbs.Items[0].Name)
+
+	assert.Len(t, recorder.Events, 3)
+	assert.Contains(t, <-recorder.Events, "OrphanedRBACRemoved")
+}
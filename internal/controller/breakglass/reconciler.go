@@ -9,12 +9,15 @@ import (
 	"go.opentelemetry.io/otel"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 
 	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
 	"github.com/cloud-nimbus/firedoor/internal/config"
 	"github.com/cloud-nimbus/firedoor/internal/controller"
 	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/handlers"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
 	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,27 +32,32 @@ const finalizer = "breakglass.firedoor.cloudnimbus.io/finalizer"
 
 var defaultFactory = func(h *handlers.Handler) Controller { return handlers.NewPendingCondition(h) }
 
-var handlerFactories = map[accessv1alpha1.BreakglassCondition]func(*handlers.Handler) Controller{
-	accessv1alpha1.NoCondition:       defaultFactory,
-	accessv1alpha1.ConditionPending:  func(h *handlers.Handler) Controller { return handlers.NewPendingCondition(h) },
-	accessv1alpha1.ConditionApproved: func(h *handlers.Handler) Controller { return handlers.NewApprovedCondition(h) },
-	accessv1alpha1.ConditionActive: func(h *handlers.Handler) Controller {
-		return handlers.NewRecurringActiveCondition(h)
+var validatingFactory = func(h *handlers.Handler) Controller { return handlers.NewValidatingCondition(h) }
+
+// handlerFactories maps each phase to its handler. New requests are validated first. Failed requests
+// are retried from Pending unless they failed validation and their spec has not changed.
+var handlerFactories = map[accessv1alpha1.BreakglassPhase]func(*handlers.Handler) Controller{
+	"":                             validatingFactory,
+	accessv1alpha1.PhaseValidating: validatingFactory,
+	accessv1alpha1.PhasePending:    defaultFactory,
+	accessv1alpha1.PhaseFailed:     defaultFactory,
+	accessv1alpha1.PhaseApproved: func(h *handlers.Handler) Controller {
+		return handlers.NewApprovedCondition(h)
 	},
-	accessv1alpha1.ConditionDenied: func(h *handlers.Handler) Controller {
-		return handlers.NewTerminalCondition(h, accessv1alpha1.ConditionDenied)
+	accessv1alpha1.PhaseScheduled: func(h *handlers.Handler) Controller {
+		return handlers.NewRecurringPendingCondition(h)
 	},
-	accessv1alpha1.ConditionExpired: func(h *handlers.Handler) Controller {
-		return handlers.NewTerminalCondition(h, accessv1alpha1.ConditionExpired)
+	accessv1alpha1.PhaseActive: func(h *handlers.Handler) Controller {
+		return handlers.NewRecurringActiveCondition(h)
 	},
-	accessv1alpha1.ConditionRevoked: func(h *handlers.Handler) Controller {
-		return handlers.NewTerminalCondition(h, accessv1alpha1.ConditionRevoked)
+	accessv1alpha1.PhaseDenied: func(h *handlers.Handler) Controller {
+		return handlers.NewTerminalCondition(h, accessv1alpha1.PhaseDenied)
 	},
-	accessv1alpha1.ConditionRecurringPending: func(h *handlers.Handler) Controller {
-		return handlers.NewRecurringPendingCondition(h)
+	accessv1alpha1.PhaseExpired: func(h *handlers.Handler) Controller {
+		return handlers.NewTerminalCondition(h, accessv1alpha1.PhaseExpired)
 	},
-	accessv1alpha1.ConditionRecurringActive: func(h *handlers.Handler) Controller {
-		return handlers.NewRecurringActiveCondition(h)
+	accessv1alpha1.PhaseRevoked: func(h *handlers.Handler) Controller {
+		return handlers.NewTerminalCondition(h, accessv1alpha1.PhaseRevoked)
 	},
 }
 
@@ -69,25 +77,25 @@ type BreakglassReconciler struct {
 	Clock            controller.Clock
 	Config           *config.Config
 	Telemetry        controller.TelemetrySink
+	Tickets          controller.TicketProvider
 	baseHandler      *handlers.Handler
+	deadlines        *Deadlines
 	recorder         record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=access.cloudnimbus.io,resources=breakglasses,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=access.cloudnimbus.io,resources=breakglasses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=access.cloudnimbus.io,resources=breakglassapprovals,verbs=get;list;watch
+// +kubebuilder:rbac:groups=access.cloudnimbus.io,resources=breakglasspolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,
 //
 //	resources=rolebindings;clusterrolebindings;roles;clusterroles,
-//	verbs=get;create;delete
+//	verbs=get;list;watch;create;patch;delete
 //
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
-func (r *BreakglassReconciler) currentCondition(bg *accessv1alpha1.Breakglass) accessv1alpha1.BreakglassCondition {
-	if len(bg.Status.Conditions) == 0 {
-		return accessv1alpha1.NoCondition
-	}
-	return accessv1alpha1.BreakglassCondition(bg.Status.Conditions[len(bg.Status.Conditions)-1].Type)
-}
-
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch
 func (r *BreakglassReconciler) cleanupOnDelete(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
 	// 1) Revoke any external grants
 	if err := r.Operator.RevokeAccess(ctx, bg); err != nil {
@@ -118,15 +126,23 @@ func (r *BreakglassReconciler) cleanupOnDelete(ctx context.Context, bg *accessv1
 	return nil
 }
 
+// reconcileFinalizers adds the finalizer to bg, or cleans up and removes it once bg is being deleted.
+// stored is bg as read, before the schedule defaults were applied; adding the finalizer persists them.
 func (r *BreakglassReconciler) reconcileFinalizers(
 	ctx context.Context,
-	bg *accessv1alpha1.Breakglass,
+	bg, stored *accessv1alpha1.Breakglass,
 ) (ctrl.Result, error) {
 	if bg.DeletionTimestamp.IsZero() {
-		if controllerutil.AddFinalizer(bg, finalizer) {
-			return ctrl.Result{Requeue: true}, r.Client.Update(ctx, bg)
+		if controllerutil.ContainsFinalizer(bg, finalizer) {
+			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, nil
+		start := bg.Spec.Schedule.Start
+		return ctrl.Result{Requeue: true}, r.patchWithRetry(ctx, bg, stored, func(obj *accessv1alpha1.Breakglass) {
+			if obj.Spec.Schedule.Start.IsZero() {
+				obj.Spec.Schedule.Start = start
+			}
+			controllerutil.AddFinalizer(obj, finalizer)
+		})
 	}
 
 	// CR is being deleted: do our cleanup
@@ -136,8 +152,38 @@ func (r *BreakglassReconciler) reconcileFinalizers(
 	}
 
 	// all cleaned up → remove our finalizer and let the CR go away
-	controllerutil.RemoveFinalizer(bg, finalizer)
-	return ctrl.Result{}, r.Client.Update(ctx, bg)
+	return ctrl.Result{}, r.patchWithRetry(ctx, bg, stored, func(obj *accessv1alpha1.Breakglass) {
+		controllerutil.RemoveFinalizer(obj, finalizer)
+	})
+}
+
+// patchWithRetry applies mutate to bg and writes the changes since stored as a merge patch locked to the
+// resourceVersion of stored, so a concurrent write is never overwritten. On conflict bg is re-read and
+// mutate applied to the latest object before retrying.
+func (r *BreakglassReconciler) patchWithRetry(
+	ctx context.Context,
+	bg, stored *accessv1alpha1.Breakglass,
+	mutate func(*accessv1alpha1.Breakglass),
+) error {
+	mutate(bg)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		data, err := client.MergeFromWithOptions(stored, client.MergeFromWithOptimisticLock{}).Data(bg)
+		if err != nil {
+			return err
+		}
+		err = r.Client.Patch(ctx, bg, client.RawPatch(types.MergePatchType, data))
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+		latest := &accessv1alpha1.Breakglass{}
+		if getErr := r.Client.Get(ctx, client.ObjectKeyFromObject(bg), latest); getErr != nil {
+			return getErr
+		}
+		stored = latest.DeepCopy()
+		mutate(latest)
+		*bg = *latest
+		return err
+	})
 }
 
 func (r *BreakglassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -146,6 +192,9 @@ func (r *BreakglassReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	bg, err := r.fetchAndInit(ctx, req)
 	if bg == nil || err != nil {
+		if err == nil && r.deadlines != nil {
+			r.deadlines.Forget(req.NamespacedName)
+		}
 		return ctrl.Result{}, err
 	}
 
@@ -159,6 +208,7 @@ func (r *BreakglassReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 	ctrl.LoggerFrom(ctx).V(1).Info("fetched latest breakglass resource for reconciliation")
+	stored := bg.DeepCopy()
 
 	// Default start and end if not set
 	now := metav1.Now()
@@ -185,23 +235,31 @@ func (r *BreakglassReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, fmt.Errorf("Duration must be greater than 0 for recurring schedules")
 	}
 
-	if res, err := r.reconcileFinalizers(ctx, bg); res.Requeue || err != nil {
+	// status writes below only send what this reconcile changed
+	ctx = controller.WithStatusBase(ctx, bg)
+	if res, err := r.reconcileFinalizers(ctx, bg, stored); res.Requeue || err != nil {
 		return res, err
 	}
 
-	cond := r.currentCondition(bg)
-	factory, found := handlerFactories[cond]
+	phase := usecases.CurrentPhase(bg)
+	if bg.Spec.Revocation != nil && !usecases.IsTerminalPhase(phase) {
+		ctx, revokeSpan := tracer.Start(ctx, "Revoke")
+		defer revokeSpan.End()
+		return r.baseHandler.Revoke(ctx, bg)
+	}
+
+	factory, found := handlerFactories[phase]
 	if !found {
 		ctrl.LoggerFrom(ctx).
 			V(1).
-			Info("unrecognized condition; defaulting to pending", "condition", cond)
+			Info("unrecognized phase; defaulting to pending", "phase", phase)
 		factory = defaultFactory
 	}
-	ctx, condSpan := tracer.Start(ctx, string(cond))
-	defer condSpan.End()
+	ctx, phaseSpan := tracer.Start(ctx, string(phase))
+	defer phaseSpan.End()
 	handler := factory(r.baseHandler)
 	if handler == nil {
-		err := fmt.Errorf("no handler for condition %q", cond)
+		err := fmt.Errorf("no handler for phase %q", phase)
 		ctrl.LoggerFrom(ctx).Error(err, "invalid handler")
 		return ctrl.Result{}, err
 	}
@@ -229,18 +287,14 @@ func (r *BreakglassReconciler) fetchAndInit(
 		return nil, err
 	}
 
-	conditionType := "none"
-	reason := "none"
-	if len(bg.Status.Conditions) > 0 {
-		lastCond := bg.Status.Conditions[len(bg.Status.Conditions)-1]
-		conditionType = lastCond.Type
-		reason = lastCond.Reason
+	phase := "none"
+	if p := usecases.CurrentPhase(bg); p != "" {
+		phase = string(p)
 	}
 	log.V(1).Info("fetched breakglass resource",
 		"name", bg.GetName(),
 		"namespace", bg.GetNamespace(),
-		"condition", conditionType,
-		"reason", reason,
+		"phase", phase,
 		"expiresAt", bg.Status.ExpiresAt,
 	)
 	return bg, nil
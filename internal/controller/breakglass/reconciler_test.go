@@ -1,11 +1,22 @@
 package breakglass
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
 	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/handlers"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 type factoryType int
@@ -13,8 +24,10 @@ type factoryType int
 const (
 	factoryPending factoryType = iota
 	factoryApproved
+	factoryRecurringPending
 	factoryRecurringActive
 	factoryTerminal
+	factoryValidating
 )
 
 func factoryKind(f func(*handlers.Handler) Controller) factoryType {
@@ -25,10 +38,14 @@ func factoryKind(f func(*handlers.Handler) Controller) factoryType {
 		return factoryPending
 	case *handlers.ApprovedCondition:
 		return factoryApproved
+	case *handlers.RecurringPendingCondition:
+		return factoryRecurringPending
 	case *handlers.RecurringActiveCondition:
 		return factoryRecurringActive
 	case *handlers.TerminalCondition:
 		return factoryTerminal
+	case *handlers.ValidatingCondition:
+		return factoryValidating
 	default:
 		return -1
 	}
@@ -37,41 +54,101 @@ func factoryKind(f func(*handlers.Handler) Controller) factoryType {
 func TestHandlerFactoriesDispatch(t *testing.T) {
 	tests := []struct {
 		name        string
-		lastCond    accessv1alpha1.BreakglassCondition
+		phase       accessv1alpha1.BreakglassPhase
 		expectKind  factoryType
 		expectFound bool
 	}{
 		// “empty” keys
-		{"NoCondition", accessv1alpha1.NoCondition, factoryPending, true},
+		{"NoPhase", "", factoryValidating, true},
 
 		// explicit mappings
-		{"Pending", accessv1alpha1.ConditionPending, factoryPending, true},
-		{"Approved", accessv1alpha1.ConditionApproved, factoryApproved, true},
-		{"Active", accessv1alpha1.ConditionActive, factoryRecurringActive, true},
-		{"RecurringActive", accessv1alpha1.ConditionRecurringActive, factoryRecurringActive, true},
+		{"Validating", accessv1alpha1.PhaseValidating, factoryValidating, true},
+		{"Pending", accessv1alpha1.PhasePending, factoryPending, true},
+		{"Failed", accessv1alpha1.PhaseFailed, factoryPending, true},
+		{"Approved", accessv1alpha1.PhaseApproved, factoryApproved, true},
+		{"Scheduled", accessv1alpha1.PhaseScheduled, factoryRecurringPending, true},
+		{"Active", accessv1alpha1.PhaseActive, factoryRecurringActive, true},
 
 		// terminal states
-		{"Denied", accessv1alpha1.ConditionDenied, factoryTerminal, true},
-		{"Expired", accessv1alpha1.ConditionExpired, factoryTerminal, true},
-		{"Revoked", accessv1alpha1.ConditionRevoked, factoryTerminal, true},
+		{"Denied", accessv1alpha1.PhaseDenied, factoryTerminal, true},
+		{"Expired", accessv1alpha1.PhaseExpired, factoryTerminal, true},
+		{"Revoked", accessv1alpha1.PhaseRevoked, factoryTerminal, true},
 
 		// totally unknown → fallback to Pending
-		{"FooBar", accessv1alpha1.BreakglassCondition("FooBar"), factoryPending, false},
+		{"FooBar", accessv1alpha1.BreakglassPhase("FooBar"), factoryPending, false},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			factory, found := handlerFactories[tt.lastCond]
+			factory, found := handlerFactories[tt.phase]
 			if !found {
-				// fallback factory
-				factory = func(h *handlers.Handler) Controller {
-					return handlers.NewPendingCondition(h)
-				}
+				factory = defaultFactory
 			}
 
 			kind := factoryKind(factory)
-			assert.Equal(t, tt.expectKind, kind, "factory kind mismatch for %q", tt.lastCond)
-			assert.Equal(t, tt.expectFound, found, "found flag mismatch for %q", tt.lastCond)
+			assert.Equal(t, tt.expectKind, kind, "factory kind mismatch for %q", tt.phase)
+			assert.Equal(t, tt.expectFound, found, "found flag mismatch for %q", tt.phase)
 		})
 	}
 }
+
+func TestHandlerFactoriesDispatchOnPhase(t *testing.T) {
+	// Conditions are updated in place, so the last one is not the newest state.
+	// The phase decides which handler runs.
+	bg := &accessv1alpha1.Breakglass{
+		Status: accessv1alpha1.BreakglassStatus{
+			Phase: accessv1alpha1.PhaseActive,
+			Conditions: []metav1.Condition{
+				{Type: string(accessv1alpha1.ConditionRecurringActive), Status: metav1.ConditionTrue},
+				{Type: string(accessv1alpha1.ConditionRecurringPending), Status: metav1.ConditionTrue},
+			},
+		},
+	}
+
+	factory := handlerFactories[usecases.CurrentPhase(bg)]
+	assert.Equal(t, factoryRecurringActive, factoryKind(factory))
+}
+
+func TestReconcileFinalizers_RetriesOnConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	stored := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default"},
+		Spec: accessv1alpha1.BreakglassSpec{Schedule: accessv1alpha1.ScheduleSpec{
+			Cron:     "0 9 * * *",
+			Duration: metav1.Duration{Duration: time.Hour},
+		}},
+	}
+	conflicts := 1
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+			opts ...client.PatchOption) error {
+			if conflicts > 0 {
+				conflicts--
+				// another controller adds its finalizer in between
+				var other accessv1alpha1.Breakglass
+				require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), &other))
+				other.Finalizers = append(other.Finalizers, "example.com/other")
+				require.NoError(t, c.Update(ctx, &other))
+				return apierrors.NewConflict(schema.GroupResource{Resource: "breakglasses"}, obj.GetName(), nil)
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}).Build()
+	r := NewBreakglassReconciler(c, scheme)
+
+	bg := &accessv1alpha1.Breakglass{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(stored), bg))
+	read := bg.DeepCopy()
+	start := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	bg.Spec.Schedule.Start = start
+
+	res, err := r.reconcileFinalizers(context.Background(), bg, read)
+	require.NoError(t, err)
+	assert.True(t, res.Requeue)
+
+	var got accessv1alpha1.Breakglass
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(stored), &got))
+	assert.ElementsMatch(t, []string{"example.com/other", finalizer}, got.Finalizers)
+	assert.True(t, start.Equal(&got.Spec.Schedule.Start), "the defaulted start is persisted with the finalizer")
+}
@@ -1,15 +1,24 @@
 package breakglass
 
 import (
+	"context"
+
 	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
 	"github.com/cloud-nimbus/firedoor/internal/clock"
 	"github.com/cloud-nimbus/firedoor/internal/config"
 	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/handlers"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
 	"github.com/cloud-nimbus/firedoor/internal/operator/rbac"
 
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // NewBreakglassReconciler creates a new BreakglassReconciler with the given options.
@@ -30,6 +39,86 @@ func NewBreakglassReconciler(
 }
 
 func (r *BreakglassReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.setupHandler(mgr, "breakglass-controller")
+	if err := mgr.Add(r.deadlines); err != nil {
+		return err
+	}
+	if err := rbac.IndexOwnerUID(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&accessv1alpha1.BreakglassApproval{},
+		handlers.ApprovalBreakglassRefField,
+		handlers.ApprovalBreakglassRefIndexer,
+	); err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&accessv1alpha1.Breakglass{}).
+		Watches(&accessv1alpha1.BreakglassApproval{}, handler.EnqueueRequestsFromMapFunc(approvalToBreakglass)).
+		Watches(&accessv1alpha1.BreakglassPolicy{}, handler.EnqueueRequestsFromMapFunc(policyToBreakglass(r.Client))).
+		WatchesRawSource(r.deadlines.Source())
+	return watchGrantedRBAC(b, rbacToBreakglass).Complete(r)
+}
+
+// watchGrantedRBAC reconciles a request whenever an RBAC object labelled for it is created, edited or
+// deleted, so tampering is noticed straight away rather than on the next scheduled requeue.
+func watchGrantedRBAC(b *builder.Builder, mapFn handler.MapFunc) *builder.Builder {
+	for _, obj := range []client.Object{
+		&rbacv1.Role{}, &rbacv1.RoleBinding{}, &rbacv1.ClusterRole{}, &rbacv1.ClusterRoleBinding{},
+	} {
+		b = b.Watches(obj, handler.EnqueueRequestsFromMapFunc(mapFn))
+	}
+	return b
+}
+
+// rbacToBreakglass maps an RBAC object carrying breakglass labels to the Breakglass it was created for.
+func rbacToBreakglass(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels[rbac.LabelUID] == "" || labels[rbac.LabelName] == "" || labels[rbac.LabelNamespace] == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: labels[rbac.LabelNamespace],
+		Name:      labels[rbac.LabelName],
+	}}}
+}
+
+// policyToBreakglass maps a BreakglassPolicy to the unfinished Breakglass requests in the namespaces it
+// governs, so a request waiting on approvals or a ticket is checked against the changed guardrails.
+// Updates map both the old and the new policy, which covers requests a policy stops governing.
+func policyToBreakglass(reader client.Reader) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		p, ok := obj.(*accessv1alpha1.BreakglassPolicy)
+		if !ok {
+			return nil
+		}
+		namespaces := p.Spec.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{metav1.NamespaceAll}
+		}
+		var requests []reconcile.Request
+		for _, ns := range namespaces {
+			var list accessv1alpha1.BreakglassList
+			if err := reader.List(ctx, &list, client.InNamespace(ns)); err != nil {
+				ctrl.LoggerFrom(ctx).Error(err, "unable to list breakglass requests for policy", "policy", p.Name)
+				return nil
+			}
+			for i := range list.Items {
+				if usecases.IsTerminalPhase(usecases.CurrentPhase(&list.Items[i])) {
+					continue
+				}
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+			}
+		}
+		return requests
+	}
+}
+
+// setupHandler fills in unset dependencies and builds the condition handler shared by all conditions,
+// along with the deadlines waking its requests.
+func (r *BreakglassReconciler) setupHandler(mgr ctrl.Manager, recorderName string) {
 	if r.Config == nil {
 		r.Config = config.NewDefaultConfig()
 	}
@@ -37,15 +126,43 @@ func (r *BreakglassReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		r.Clock = clock.SimpleClock{}
 	}
 	if r.Operator == nil {
-		r.Operator = rbac.New(mgr.GetClient())
+		r.Operator = rbac.New(
+			r.Client,
+			rbac.WithPrivilegeEscalation(r.Config.Controller.PrivilegeEscalation),
+			rbac.WithAdmissionWebhook(r.Config.Webhook.Enabled),
+			rbac.WithDenylist(r.Config.Denylist),
+			rbac.WithNamespaceRestriction(r.Config.Controller.RestrictToNamespace),
+			rbac.WithClock(r.Clock),
+			rbac.WithReader(mgr.GetAPIReader()),
+			rbac.WithOwnerIndex(),
+		)
 	}
 	if r.recorder == nil {
-		r.recorder = mgr.GetEventRecorderFor("breakglass-controller")
+		r.recorder = mgr.GetEventRecorderFor(recorderName)
 	}
 	r.baseHandler = handlers.NewHandler(
 		r.Client, r.Operator, r.RecurringManager, r.Alerts, r.Clock, r.recorder, r.Config.Controller.Backoff,
 	)
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&accessv1alpha1.Breakglass{}).
-		Complete(r)
+	r.baseHandler.ClusterApprovalNamespace = r.Config.Controller.ClusterApprovalNamespace
+	r.baseHandler.Tickets = r.Tickets
+	r.baseHandler.ApprovalWebhook = r.Config.Webhook.Enabled
+	r.baseHandler.TicketRequired = r.Config.Tickets.Required
+	r.baseHandler.RevokeOnTicketClose = r.Config.Tickets.RevokeOnClose
+	r.baseHandler.TicketPollInterval = r.Config.Tickets.PollInterval
+	r.baseHandler.RevokeOnDrift = r.Config.Controller.DriftAction == config.DriftActionRevoke
+	r.deadlines = NewDeadlines(r.Clock)
+	r.baseHandler.Deadlines = r.deadlines
+}
+
+// approvalToBreakglass maps a BreakglassApproval to the Breakglass it decides on.
+func approvalToBreakglass(_ context.Context, obj client.Object) []reconcile.Request {
+	approval, ok := obj.(*accessv1alpha1.BreakglassApproval)
+	if !ok || approval.Spec.BreakglassRef == "" ||
+		handlers.ApprovalRefKind(approval) != accessv1alpha1.RefKindBreakglass {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: approval.Namespace,
+		Name:      approval.Spec.BreakglassRef,
+	}}}
 }
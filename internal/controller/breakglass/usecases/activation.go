@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+// openActivation returns the last activation record if its window has not been revoked yet.
+func openActivation(bg *accessv1alpha1.Breakglass) *accessv1alpha1.ActivationRecord {
+	if len(bg.Status.ActivationHistory) == 0 {
+		return nil
+	}
+	last := &bg.Status.ActivationHistory[len(bg.Status.ActivationHistory)-1]
+	if last.RevokedAt != nil {
+		return nil
+	}
+	return last
+}
+
+// RecordActivation opens an activation record for the window just granted, keeping the most recent
+// MaxActivationHistory records. A grant while the previous window is still open, such as a retry after
+// a failure, continues that window and only refreshes its resources. The GrantsHash of the spec the
+// window was granted from is recorded as well.
+func RecordActivation(bg *accessv1alpha1.Breakglass, grantedAt time.Time) {
+	bg.Status.GrantsHash = GrantsHash(&bg.Spec)
+	resources := append([]string(nil), bg.Status.CreatedResources...)
+	if open := openActivation(bg); open != nil {
+		open.CreatedResources = resources
+		return
+	}
+
+	bg.Status.ActivationHistory = append(bg.Status.ActivationHistory, accessv1alpha1.ActivationRecord{
+		Activation:       bg.Status.ActivationCount,
+		GrantedAt:        metav1.NewTime(grantedAt),
+		ApprovedBy:       bg.Status.ApprovedBy,
+		CreatedResources: resources,
+	})
+	if overflow := len(bg.Status.ActivationHistory) - accessv1alpha1.MaxActivationHistory; overflow > 0 {
+		bg.Status.ActivationHistory = append([]accessv1alpha1.ActivationRecord(nil),
+			bg.Status.ActivationHistory[overflow:]...)
+	}
+}
+
+// CloseActivation stamps the open activation record, if any, with the time its resources were revoked.
+// CreatedResources is cleared because the resources no longer exist and the next window has to create
+// them again.
+func CloseActivation(bg *accessv1alpha1.Breakglass, revokedAt time.Time) {
+	if open := openActivation(bg); open != nil {
+		t := metav1.NewTime(revokedAt)
+		open.RevokedAt = &t
+	}
+	bg.Status.CreatedResources = nil
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+func TestRecordAndCloseActivation(t *testing.T) {
+	granted := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	revoked := granted.Add(8 * time.Hour)
+	bg := &accessv1alpha1.Breakglass{
+		Status: accessv1alpha1.BreakglassStatus{
+			ActivationCount:  1,
+			ApprovedBy:       "bob",
+			CreatedResources: []string{"breakglass-role"},
+		},
+	}
+
+	RecordActivation(bg, granted)
+	// a retried grant in the same window does not open a second record
+	bg.Status.CreatedResources = append(bg.Status.CreatedResources, "breakglass-rolebinding")
+	RecordActivation(bg, granted.Add(time.Minute))
+	CloseActivation(bg, revoked)
+
+	if len(bg.Status.ActivationHistory) != 1 {
+		t.Fatalf("history length = %d, want 1", len(bg.Status.ActivationHistory))
+	}
+	record := bg.Status.ActivationHistory[0]
+	if record.Activation != 1 || record.ApprovedBy != "bob" {
+		t.Errorf("record = %+v, want activation 1 approved by bob", record)
+	}
+	if !record.GrantedAt.Time.Equal(granted) {
+		t.Errorf("GrantedAt = %v, want %v", record.GrantedAt, granted)
+	}
+	if record.RevokedAt == nil || !record.RevokedAt.Time.Equal(revoked) {
+		t.Errorf("RevokedAt = %v, want %v", record.RevokedAt, revoked)
+	}
+	if len(record.CreatedResources) != 2 {
+		t.Errorf("CreatedResources = %v, want both resources", record.CreatedResources)
+	}
+	if len(bg.Status.CreatedResources) != 0 {
+		t.Errorf("status CreatedResources = %v, want cleared after revoke", bg.Status.CreatedResources)
+	}
+
+	// closing again leaves the record alone
+	CloseActivation(bg, revoked.Add(time.Hour))
+	if !bg.Status.ActivationHistory[0].RevokedAt.Time.Equal(revoked) {
+		t.Errorf("RevokedAt changed on second close: %v", bg.Status.ActivationHistory[0].RevokedAt)
+	}
+}
+
+func TestCloseActivationClearsCreatedResources(t *testing.T) {
+	first := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	bg := &accessv1alpha1.Breakglass{
+		Status: accessv1alpha1.BreakglassStatus{ActivationCount: 1, CreatedResources: []string{"breakglass-role-a"}},
+	}
+
+	RecordActivation(bg, first)
+	CloseActivation(bg, first.Add(time.Hour))
+	if bg.Status.CreatedResources != nil {
+		t.Fatalf("status CreatedResources = %v, want nil between windows", bg.Status.CreatedResources)
+	}
+
+	// the next window only lists what it created, the previous one keeps its own resources
+	bg.Status.ActivationCount = 2
+	bg.Status.CreatedResources = []string{"breakglass-role-b"}
+	RecordActivation(bg, second)
+	history := bg.Status.ActivationHistory
+	if len(history) != 2 {
+		t.Fatalf("history length = %d, want 2", len(history))
+	}
+	if got := history[0].CreatedResources; len(got) != 1 || got[0] != "breakglass-role-a" {
+		t.Errorf("first window CreatedResources = %v, want [breakglass-role-a]", got)
+	}
+	if got := history[1].CreatedResources; len(got) != 1 || got[0] != "breakglass-role-b" {
+		t.Errorf("second window CreatedResources = %v, want [breakglass-role-b]", got)
+	}
+
+	// a revoke without an open window still forgets resources that no longer exist
+	CloseActivation(bg, second.Add(time.Hour))
+	bg.Status.CreatedResources = []string{"breakglass-role-stale"}
+	CloseActivation(bg, second.Add(2*time.Hour))
+	if bg.Status.CreatedResources != nil {
+		t.Errorf("status CreatedResources = %v, want nil", bg.Status.CreatedResources)
+	}
+	if !bg.Status.ActivationHistory[1].RevokedAt.Time.Equal(second.Add(time.Hour)) {
+		t.Errorf("RevokedAt = %v, want the first close", bg.Status.ActivationHistory[1].RevokedAt)
+	}
+}
+
+func TestRecordActivationTrimsHistory(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	bg := &accessv1alpha1.Breakglass{}
+
+	for i := 1; i <= accessv1alpha1.MaxActivationHistory+3; i++ {
+		at := start.Add(time.Duration(i) * 24 * time.Hour)
+		bg.Status.ActivationCount = int32(i)
+		RecordActivation(bg, at)
+		CloseActivation(bg, at.Add(time.Hour))
+	}
+
+	if len(bg.Status.ActivationHistory) != accessv1alpha1.MaxActivationHistory {
+		t.Fatalf("history length = %d, want %d", len(bg.Status.ActivationHistory), accessv1alpha1.MaxActivationHistory)
+	}
+	if first := bg.Status.ActivationHistory[0].Activation; first != 4 {
+		t.Errorf("oldest kept activation = %d, want 4", first)
+	}
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+// GrantsHash returns a digest of the fields of spec that decide what RBAC is granted: subjects,
+// clusterRoles, clusterRoleScope and policy. Other spec changes, such as a new justification or an
+// extension, leave it unchanged.
+func GrantsHash(spec *accessv1alpha1.BreakglassSpec) string {
+	grants := struct {
+		Subjects         []rbacv1.Subject               `json:"subjects,omitempty"`
+		ClusterRoles     []string                       `json:"clusterRoles,omitempty"`
+		ClusterRoleScope *accessv1alpha1.NamespaceScope `json:"clusterRoleScope,omitempty"`
+		Policy           []accessv1alpha1.Policy        `json:"policy,omitempty"`
+	}{spec.Subjects, spec.ClusterRoles, spec.ClusterRoleScope, spec.Policy}
+	// Plain API types always marshal
+	data, _ := json.Marshal(grants)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+func TestGrantsHash(t *testing.T) {
+	spec := &accessv1alpha1.BreakglassSpec{
+		Subjects:      []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		ClusterRoles:  []string{"view"},
+		Justification: "incident",
+	}
+	hash := GrantsHash(spec)
+
+	other := spec.DeepCopy()
+	other.Justification = "changed"
+	other.Schedule.Duration = metav1.Duration{Duration: time.Hour}
+	other.Extensions = []accessv1alpha1.ExtensionRequest{{Name: "more-time"}}
+	assert.Equal(t, hash, GrantsHash(other), "fields that grant nothing are ignored")
+
+	other = spec.DeepCopy()
+	other.Subjects = append(other.Subjects, rbacv1.Subject{Kind: rbacv1.UserKind, Name: "mallory"})
+	assert.NotEqual(t, hash, GrantsHash(other))
+
+	other = spec.DeepCopy()
+	other.ClusterRoleScope = &accessv1alpha1.NamespaceScope{Namespaces: []string{"payments"}}
+	assert.NotEqual(t, hash, GrantsHash(other))
+}
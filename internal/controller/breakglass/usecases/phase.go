@@ -0,0 +1,167 @@
+package usecases
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+// transitions declares the phases each phase may move to. Staying in the same phase is always
+// allowed and is not recorded. Denied, Expired and Revoked are terminal.
+var transitions = map[accessv1alpha1.BreakglassPhase][]accessv1alpha1.BreakglassPhase{
+	"": {
+		accessv1alpha1.PhaseValidating,
+		accessv1alpha1.PhasePending,
+		accessv1alpha1.PhaseDenied,
+		accessv1alpha1.PhaseRevoked,
+		accessv1alpha1.PhaseFailed,
+	},
+	accessv1alpha1.PhaseValidating: {
+		accessv1alpha1.PhasePending,
+		accessv1alpha1.PhaseDenied,
+		accessv1alpha1.PhaseRevoked,
+		accessv1alpha1.PhaseFailed,
+	},
+	accessv1alpha1.PhasePending: {
+		accessv1alpha1.PhaseApproved,
+		accessv1alpha1.PhaseScheduled,
+		accessv1alpha1.PhaseActive,
+		accessv1alpha1.PhaseDenied,
+		accessv1alpha1.PhaseExpired,
+		accessv1alpha1.PhaseRevoked,
+		accessv1alpha1.PhaseFailed,
+	},
+	accessv1alpha1.PhaseApproved: {
+		accessv1alpha1.PhasePending,
+		accessv1alpha1.PhaseScheduled,
+		accessv1alpha1.PhaseActive,
+		accessv1alpha1.PhaseDenied,
+		accessv1alpha1.PhaseExpired,
+		accessv1alpha1.PhaseRevoked,
+		accessv1alpha1.PhaseFailed,
+	},
+	accessv1alpha1.PhaseScheduled: {
+		accessv1alpha1.PhaseActive,
+		accessv1alpha1.PhaseDenied,
+		accessv1alpha1.PhaseExpired,
+		accessv1alpha1.PhaseRevoked,
+		accessv1alpha1.PhaseFailed,
+	},
+	accessv1alpha1.PhaseActive: {
+		accessv1alpha1.PhaseScheduled,
+		accessv1alpha1.PhaseExpired,
+		accessv1alpha1.PhaseRevoked,
+		accessv1alpha1.PhaseFailed,
+	},
+	// Failed requests are retried from Pending, except invalid ones, which wait for their spec to change
+	accessv1alpha1.PhaseFailed: {
+		accessv1alpha1.PhasePending,
+		accessv1alpha1.PhaseApproved,
+		accessv1alpha1.PhaseScheduled,
+		accessv1alpha1.PhaseActive,
+		accessv1alpha1.PhaseDenied,
+		accessv1alpha1.PhaseExpired,
+		accessv1alpha1.PhaseRevoked,
+	},
+	accessv1alpha1.PhaseDenied:  nil,
+	accessv1alpha1.PhaseExpired: nil,
+	accessv1alpha1.PhaseRevoked: nil,
+}
+
+// PhaseForCondition returns the phase a condition moves a request to.
+func PhaseForCondition(cond accessv1alpha1.BreakglassCondition) accessv1alpha1.BreakglassPhase {
+	switch cond {
+	case accessv1alpha1.ConditionValidating:
+		return accessv1alpha1.PhaseValidating
+	case accessv1alpha1.ConditionPending:
+		return accessv1alpha1.PhasePending
+	case accessv1alpha1.ConditionApproved:
+		return accessv1alpha1.PhaseApproved
+	case accessv1alpha1.ConditionDenied:
+		return accessv1alpha1.PhaseDenied
+	case accessv1alpha1.ConditionRecurringPending:
+		return accessv1alpha1.PhaseScheduled
+	case accessv1alpha1.ConditionActive, accessv1alpha1.ConditionRecurringActive:
+		return accessv1alpha1.PhaseActive
+	case accessv1alpha1.ConditionExpired:
+		return accessv1alpha1.PhaseExpired
+	case accessv1alpha1.ConditionRevoked:
+		return accessv1alpha1.PhaseRevoked
+	case accessv1alpha1.ConditionFailed:
+		return accessv1alpha1.PhaseFailed
+	}
+	return ""
+}
+
+// CurrentPhase returns the phase of bg. Requests written before status.phase existed have their
+// phase inferred from the last condition.
+func CurrentPhase(bg *accessv1alpha1.Breakglass) accessv1alpha1.BreakglassPhase {
+	if bg.Status.Phase != "" || len(bg.Status.Conditions) == 0 {
+		return bg.Status.Phase
+	}
+	last := bg.Status.Conditions[len(bg.Status.Conditions)-1]
+	return PhaseForCondition(accessv1alpha1.BreakglassCondition(last.Type))
+}
+
+// FailedValidation reports whether bg failed with ReasonInvalidRequest, which holds until its spec changes.
+func FailedValidation(bg *accessv1alpha1.Breakglass) bool {
+	if CurrentPhase(bg) != accessv1alpha1.PhaseFailed {
+		return false
+	}
+	cond := meta.FindStatusCondition(bg.Status.Conditions, string(accessv1alpha1.ConditionFailed))
+	return cond != nil && cond.Reason == string(accessv1alpha1.ReasonInvalidRequest)
+}
+
+// IsTerminalPhase reports whether no further transitions happen from phase.
+func IsTerminalPhase(phase accessv1alpha1.BreakglassPhase) bool {
+	next, ok := transitions[phase]
+	return ok && len(next) == 0
+}
+
+// CanTransition reports whether the transition table allows moving from one phase to another.
+func CanTransition(from, to accessv1alpha1.BreakglassPhase) bool {
+	if from == to {
+		return true
+	}
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves bg to phase to and records the change in status.history, keeping the most
+// recent MaxPhaseHistory entries. Illegal transitions leave bg untouched and return a
+// TransitionError.
+func Transition(
+	bg *accessv1alpha1.Breakglass,
+	to accessv1alpha1.BreakglassPhase,
+	reason, actor string,
+	at time.Time,
+) error {
+	from := CurrentPhase(bg)
+	if !CanTransition(from, to) {
+		return internalerrors.NewTransitionError(string(from), string(to))
+	}
+	bg.Status.Phase = to
+	if from == to {
+		return nil
+	}
+
+	bg.Status.History = append(bg.Status.History, accessv1alpha1.PhaseTransition{
+		From:   from,
+		To:     to,
+		Reason: reason,
+		Actor:  actor,
+		Time:   metav1.NewTime(at),
+	})
+	if overflow := len(bg.Status.History) - accessv1alpha1.MaxPhaseHistory; overflow > 0 {
+		bg.Status.History = append([]accessv1alpha1.PhaseTransition(nil), bg.Status.History[overflow:]...)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTransitionRecordsHistory(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	bg := &accessv1alpha1.Breakglass{}
+
+	if err := Transition(bg, accessv1alpha1.PhasePending, "Pending", "system", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Transition(bg, accessv1alpha1.PhaseActive, "Activated", "bob", now.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// staying in the same phase is not recorded
+	if err := Transition(bg, accessv1alpha1.PhaseActive, "Activated", "bob", now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if bg.Status.Phase != accessv1alpha1.PhaseActive {
+		t.Errorf("phase = %q, want %q", bg.Status.Phase, accessv1alpha1.PhaseActive)
+	}
+	if len(bg.Status.History) != 2 {
+		t.Fatalf("history length = %d, want 2", len(bg.Status.History))
+	}
+	last := bg.Status.History[1]
+	if last.From != accessv1alpha1.PhasePending || last.To != accessv1alpha1.PhaseActive {
+		t.Errorf("last transition = %s -> %s, want Pending -> Active", last.From, last.To)
+	}
+	if last.Actor != "bob" || last.Reason != "Activated" {
+		t.Errorf("last transition actor/reason = %q/%q", last.Actor, last.Reason)
+	}
+	if !last.Time.Time.Equal(now.Add(time.Minute)) {
+		t.Errorf("last transition time = %v, want %v", last.Time, now.Add(time.Minute))
+	}
+}
+
+func TestTransitionRejectsIllegal(t *testing.T) {
+	tests := []struct {
+		from accessv1alpha1.BreakglassPhase
+		to   accessv1alpha1.BreakglassPhase
+	}{
+		{"", accessv1alpha1.PhaseActive},
+		{accessv1alpha1.PhaseScheduled, accessv1alpha1.PhasePending},
+		{accessv1alpha1.PhaseActive, accessv1alpha1.PhaseDenied},
+		{accessv1alpha1.PhaseExpired, accessv1alpha1.PhaseActive},
+		{accessv1alpha1.PhaseRevoked, accessv1alpha1.PhasePending},
+		{accessv1alpha1.PhaseDenied, accessv1alpha1.PhaseApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			bg := &accessv1alpha1.Breakglass{Status: accessv1alpha1.BreakglassStatus{Phase: tt.from}}
+			err := Transition(bg, tt.to, "", "", time.Now())
+			if !internalerrors.IsTransitionError(err) {
+				t.Fatalf("expected transition error, got %v", err)
+			}
+			if bg.Status.Phase != tt.from || len(bg.Status.History) != 0 {
+				t.Errorf("status changed on illegal transition: %+v", bg.Status)
+			}
+		})
+	}
+}
+
+func TestTransitionTrimsHistory(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	bg := &accessv1alpha1.Breakglass{Status: accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhaseScheduled}}
+
+	for i := 0; i < accessv1alpha1.MaxPhaseHistory+5; i++ {
+		to := accessv1alpha1.PhaseActive
+		if bg.Status.Phase == accessv1alpha1.PhaseActive {
+			to = accessv1alpha1.PhaseScheduled
+		}
+		if err := Transition(bg, to, "", "system", now.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(bg.Status.History) != accessv1alpha1.MaxPhaseHistory {
+		t.Fatalf("history length = %d, want %d", len(bg.Status.History), accessv1alpha1.MaxPhaseHistory)
+	}
+	if first := bg.Status.History[0].Time.Time; !first.Equal(now.Add(5 * time.Minute)) {
+		t.Errorf("oldest kept transition at %v, want %v", first, now.Add(5*time.Minute))
+	}
+}
+
+func TestCurrentPhaseFallsBackToConditions(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		Status: accessv1alpha1.BreakglassStatus{
+			Conditions: []metav1.Condition{
+				{Type: string(accessv1alpha1.ConditionApproved), Status: metav1.ConditionTrue},
+				{Type: string(accessv1alpha1.ConditionRecurringPending), Status: metav1.ConditionTrue},
+			},
+		},
+	}
+
+	if got := CurrentPhase(bg); got != accessv1alpha1.PhaseScheduled {
+		t.Errorf("CurrentPhase = %q, want %q", got, accessv1alpha1.PhaseScheduled)
+	}
+
+	bg.Status.Phase = accessv1alpha1.PhaseActive
+	if got := CurrentPhase(bg); got != accessv1alpha1.PhaseActive {
+		t.Errorf("CurrentPhase = %q, want %q", got, accessv1alpha1.PhaseActive)
+	}
+}
+
+func TestIsTerminalPhase(t *testing.T) {
+	for _, p := range []accessv1alpha1.BreakglassPhase{
+		accessv1alpha1.PhaseDenied, accessv1alpha1.PhaseExpired, accessv1alpha1.PhaseRevoked,
+	} {
+		if !IsTerminalPhase(p) {
+			t.Errorf("IsTerminalPhase(%q) = false, want true", p)
+		}
+	}
+	for _, p := range []accessv1alpha1.BreakglassPhase{
+		"", accessv1alpha1.PhasePending, accessv1alpha1.PhaseActive, accessv1alpha1.PhaseFailed, "Unknown",
+	} {
+		if IsTerminalPhase(p) {
+			t.Errorf("IsTerminalPhase(%q) = true, want false", p)
+		}
+	}
+}
@@ -17,7 +17,8 @@ type Window struct {
 var cronParser = cronv3.NewParser(cronv3.Minute | cronv3.Hour | cronv3.Dom | cronv3.Month | cronv3.Dow)
 
 // CurrentWindow computes the activation window that applies at the provided
-// reference time. When the schedule has no finite duration, false is returned.
+// reference time. When the schedule has neither a finite duration nor an until, false
+// is returned.
 func CurrentWindow(bg *accessv1alpha1.Breakglass, now time.Time) (Window, bool) {
 	if bg == nil {
 		return Window{}, false
@@ -25,13 +26,18 @@ func CurrentWindow(bg *accessv1alpha1.Breakglass, now time.Time) (Window, bool)
 
 	duration := bg.Spec.Schedule.Duration.Duration
 	if duration <= 0 {
-		return Window{}, false
+		// A one-shot without a duration runs until schedule.until, if set
+		if bg.Spec.Schedule.Cron != "" || bg.Spec.Schedule.Until == nil {
+			return Window{}, false
+		}
+		start := resolveOneShotStart(bg, now)
+		return capWindow(bg, Window{Start: start, End: bg.Spec.Schedule.Until.Time.UTC()})
 	}
 
 	if bg.Spec.Schedule.Cron == "" {
 		start := resolveOneShotStart(bg, now)
 		end := start.Add(duration)
-		return Window{Start: start, End: end}, true
+		return capWindow(bg, extendWindow(bg, Window{Start: start, End: end}))
 	}
 
 	sched, loc, err := parseCronSchedule(&bg.Spec.Schedule)
@@ -67,8 +73,71 @@ func CurrentWindow(bg *accessv1alpha1.Breakglass, now time.Time) (Window, bool)
 	}
 
 	end := start.Add(duration)
+	w := mergeExtendedWindow(bg, Window{Start: start.UTC(), End: end.UTC()})
+
+	return capWindow(bg, extendWindow(bg, w))
+}
+
+// InActiveWindow reports whether bg should currently hold access: it is Active and the window it was
+// granted for, when one can be computed, has not ended yet.
+func InActiveWindow(bg *accessv1alpha1.Breakglass, now time.Time) bool {
+	if CurrentPhase(bg) != accessv1alpha1.PhaseActive {
+		return false
+	}
+	window, ok := CurrentWindow(bg, now)
+	return !ok || now.Before(window.End)
+}
 
-	return Window{Start: start.UTC(), End: end.UTC()}, true
+// capWindow cuts w at schedule.until. No window exists when it would start at or after until.
+func capWindow(bg *accessv1alpha1.Breakglass, w Window) (Window, bool) {
+	until := bg.Spec.Schedule.Until
+	if until == nil {
+		return w, true
+	}
+	if !w.Start.Before(until.Time) {
+		return Window{}, false
+	}
+	if w.End.After(until.Time) {
+		w.End = until.Time.UTC()
+	}
+	return w, true
+}
+
+// ExtendedEnd returns the end of w pushed out by d, and whether schedule.until cut it short.
+func ExtendedEnd(bg *accessv1alpha1.Breakglass, w Window, d time.Duration) (time.Time, bool) {
+	end := w.End.Add(d)
+	if until := bg.Spec.Schedule.Until; until != nil && end.After(until.Time) {
+		return until.Time.UTC(), true
+	}
+	return end, false
+}
+
+// mergeExtendedWindow folds the cron occurrence w into an earlier window that an approved extension keeps
+// open past the start of w. The merged window keeps the earlier start, so the extensions recorded for it
+// still apply, and ends at whichever of the two ends is later.
+func mergeExtendedWindow(bg *accessv1alpha1.Breakglass, w Window) Window {
+	for _, ext := range bg.Status.Extensions {
+		if ext.Decision != accessv1alpha1.DecisionApprove || ext.WindowStart == nil || ext.ExtendedUntil == nil {
+			continue
+		}
+		if ext.WindowStart.Time.Before(w.Start) && ext.ExtendedUntil.Time.After(w.Start) {
+			w.Start = ext.WindowStart.Time.UTC()
+		}
+	}
+	return w
+}
+
+// extendWindow pushes the end of w out to the latest approved extension recorded for it.
+func extendWindow(bg *accessv1alpha1.Breakglass, w Window) Window {
+	for _, ext := range bg.Status.Extensions {
+		if ext.Decision != accessv1alpha1.DecisionApprove || ext.WindowStart == nil || ext.ExtendedUntil == nil {
+			continue
+		}
+		if ext.WindowStart.Time.Equal(w.Start) && ext.ExtendedUntil.Time.After(w.End) {
+			w.End = ext.ExtendedUntil.Time.UTC()
+		}
+	}
+	return w
 }
 
 // FinalCompletionTime returns the timestamp that should be recorded when the
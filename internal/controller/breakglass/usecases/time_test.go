@@ -104,3 +104,215 @@ func TestFinalCompletionTime(t *testing.T) {
 		t.Errorf("end = %v, want %v", got, wantEnd)
 	}
 }
+
+func TestCurrentWindowHonoursExtension(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC)
+	windowStart := metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	extendedUntil := metav1.NewTime(windowStart.Add(90 * time.Minute))
+	staleStart := metav1.NewTime(windowStart.Add(-time.Hour))
+	// The previous window's extension ended before this window opened
+	staleUntil := metav1.NewTime(windowStart.Add(-15 * time.Minute))
+	deniedUntil := metav1.NewTime(windowStart.Add(3 * time.Hour))
+
+	bg := &accessv1alpha1.Breakglass{
+		Spec: accessv1alpha1.BreakglassSpec{
+			Schedule: accessv1alpha1.ScheduleSpec{
+				Cron:     "0 * * * *",
+				Duration: metav1.Duration{Duration: 30 * time.Minute},
+			},
+		},
+		Status: accessv1alpha1.BreakglassStatus{
+			Extensions: []accessv1alpha1.ExtensionRecord{
+				{
+					Name:          "previous-window",
+					Decision:      accessv1alpha1.DecisionApprove,
+					WindowStart:   &staleStart,
+					ExtendedUntil: &staleUntil,
+				},
+				{
+					Name:          "denied",
+					Decision:      accessv1alpha1.DecisionDeny,
+					WindowStart:   &windowStart,
+					ExtendedUntil: &deniedUntil,
+				},
+				{
+					Name:          "more-time",
+					Decision:      accessv1alpha1.DecisionApprove,
+					WindowStart:   &windowStart,
+					ExtendedUntil: &extendedUntil,
+				},
+			},
+		},
+	}
+
+	window, ok := CurrentWindow(bg, now)
+	if !ok {
+		t.Fatalf("expected window, got none")
+	}
+	if !window.Start.Equal(windowStart.Time) {
+		t.Errorf("start = %v, want %v", window.Start, windowStart.Time)
+	}
+	if !window.End.Equal(extendedUntil.Time) {
+		t.Errorf("end = %v, want %v", window.End, extendedUntil.Time)
+	}
+}
+
+func TestCurrentWindowExtensionOverlapsNextOccurrence(t *testing.T) {
+	windowStart := metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	newExtension := func(until time.Time) accessv1alpha1.ExtensionRecord {
+		extendedUntil := metav1.NewTime(until)
+		return accessv1alpha1.ExtensionRecord{
+			Name:          "more-time",
+			Decision:      accessv1alpha1.DecisionApprove,
+			WindowStart:   &windowStart,
+			ExtendedUntil: &extendedUntil,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		extension accessv1alpha1.ExtensionRecord
+		now       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "extension outlasts the next occurrence",
+			extension: newExtension(windowStart.Add(100 * time.Minute)),
+			now:       windowStart.Add(70 * time.Minute),
+			wantStart: windowStart.Time,
+			wantEnd:   windowStart.Add(100 * time.Minute),
+		},
+		{
+			name:      "next occurrence outlasts the extension",
+			extension: newExtension(windowStart.Add(75 * time.Minute)),
+			now:       windowStart.Add(70 * time.Minute),
+			wantStart: windowStart.Time,
+			wantEnd:   windowStart.Add(90 * time.Minute),
+		},
+		{
+			name:      "extension ended before the next occurrence",
+			extension: newExtension(windowStart.Add(45 * time.Minute)),
+			now:       windowStart.Add(70 * time.Minute),
+			wantStart: windowStart.Add(time.Hour),
+			wantEnd:   windowStart.Add(90 * time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := &accessv1alpha1.Breakglass{
+				Spec: accessv1alpha1.BreakglassSpec{
+					Schedule: accessv1alpha1.ScheduleSpec{
+						Cron:     "0 * * * *",
+						Duration: metav1.Duration{Duration: 30 * time.Minute},
+					},
+				},
+				Status: accessv1alpha1.BreakglassStatus{Extensions: []accessv1alpha1.ExtensionRecord{tt.extension}},
+			}
+
+			window, ok := CurrentWindow(bg, tt.now)
+			if !ok {
+				t.Fatalf("expected window, got none")
+			}
+			if !window.Start.Equal(tt.wantStart) {
+				t.Errorf("start = %v, want %v", window.Start, tt.wantStart)
+			}
+			if !window.End.Equal(tt.wantEnd) {
+				t.Errorf("end = %v, want %v", window.End, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestExtendedEnd(t *testing.T) {
+	w := Window{
+		Start: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+	}
+	until := metav1.NewTime(w.End.Add(30 * time.Minute))
+	bg := &accessv1alpha1.Breakglass{}
+
+	if end, capped := ExtendedEnd(bg, w, time.Hour); capped || !end.Equal(w.End.Add(time.Hour)) {
+		t.Errorf("without until: end = %v capped = %v, want %v uncapped", end, capped, w.End.Add(time.Hour))
+	}
+	bg.Spec.Schedule.Until = &until
+	if end, capped := ExtendedEnd(bg, w, 20*time.Minute); capped || !end.Equal(w.End.Add(20*time.Minute)) {
+		t.Errorf("within until: end = %v capped = %v, want %v uncapped", end, capped, w.End.Add(20*time.Minute))
+	}
+	if end, capped := ExtendedEnd(bg, w, time.Hour); !capped || !end.Equal(until.Time) {
+		t.Errorf("past until: end = %v capped = %v, want %v capped", end, capped, until.Time)
+	}
+}
+
+func TestCurrentWindowCutAtUntil(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC)
+	until := metav1.NewTime(time.Date(2024, 1, 1, 10, 20, 0, 0, time.UTC))
+	bg := &accessv1alpha1.Breakglass{
+		Spec: accessv1alpha1.BreakglassSpec{
+			Schedule: accessv1alpha1.ScheduleSpec{
+				Cron:     "0 * * * *",
+				Duration: metav1.Duration{Duration: 30 * time.Minute},
+				Until:    &until,
+			},
+		},
+	}
+
+	window, ok := CurrentWindow(bg, now)
+	if !ok {
+		t.Fatalf("expected window, got none")
+	}
+	if !window.End.Equal(until.Time) {
+		t.Errorf("end = %v, want %v", window.End, until.Time)
+	}
+
+	// The 11:00 window starts after until and never opens
+	if _, ok := CurrentWindow(bg, now.Add(time.Hour)); ok {
+		t.Fatalf("expected no window after until")
+	}
+}
+
+func TestCurrentWindowOneShotUntil(t *testing.T) {
+	start := metav1.NewTime(time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC))
+	until := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	bg := &accessv1alpha1.Breakglass{
+		Spec: accessv1alpha1.BreakglassSpec{
+			Schedule: accessv1alpha1.ScheduleSpec{
+				Start: start,
+				Until: &until,
+			},
+		},
+	}
+
+	window, ok := CurrentWindow(bg, start.Time.Add(time.Minute))
+	if !ok {
+		t.Fatalf("expected window for one-shot with until")
+	}
+	if !window.Start.Equal(start.Time) || !window.End.Equal(until.Time) {
+		t.Errorf("window = %v-%v, want %v-%v", window.Start, window.End, start.Time, until.Time)
+	}
+}
+
+func TestInActiveWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	bg := &accessv1alpha1.Breakglass{
+		Spec: accessv1alpha1.BreakglassSpec{
+			Schedule: accessv1alpha1.ScheduleSpec{
+				Duration: metav1.Duration{Duration: time.Hour},
+				Start:    metav1.NewTime(start),
+			},
+		},
+		Status: accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhaseActive},
+	}
+
+	if !InActiveWindow(bg, start.Add(30*time.Minute)) {
+		t.Errorf("expected active request inside its window to hold access")
+	}
+	if InActiveWindow(bg, start.Add(2*time.Hour)) {
+		t.Errorf("expected active request past its window not to hold access")
+	}
+	bg.Status.Phase = accessv1alpha1.PhaseExpired
+	if InActiveWindow(bg, start.Add(30*time.Minute)) {
+		t.Errorf("expected expired request not to hold access")
+	}
+}
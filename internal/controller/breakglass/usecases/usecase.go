@@ -1,6 +1,8 @@
 package usecases
 
 import (
+	"time"
+
 	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
 )
 
@@ -45,6 +47,15 @@ func MaxActivationsReached(bg *accessv1alpha1.Breakglass) bool {
 	return bg.Status.ActivationCount >= *bg.Spec.Schedule.MaxActivations
 }
 
+// ScheduleEnded reports whether no further activations can happen, because maxActivations has been
+// used up or schedule.until has passed.
+func ScheduleEnded(bg *accessv1alpha1.Breakglass, now time.Time) bool {
+	if MaxActivationsReached(bg) {
+		return true
+	}
+	return bg != nil && bg.Spec.Schedule.Until != nil && !now.Before(bg.Spec.Schedule.Until.Time)
+}
+
 // HasFutureActivations reports whether the schedule indicates more work after the current activation.
 func HasFutureActivations(bg *accessv1alpha1.Breakglass) bool {
 	switch Determine(bg) {
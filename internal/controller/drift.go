@@ -0,0 +1,29 @@
+package controller
+
+// DriftChange describes how a live RBAC object differs from what a Breakglass grants
+type DriftChange string
+
+const (
+	// DriftMissing means a granted object was deleted
+	DriftMissing DriftChange = "missing"
+	// DriftModified means a granted object was edited
+	DriftModified DriftChange = "modified"
+	// DriftStray means an object carries the labels of a Breakglass that does not grant it
+	DriftStray DriftChange = "stray"
+)
+
+// Drift is an RBAC object whose live state differs from what its Breakglass grants
+type Drift struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Change    DriftChange
+}
+
+// String returns the drift as e.g. "RoleBinding team-a/breakglass-1a2b3c4d-rolebinding-0 modified"
+func (d Drift) String() string {
+	if d.Namespace != "" {
+		return d.Kind + " " + d.Namespace + "/" + d.Name + " " + string(d.Change)
+	}
+	return d.Kind + " " + d.Name + " " + string(d.Change)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
@@ -15,6 +16,18 @@ type BreakglassOperator interface {
 	RevokeAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) error
 	ValidateAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) error
 	CleanupResources(ctx context.Context, bg *accessv1alpha1.Breakglass) error
+	PlanAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) (*accessv1alpha1.RBACPlan, error)
+	// DetectDrift compares the live RBAC objects labelled for bg with what it grants. When granted is
+	// false bg should hold no access and every labelled object is reported as stray.
+	DetectDrift(ctx context.Context, bg *accessv1alpha1.Breakglass, granted bool) ([]Drift, error)
+	// RepairDrift recreates missing objects, restores modified ones and deletes stray ones.
+	RepairDrift(ctx context.Context, bg *accessv1alpha1.Breakglass, drift []Drift) error
+	// SyncAccess converges the live RBAC of an active bg on its spec after the spec changed and returns
+	// the changes made.
+	SyncAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) ([]Drift, error)
+	// MigrateLegacyNames moves RBAC objects granted to bg under names older operators generated onto
+	// the current names and reports whether status.createdResources was rewritten.
+	MigrateLegacyNames(ctx context.Context, bg *accessv1alpha1.Breakglass) (bool, error)
 }
 
 // RecurringManager handles recurring breakglass schedules
@@ -30,6 +43,14 @@ type AlertService interface {
 	SendAlert(ctx context.Context, bg *accessv1alpha1.Breakglass, alertType string) error
 }
 
+// TicketProvider looks up the tickets linked by spec.ticketID and records breakglass activity on them
+type TicketProvider interface {
+	// IsOpen reports whether the ticket is open. A missing ticket yields a TicketNotFoundError.
+	IsOpen(ctx context.Context, id string) (bool, error)
+	// Comment adds a comment to the ticket.
+	Comment(ctx context.Context, id, body string) error
+}
+
 // Clock provides time-related operations
 type Clock interface {
 	Now() time.Time
@@ -37,6 +58,15 @@ type Clock interface {
 	IsExpired(t time.Time) bool
 }
 
+// DeadlineScheduler wakes a request at the next time its state changes on its own, such as an activation
+// or expiry
+type DeadlineScheduler interface {
+	// Schedule reconciles the request named by key at at, replacing any deadline set for it before.
+	Schedule(key types.NamespacedName, at time.Time)
+	// Forget drops the deadline of key.
+	Forget(key types.NamespacedName)
+}
+
 // TelemetrySink handles telemetry operations
 type TelemetrySink interface {
 	RecordEvent(ctx context.Context, bg *accessv1alpha1.Breakglass, eventType string) error
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	v1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	controller "github.com/cloud-nimbus/firedoor/internal/controller"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -54,6 +55,21 @@ func (mr *MockBreakglassOperatorMockRecorder) CleanupResources(arg0, arg1 any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupResources", reflect.TypeOf((*MockBreakglassOperator)(nil).CleanupResources), arg0, arg1)
 }
 
+// DetectDrift mocks base method.
+func (m *MockBreakglassOperator) DetectDrift(arg0 context.Context, arg1 *v1alpha1.Breakglass, arg2 bool) ([]controller.Drift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectDrift", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]controller.Drift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectDrift indicates an expected call of DetectDrift.
+func (mr *MockBreakglassOperatorMockRecorder) DetectDrift(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectDrift", reflect.TypeOf((*MockBreakglassOperator)(nil).DetectDrift), arg0, arg1, arg2)
+}
+
 // GrantAccess mocks base method.
 func (m *MockBreakglassOperator) GrantAccess(arg0 context.Context, arg1 *v1alpha1.Breakglass) error {
 	m.ctrl.T.Helper()
@@ -68,6 +84,50 @@ func (mr *MockBreakglassOperatorMockRecorder) GrantAccess(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantAccess", reflect.TypeOf((*MockBreakglassOperator)(nil).GrantAccess), arg0, arg1)
 }
 
+// MigrateLegacyNames mocks base method.
+func (m *MockBreakglassOperator) MigrateLegacyNames(arg0 context.Context, arg1 *v1alpha1.Breakglass) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateLegacyNames", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateLegacyNames indicates an expected call of MigrateLegacyNames.
+func (mr *MockBreakglassOperatorMockRecorder) MigrateLegacyNames(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateLegacyNames", reflect.TypeOf((*MockBreakglassOperator)(nil).MigrateLegacyNames), arg0, arg1)
+}
+
+// PlanAccess mocks base method.
+func (m *MockBreakglassOperator) PlanAccess(arg0 context.Context, arg1 *v1alpha1.Breakglass) (*v1alpha1.RBACPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanAccess", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha1.RBACPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanAccess indicates an expected call of PlanAccess.
+func (mr *MockBreakglassOperatorMockRecorder) PlanAccess(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanAccess", reflect.TypeOf((*MockBreakglassOperator)(nil).PlanAccess), arg0, arg1)
+}
+
+// RepairDrift mocks base method.
+func (m *MockBreakglassOperator) RepairDrift(arg0 context.Context, arg1 *v1alpha1.Breakglass, arg2 []controller.Drift) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairDrift", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RepairDrift indicates an expected call of RepairDrift.
+func (mr *MockBreakglassOperatorMockRecorder) RepairDrift(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairDrift", reflect.TypeOf((*MockBreakglassOperator)(nil).RepairDrift), arg0, arg1, arg2)
+}
+
 // RevokeAccess mocks base method.
 func (m *MockBreakglassOperator) RevokeAccess(arg0 context.Context, arg1 *v1alpha1.Breakglass) error {
 	m.ctrl.T.Helper()
@@ -82,6 +142,21 @@ func (mr *MockBreakglassOperatorMockRecorder) RevokeAccess(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccess", reflect.TypeOf((*MockBreakglassOperator)(nil).RevokeAccess), arg0, arg1)
 }
 
+// SyncAccess mocks base method.
+func (m *MockBreakglassOperator) SyncAccess(arg0 context.Context, arg1 *v1alpha1.Breakglass) ([]controller.Drift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncAccess", arg0, arg1)
+	ret0, _ := ret[0].([]controller.Drift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncAccess indicates an expected call of SyncAccess.
+func (mr *MockBreakglassOperatorMockRecorder) SyncAccess(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncAccess", reflect.TypeOf((*MockBreakglassOperator)(nil).SyncAccess), arg0, arg1)
+}
+
 // ValidateAccess mocks base method.
 func (m *MockBreakglassOperator) ValidateAccess(arg0 context.Context, arg1 *v1alpha1.Breakglass) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+// statusBaseKey is the context key of the Breakglass status writes are computed against
+type statusBaseKey struct{}
+
+// statusBase is the Breakglass as last read or written during a reconcile
+type statusBase struct {
+	bg *accessv1alpha1.Breakglass
+}
+
+// WithStatusBase returns a context in which PatchStatus sends only the changes made to bg from now on,
+// so fields written by others since bg was read are left alone.
+func WithStatusBase(ctx context.Context, bg *accessv1alpha1.Breakglass) context.Context {
+	return context.WithValue(ctx, statusBaseKey{}, &statusBase{bg: bg.DeepCopy()})
+}
+
+// PatchStatus writes the status of bg as a JSON merge patch locked to the resourceVersion of bg, and
+// refreshes bg from the response.
+//
+// Within a WithStatusBase context for bg the patch holds the changes since bg was read or last patched.
+// Otherwise it holds the whole status of bg. On conflict the stored object is re-read, the same changes
+// are applied to it and the patch is retried, so fields written by others in between are kept.
+func PatchStatus(ctx context.Context, c client.Client, bg *accessv1alpha1.Breakglass) error {
+	base, ok := ctx.Value(statusBaseKey{}).(*statusBase)
+	if !ok || base.bg.UID != bg.UID {
+		return replaceStatus(ctx, c, bg)
+	}
+
+	// bg may have been written elsewhere in this reconcile, which moved its resourceVersion past the base
+	from := base.bg.DeepCopy()
+	from.ResourceVersion = bg.ResourceVersion
+	changes, err := client.MergeFrom(from).Data(bg)
+	if err != nil {
+		return err
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		data, err := client.MergeFromWithOptions(from, client.MergeFromWithOptimisticLock{}).Data(bg)
+		if err != nil {
+			return err
+		}
+		err = c.Status().Patch(ctx, bg, client.RawPatch(types.MergePatchType, data))
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+		latest := &accessv1alpha1.Breakglass{}
+		if getErr := c.Get(ctx, client.ObjectKeyFromObject(bg), latest); getErr != nil {
+			return getErr
+		}
+		from = latest.DeepCopy()
+		if applyErr := applyMergePatch(latest, changes); applyErr != nil {
+			return applyErr
+		}
+		*bg = *latest
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	base.bg = bg.DeepCopy()
+	return nil
+}
+
+// replaceStatus patches the status of bg onto the latest stored object.
+func replaceStatus(ctx context.Context, c client.Client, bg *accessv1alpha1.Breakglass) error {
+	status := bg.Status.DeepCopy()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &accessv1alpha1.Breakglass{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(bg), latest); err != nil {
+			return err
+		}
+		patch := client.MergeFromWithOptions(latest.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		status.DeepCopyInto(&latest.Status)
+		data, err := patch.Data(latest)
+		if err != nil {
+			return err
+		}
+		if err := c.Status().Patch(ctx, latest, client.RawPatch(types.MergePatchType, data)); err != nil {
+			return err
+		}
+		*bg = *latest
+		return nil
+	})
+}
+
+// applyMergePatch applies the JSON merge patch data to bg in place.
+func applyMergePatch(bg *accessv1alpha1.Breakglass, data []byte) error {
+	doc, err := json.Marshal(bg)
+	if err != nil {
+		return err
+	}
+	if doc, err = jsonpatch.MergePatch(doc, data); err != nil {
+		return err
+	}
+	patched := &accessv1alpha1.Breakglass{}
+	if err := json.Unmarshal(doc, patched); err != nil {
+		return err
+	}
+	*bg = *patched
+	return nil
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+func newStatusTestClient(t *testing.T, funcs interceptor.Funcs) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "uid"},
+		Status:     accessv1alpha1.BreakglassStatus{Phase: accessv1alpha1.PhasePending},
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&accessv1alpha1.Breakglass{}).
+		WithObjects(bg).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
+
+// approveConcurrently writes a status field the way a concurrent reconcile or approval would
+func approveConcurrently(t *testing.T, c client.Client) {
+	var other accessv1alpha1.Breakglass
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "bg"}, &other))
+	other.Status.ApprovedBy = "bob"
+	require.NoError(t, c.Status().Update(context.Background(), &other))
+}
+
+func TestPatchStatus_KeepsConcurrentWrites(t *testing.T) {
+	var conflicts int
+	c := newStatusTestClient(t, interceptor.Funcs{
+		SubResourcePatch: func(
+			ctx context.Context,
+			c client.Client,
+			subResourceName string,
+			obj client.Object,
+			patch client.Patch,
+			opts ...client.SubResourcePatchOption,
+		) error {
+			err := c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			if apierrors.IsConflict(err) {
+				conflicts++
+			}
+			return err
+		},
+	})
+	var bg accessv1alpha1.Breakglass
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "bg"}, &bg))
+	ctx := WithStatusBase(context.Background(), &bg)
+
+	approveConcurrently(t, c)
+	bg.Status.Phase = accessv1alpha1.PhaseActive
+	require.NoError(t, PatchStatus(ctx, c, &bg))
+	assert.Equal(t, 1, conflicts, "the patch of the stale object is refused and retried")
+	bg.Status.CreatedResources = []string{"breakglass-clusterrolebinding-edit-0f1e"}
+	require.NoError(t, PatchStatus(ctx, c, &bg))
+	assert.Equal(t, 1, conflicts)
+
+	var stored accessv1alpha1.Breakglass
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(&bg), &stored))
+	assert.Equal(t, accessv1alpha1.PhaseActive, stored.Status.Phase)
+	assert.Equal(t, "bob", stored.Status.ApprovedBy)
+	assert.Equal(t, []string{"breakglass-clusterrolebinding-edit-0f1e"}, stored.Status.CreatedResources)
+	assert.Equal(t, stored.Status, bg.Status, "bg is refreshed from the response")
+}
+
+func TestPatchStatus_StaleResourceVersion(t *testing.T) {
+	c := newStatusTestClient(t, interceptor.Funcs{})
+	var bg accessv1alpha1.Breakglass
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "bg"}, &bg))
+	ctx := WithStatusBase(context.Background(), &bg)
+	approveConcurrently(t, c)
+
+	// The patch carries the resourceVersion it was computed against, so the server refuses it
+	from := bg.DeepCopy()
+	bg.Status.Phase = accessv1alpha1.PhaseActive
+	data, err := client.MergeFromWithOptions(from, client.MergeFromWithOptimisticLock{}).Data(&bg)
+	require.NoError(t, err)
+	err = c.Status().Patch(context.Background(), bg.DeepCopy(), client.RawPatch(types.MergePatchType, data))
+	assert.True(t, apierrors.IsConflict(err), "got %v", err)
+
+	require.NoError(t, PatchStatus(ctx, c, &bg))
+	assert.Equal(t, "bob", bg.Status.ApprovedBy, "the retry starts from the re-read object")
+	assert.Equal(t, accessv1alpha1.PhaseActive, bg.Status.Phase)
+}
+
+func TestPatchStatus_RetriesOnConflict(t *testing.T) {
+	conflicts := 1
+	calls := 0
+	c := newStatusTestClient(t, interceptor.Funcs{
+		SubResourcePatch: func(
+			ctx context.Context,
+			c client.Client,
+			subResourceName string,
+			obj client.Object,
+			patch client.Patch,
+			opts ...client.SubResourcePatchOption,
+		) error {
+			calls++
+			if conflicts > 0 {
+				conflicts--
+				approveConcurrently(t, c)
+				return apierrors.NewConflict(schema.GroupResource{Resource: "breakglasses"}, obj.GetName(), nil)
+			}
+			return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+		},
+	})
+	var bg accessv1alpha1.Breakglass
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "bg"}, &bg))
+
+	bg.Status.Phase = accessv1alpha1.PhaseActive
+	require.NoError(t, PatchStatus(context.Background(), c, &bg))
+	assert.Equal(t, 2, calls)
+
+	var stored accessv1alpha1.Breakglass
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(&bg), &stored))
+	assert.Equal(t, accessv1alpha1.PhaseActive, stored.Status.Phase)
+	assert.Equal(t, stored.ResourceVersion, bg.ResourceVersion)
+}
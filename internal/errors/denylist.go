@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DenylistError reports the protected ClusterRoles, subjects or wildcard rules a request targets.
+// It is returned instead of granting access unless the admitting BreakglassPolicy allows it.
+type DenylistError struct {
+	Reasons []string
+}
+
+func (e *DenylistError) Error() string {
+	return fmt.Sprintf("request targets protected access: %s", strings.Join(e.Reasons, "; "))
+}
+
+// IsDenylistError reports whether err is or wraps a DenylistError.
+func IsDenylistError(err error) bool {
+	var denyErr *DenylistError
+	return errors.As(err, &denyErr)
+}
+
+// NewDenylistError creates a DenylistError.
+func NewDenylistError(reasons []string) *DenylistError {
+	return &DenylistError{Reasons: reasons}
+}
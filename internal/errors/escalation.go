@@ -0,0 +1,31 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EscalationError reports the requested permissions the requester does not hold.
+// It is returned instead of granting access when privilege escalation is disabled.
+type EscalationError struct {
+	Requester string
+	// Denied holds one explanation per rule the requester is not allowed to grant.
+	Denied []string
+}
+
+func (e *EscalationError) Error() string {
+	return fmt.Sprintf("requester %q does not hold the requested permissions: %s",
+		e.Requester, strings.Join(e.Denied, "; "))
+}
+
+// IsEscalationError reports whether err is or wraps an EscalationError.
+func IsEscalationError(err error) bool {
+	var escErr *EscalationError
+	return errors.As(err, &escErr)
+}
+
+// NewEscalationError creates an EscalationError.
+func NewEscalationError(requester string, denied []string) *EscalationError {
+	return &EscalationError{Requester: requester, Denied: denied}
+}
@@ -0,0 +1,29 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NamespaceRestrictionError reports where a namespaced request grants access outside its own namespace.
+// It is returned instead of granting access when requests are restricted to their own namespace.
+type NamespaceRestrictionError struct {
+	Namespace string
+	Reasons   []string
+}
+
+func (e *NamespaceRestrictionError) Error() string {
+	return fmt.Sprintf("request may only grant access in namespace %q: %s", e.Namespace, strings.Join(e.Reasons, "; "))
+}
+
+// IsNamespaceRestrictionError reports whether err is or wraps a NamespaceRestrictionError.
+func IsNamespaceRestrictionError(err error) bool {
+	var nsErr *NamespaceRestrictionError
+	return errors.As(err, &nsErr)
+}
+
+// NewNamespaceRestrictionError creates a NamespaceRestrictionError.
+func NewNamespaceRestrictionError(namespace string, reasons []string) *NamespaceRestrictionError {
+	return &NamespaceRestrictionError{Namespace: namespace, Reasons: reasons}
+}
@@ -0,0 +1,31 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TransitionError reports a phase change the Breakglass state machine does not allow.
+type TransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TransitionError) Error() string {
+	from := e.From
+	if from == "" {
+		from = "<none>"
+	}
+	return fmt.Sprintf("illegal phase transition from %s to %s", from, e.To)
+}
+
+// IsTransitionError reports whether err is or wraps a TransitionError.
+func IsTransitionError(err error) bool {
+	var transitionErr *TransitionError
+	return errors.As(err, &transitionErr)
+}
+
+// NewTransitionError creates a TransitionError.
+func NewTransitionError(from, to string) *TransitionError {
+	return &TransitionError{From: from, To: to}
+}
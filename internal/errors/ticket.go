@@ -0,0 +1,26 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TicketNotFoundError reports that the ticket system has no ticket with the given ID.
+type TicketNotFoundError struct {
+	ID string
+}
+
+func (e *TicketNotFoundError) Error() string {
+	return fmt.Sprintf("ticket %s does not exist", e.ID)
+}
+
+// IsTicketNotFoundError reports whether err is or wraps a TicketNotFoundError.
+func IsTicketNotFoundError(err error) bool {
+	var ticketErr *TicketNotFoundError
+	return errors.As(err, &ticketErr)
+}
+
+// NewTicketNotFoundError creates a TicketNotFoundError.
+func NewTicketNotFoundError(id string) *TicketNotFoundError {
+	return &TicketNotFoundError{ID: id}
+}
@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationError lists why a request cannot be granted as written, such as references to ClusterRoles,
+// namespaces or ServiceAccounts that do not exist. It is not retried; the request has to be changed.
+type ValidationError struct {
+	Reasons []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid request: %s", strings.Join(e.Reasons, "; "))
+}
+
+// IsValidationError reports whether err is or wraps a ValidationError.
+func IsValidationError(err error) bool {
+	var validationErr *ValidationError
+	return errors.As(err, &validationErr)
+}
+
+// NewValidationError creates a ValidationError.
+func NewValidationError(reasons []string) *ValidationError {
+	return &ValidationError{Reasons: reasons}
+}
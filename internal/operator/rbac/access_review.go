@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The Cloud-Nimbus Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+// scopedRules are rules that would be granted in a namespace, or cluster-wide when namespace is empty.
+type scopedRules struct {
+	source    string
+	namespace string
+	rules     []rbacv1.PolicyRule
+}
+
+// checkRequesterPermissions verifies with SubjectAccessReviews that the requester already holds every
+// permission that bg would grant. An *errors.EscalationError lists the rules that were denied.
+func (o *Operator) checkRequesterPermissions(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	if !o.admissionWebhook {
+		return errors.NewEscalationError("", []string{
+			"requester identity cannot be trusted without the admission webhook; enable it or privilege escalation",
+		})
+	}
+	requester := userInfo(bg)
+	if requester.User == "" {
+		return errors.NewEscalationError("", []string{
+			"requester identity is unknown; enable the admission webhook or privilege escalation",
+		})
+	}
+
+	requested, err := o.requestedRules(ctx, bg)
+	if err != nil {
+		return err
+	}
+
+	var denied []string
+	for _, scoped := range requested {
+		for i, rule := range scoped.rules {
+			missing, err := o.reviewRule(ctx, requester, scoped.namespace, rule)
+			if err != nil {
+				return err
+			}
+			if len(missing) == 0 {
+				continue
+			}
+			where := "cluster-wide"
+			if scoped.namespace != "" {
+				where = "in namespace " + scoped.namespace
+			}
+			denied = append(denied, fmt.Sprintf("%s.rules[%d]: cannot %s %s",
+				scoped.source, i, strings.Join(missing, ", "), where))
+		}
+	}
+	if len(denied) > 0 {
+		ctrl.LoggerFrom(ctx).Info("requester lacks requested permissions", "requester", requester.User, "denied", denied)
+		return errors.NewEscalationError(requester.User, denied)
+	}
+	return nil
+}
+
+// requestedRules expands the policies and ClusterRoles of bg into the rules it would grant per namespace.
+func (o *Operator) requestedRules(ctx context.Context, bg *accessv1alpha1.Breakglass) ([]scopedRules, error) {
+	requested := make([]scopedRules, 0, len(bg.Spec.Policy)+len(bg.Spec.ClusterRoles))
+	for i, policy := range bg.Spec.Policy {
+		requested = append(requested, scopedRules{
+			source:    fmt.Sprintf("policy[%d]", i),
+			namespace: policy.Namespace,
+			rules:     policy.Rules,
+		})
+	}
+
+	if len(bg.Spec.ClusterRoles) == 0 {
+		return requested, nil
+	}
+	namespaces := []string{""}
+	if bg.Spec.ClusterRoleScope != nil {
+		var err error
+		if namespaces, err = o.resolveNamespaces(ctx, bg.Spec.ClusterRoleScope); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range bg.Spec.ClusterRoles {
+		var cr rbacv1.ClusterRole
+		if err := o.getResourceWithTimeout(ctx, client.ObjectKey{Name: name}, &cr); err != nil {
+			if errors.IsNotFoundError(err) {
+				return nil, errors.NewPermanentRBACError("reading", "ClusterRole "+name,
+					accessv1alpha1.ReasonInvalidRequest, err)
+			}
+			return nil, errors.NewRetryableRBACError("reading", "ClusterRole "+name,
+				accessv1alpha1.ReasonRBACTimeout, err)
+		}
+		for _, ns := range namespaces {
+			requested = append(requested, scopedRules{
+				source:    fmt.Sprintf("clusterRole %q", name),
+				namespace: ns,
+				rules:     cr.Rules,
+			})
+		}
+	}
+	return requested, nil
+}
+
+// reviewRule runs one SubjectAccessReview per verb and resource in rule and returns the denied ones.
+func (o *Operator) reviewRule(
+	ctx context.Context,
+	requester authorizationv1.SubjectAccessReviewSpec,
+	namespace string,
+	rule rbacv1.PolicyRule,
+) ([]string, error) {
+	var missing []string
+	for _, attrs := range ruleAttributes(namespace, rule) {
+		sar := &authorizationv1.SubjectAccessReview{Spec: requester}
+		sar.Spec.ResourceAttributes = attrs.resource
+		sar.Spec.NonResourceAttributes = attrs.nonResource
+
+		childCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		err := o.client.Create(childCtx, sar)
+		cancel()
+		if err != nil {
+			return nil, errors.NewRetryableRBACError("creating", "SubjectAccessReview",
+				accessv1alpha1.ReasonRBACTimeout, err)
+		}
+		if !sar.Status.Allowed {
+			missing = append(missing, attrs.String())
+		}
+	}
+	return missing, nil
+}
+
+// accessAttributes is a single verb on a single resource or non-resource URL.
+type accessAttributes struct {
+	resource    *authorizationv1.ResourceAttributes
+	nonResource *authorizationv1.NonResourceAttributes
+}
+
+func (a accessAttributes) String() string {
+	if a.nonResource != nil {
+		return a.nonResource.Verb + " " + a.nonResource.Path
+	}
+	resource := a.resource.Resource
+	if a.resource.Subresource != "" {
+		resource += "/" + a.resource.Subresource
+	}
+	if a.resource.Group != "" {
+		resource += "." + a.resource.Group
+	}
+	if a.resource.Name != "" {
+		resource += " " + a.resource.Name
+	}
+	return a.resource.Verb + " " + resource
+}
+
+// ruleAttributes expands rule into the individual attributes a SubjectAccessReview can check.
+// Wildcards are passed through, so "*" is only allowed if the requester holds the wildcard too.
+func ruleAttributes(namespace string, rule rbacv1.PolicyRule) []accessAttributes {
+	var attrs []accessAttributes
+	for _, verb := range rule.Verbs {
+		for _, path := range rule.NonResourceURLs {
+			attrs = append(attrs, accessAttributes{
+				nonResource: &authorizationv1.NonResourceAttributes{Path: path, Verb: verb},
+			})
+		}
+		names := rule.ResourceNames
+		if len(names) == 0 {
+			names = []string{""}
+		}
+		for _, group := range rule.APIGroups {
+			for _, res := range rule.Resources {
+				resource, subresource, _ := strings.Cut(res, "/")
+				for _, name := range names {
+					attrs = append(attrs, accessAttributes{resource: &authorizationv1.ResourceAttributes{
+						Namespace:   namespace,
+						Verb:        verb,
+						Group:       group,
+						Resource:    resource,
+						Subresource: subresource,
+						Name:        name,
+					}})
+				}
+			}
+		}
+	}
+	return attrs
+}
+
+// userInfo builds the SubjectAccessReview subject from the requester annotations.
+func userInfo(bg *accessv1alpha1.Breakglass) authorizationv1.SubjectAccessReviewSpec {
+	spec := authorizationv1.SubjectAccessReviewSpec{
+		User: bg.Annotations[accessv1alpha1.RequestedByAnnotation],
+		UID:  bg.Annotations[accessv1alpha1.RequestedByUIDAnnotation],
+	}
+	if groups := bg.Annotations[accessv1alpha1.RequestedByGroupsAnnotation]; groups != "" {
+		spec.Groups = strings.Split(groups, ",")
+	}
+	return spec
+}
+
+// getResourceWithTimeout reads a resource firedoor did not create through the operator's reader with a
+// 30-second timeout
+func (o *Operator) getResourceWithTimeout(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	childCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return o.reader.Get(childCtx, key, obj)
+}
@@ -0,0 +1,55 @@
+package rbac
+
+import (
+	"context"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OwnerUIDField indexes Roles, RoleBindings, ClusterRoles and ClusterRoleBindings by the breakglass/uid
+// label, so the objects of one request are found without scanning the others.
+const OwnerUIDField = "rbac.ownerUID"
+
+// rbacKinds returns an empty object of each RBAC kind the operator creates.
+func rbacKinds() []client.Object {
+	return []client.Object{&rbacv1.Role{}, &rbacv1.RoleBinding{}, &rbacv1.ClusterRole{}, &rbacv1.ClusterRoleBinding{}}
+}
+
+// OwnerUIDIndexer returns the breakglass/uid label of obj, if any.
+func OwnerUIDIndexer(obj client.Object) []string {
+	if uid := obj.GetLabels()[LabelUID]; uid != "" {
+		return []string{uid}
+	}
+	return nil
+}
+
+// IndexOwnerUID registers OwnerUIDField for every RBAC kind the operator creates. Operators built with
+// WithOwnerIndex need it.
+func IndexOwnerUID(ctx context.Context, indexer client.FieldIndexer) error {
+	for _, obj := range rbacKinds() {
+		if err := indexer.IndexField(ctx, obj, OwnerUIDField, OwnerUIDIndexer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CacheByObject restricts the informers for RBAC kinds to objects carrying the breakglass/uid label, so
+// the cache holds what firedoor created rather than every binding in the cluster. Any other RBAC object
+// is invisible to a client reading from that cache; operators sharing it need WithReader.
+func CacheByObject() map[client.Object]cache.ByObject {
+	owned, err := labels.NewRequirement(LabelUID, selection.Exists, nil)
+	if err != nil {
+		panic(err) // LabelUID is a valid label key
+	}
+	selector := labels.NewSelector().Add(*owned)
+	byObject := make(map[client.Object]cache.ByObject)
+	for _, obj := range rbacKinds() {
+		byObject[obj] = cache.ByObject{Label: selector}
+	}
+	return byObject
+}
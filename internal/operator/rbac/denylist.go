@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Cloud-Nimbus Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/errors"
+	"github.com/cloud-nimbus/firedoor/internal/policy"
+)
+
+// checkDenylist refuses requests that target protected ClusterRoles, subjects or wildcard rules,
+// including wildcard rules inside the requested ClusterRoles.
+// The BreakglassPolicy recorded as admitting bg may lift the refusal with allowProtected.
+func (o *Operator) checkDenylist(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	var clusterRoles []rbacv1.ClusterRole
+	if !o.denylist.AllowWildcardRules {
+		childCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		var err error
+		if clusterRoles, err = policy.RequestedClusterRoles(childCtx, o.reader, bg); err != nil {
+			return errors.NewRetryableRBACError("reading", "requested ClusterRoles", accessv1alpha1.ReasonRBACTimeout, err)
+		}
+	}
+
+	reasons := policy.CheckDenylist(o.denylist, bg, clusterRoles)
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	admitted, err := o.admittingPolicy(ctx, bg)
+	if err != nil {
+		return err
+	}
+	if admitted != nil && admitted.Spec.AllowProtected {
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("refusing protected access", "reasons", reasons)
+	return errors.NewDenylistError(reasons)
+}
+
+// checkTargetNamespaces refuses namespaced requests that grant access outside their own namespace
+// without an allowance from the BreakglassPolicy recorded as admitting bg.
+func (o *Operator) checkTargetNamespaces(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	if bg.IsClusterScoped() {
+		return nil
+	}
+	admitted, err := o.admittingPolicy(ctx, bg)
+	if err != nil {
+		return err
+	}
+	if reasons := policy.CheckTargetNamespaces(bg, admitted); len(reasons) > 0 {
+		ctrl.LoggerFrom(ctx).Info("refusing access outside the request namespace", "reasons", reasons)
+		return errors.NewNamespaceRestrictionError(bg.Namespace, reasons)
+	}
+	return nil
+}
+
+// admittingPolicy returns the BreakglassPolicy recorded in bg.Status.AdmittedByPolicy,
+// or nil when none is recorded or it no longer exists.
+func (o *Operator) admittingPolicy(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+) (*accessv1alpha1.BreakglassPolicy, error) {
+	name := bg.Status.AdmittedByPolicy
+	if name == "" {
+		return nil, nil
+	}
+	var admitted accessv1alpha1.BreakglassPolicy
+	if err := o.client.Get(ctx, client.ObjectKey{Name: name}, &admitted); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get breakglass policy %s: %w", name, err)
+	}
+	return &admitted, nil
+}
@@ -0,0 +1,263 @@
+package rbac
+
+import (
+	"context"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	"github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+// DetectDrift compares the live RBAC objects labelled for bg with what it grants. When granted is false
+// bg should hold no access and every labelled object is reported as stray. An object whose labels were
+// stripped no longer counts as granted and is reported as missing.
+func (o *Operator) DetectDrift(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+	granted bool,
+) ([]controller.Drift, error) {
+	desired := map[string]client.Object{}
+	if granted {
+		var err error
+		if desired, err = o.desiredObjects(ctx, bg); err != nil {
+			return nil, err
+		}
+	}
+	live, err := o.labelledObjects(ctx, bg)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift []controller.Drift
+	for key, want := range desired {
+		got, ok := live[key]
+		switch {
+		case !ok:
+			drift = append(drift, driftOf(want, controller.DriftMissing))
+		case rbacDiffers(want, got):
+			drift = append(drift, driftOf(want, controller.DriftModified))
+		}
+	}
+	for key, got := range live {
+		if _, ok := desired[key]; !ok {
+			drift = append(drift, driftOf(got, controller.DriftStray))
+		}
+	}
+	sort.Slice(drift, func(i, j int) bool { return drift[i].String() < drift[j].String() })
+	return drift, nil
+}
+
+// RepairDrift recreates missing objects, restores modified ones and deletes stray ones. Objects bg no
+// longer grants since the drift was detected are left alone.
+func (o *Operator) RepairDrift(ctx context.Context, bg *accessv1alpha1.Breakglass, drift []controller.Drift) error {
+	log := ctrl.LoggerFrom(ctx)
+	var desired map[string]client.Object
+	for _, d := range drift {
+		if d.Change == controller.DriftStray {
+			obj := newRBACObject(d.Kind)
+			obj.SetName(d.Name)
+			obj.SetNamespace(d.Namespace)
+			if err := o.deleteResourceWithTimeout(ctx, obj, describeResource(obj)); err != nil {
+				return err
+			}
+			log.Info("deleted stray "+d.Kind, "namespace", d.Namespace, "name", d.Name)
+			continue
+		}
+
+		if desired == nil {
+			var err error
+			if desired, err = o.desiredObjects(ctx, bg); err != nil {
+				return err
+			}
+		}
+		want, ok := desired[roleKey(d.Kind, d.Namespace, d.Name)]
+		if !ok {
+			continue
+		}
+		if err := o.restoreResource(ctx, want); err != nil {
+			return err
+		}
+		log.Info("restored "+d.Kind, "namespace", d.Namespace, "name", d.Name, "change", d.Change)
+	}
+	return nil
+}
+
+// desiredObjects returns the objects bg grants, keyed by kind, namespace and name.
+func (o *Operator) desiredObjects(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+) (map[string]client.Object, error) {
+	resources, err := o.desiredResources(ctx, bg, o.getBreakglassLabels(bg))
+	if err != nil {
+		return nil, err
+	}
+	objects := make(map[string]client.Object, len(resources))
+	for _, r := range resources {
+		objects[objectKey(r.obj)] = r.obj
+	}
+	return objects, nil
+}
+
+// labelledObjects returns the live RBAC objects carrying the labels of bg, keyed by kind, namespace and name.
+func (o *Operator) labelledObjects(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+) (map[string]client.Object, error) {
+	labels := o.getBreakglassLabels(bg)
+	objects := make(map[string]client.Object)
+
+	var rbList rbacv1.RoleBindingList
+	if err := o.listResourcesWithTimeout(ctx, &rbList, labels); err != nil {
+		return nil, errors.NewRetryableRBACError("listing", "RoleBindings", accessv1alpha1.ReasonRBACTimeout, err)
+	}
+	for i := range rbList.Items {
+		objects[objectKey(&rbList.Items[i])] = &rbList.Items[i]
+	}
+
+	var roleList rbacv1.RoleList
+	if err := o.listResourcesWithTimeout(ctx, &roleList, labels); err != nil {
+		return nil, errors.NewRetryableRBACError("listing", "Roles", accessv1alpha1.ReasonRBACTimeout, err)
+	}
+	for i := range roleList.Items {
+		objects[objectKey(&roleList.Items[i])] = &roleList.Items[i]
+	}
+
+	var crbList rbacv1.ClusterRoleBindingList
+	if err := o.listResourcesWithTimeout(ctx, &crbList, labels); err != nil {
+		return nil, errors.NewRetryableRBACError("listing", "ClusterRoleBindings", accessv1alpha1.ReasonRBACTimeout, err)
+	}
+	for i := range crbList.Items {
+		objects[objectKey(&crbList.Items[i])] = &crbList.Items[i]
+	}
+
+	var crList rbacv1.ClusterRoleList
+	if err := o.listResourcesWithTimeout(ctx, &crList, labels); err != nil {
+		return nil, errors.NewRetryableRBACError("listing", "ClusterRoles", accessv1alpha1.ReasonRBACTimeout, err)
+	}
+	for i := range crList.Items {
+		objects[objectKey(&crList.Items[i])] = &crList.Items[i]
+	}
+
+	return objects, nil
+}
+
+// restoreResource applies want again, which takes back its rules and subjects from whoever changed them.
+// Bindings whose roleRef was changed are deleted first since roleRef cannot be updated. An object at the
+// generated name whose labels were stripped is relabelled if firedoor applied it, and replaced otherwise.
+func (o *Operator) restoreResource(ctx context.Context, want client.Object) error {
+	desc := describeResource(want)
+	want = want.DeepCopyObject().(client.Object)
+	live := newRBACObject(kindOf(want))
+	if err := o.getOwnedResourceWithTimeout(ctx, client.ObjectKeyFromObject(want), live); err != nil {
+		if !errors.IsNotFoundError(err) {
+			return errors.NewRetryableRBACError("reading", desc, accessv1alpha1.ReasonRBACTimeout, err)
+		}
+		return o.serverSideApply(ctx, want, desc)
+	}
+
+	unlabelled := live.GetLabels()[LabelUID] == ""
+	if !unlabelled {
+		if err := checkOwnership(live, want, "restoring", desc); err != nil {
+			return err
+		}
+	}
+	if roleRefChanged(want, live) || (unlabelled && !appliedByFiredoor(live)) {
+		if err := o.deleteResourceWithTimeout(ctx, live, desc); err != nil {
+			return err
+		}
+	}
+	return o.serverSideApply(ctx, want, desc)
+}
+
+// appliedByFiredoor reports whether obj carries fields server-side applied by the operator, which proves an
+// object that lost its labels was created for a request rather than planted at the generated name.
+func appliedByFiredoor(obj client.Object) bool {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == FieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			return true
+		}
+	}
+	return false
+}
+
+// rbacDiffers reports whether the rules, subjects or roleRef of live differ from want.
+func rbacDiffers(want, live client.Object) bool {
+	switch w := want.(type) {
+	case *rbacv1.Role:
+		return !equality.Semantic.DeepEqual(w.Rules, live.(*rbacv1.Role).Rules)
+	case *rbacv1.ClusterRole:
+		return !equality.Semantic.DeepEqual(w.Rules, live.(*rbacv1.ClusterRole).Rules)
+	case *rbacv1.RoleBinding:
+		l := live.(*rbacv1.RoleBinding)
+		return w.RoleRef != l.RoleRef || !subjectsEqual(w.Subjects, l.Subjects)
+	case *rbacv1.ClusterRoleBinding:
+		l := live.(*rbacv1.ClusterRoleBinding)
+		return w.RoleRef != l.RoleRef || !subjectsEqual(w.Subjects, l.Subjects)
+	}
+	return false
+}
+
+// roleRefChanged reports whether live is a binding referencing a different role than want.
+func roleRefChanged(want, live client.Object) bool {
+	switch w := want.(type) {
+	case *rbacv1.RoleBinding:
+		return w.RoleRef != live.(*rbacv1.RoleBinding).RoleRef
+	case *rbacv1.ClusterRoleBinding:
+		return w.RoleRef != live.(*rbacv1.ClusterRoleBinding).RoleRef
+	}
+	return false
+}
+
+// subjectsEqual compares subjects the way the API server stores them: User and Group subjects
+// without an apiGroup are defaulted to rbac.authorization.k8s.io.
+func subjectsEqual(a, b []rbacv1.Subject) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if defaultSubject(a[i]) != defaultSubject(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func defaultSubject(s rbacv1.Subject) rbacv1.Subject {
+	if s.APIGroup == "" && (s.Kind == rbacv1.UserKind || s.Kind == rbacv1.GroupKind) {
+		s.APIGroup = rbacv1.GroupName
+	}
+	return s
+}
+
+// newRBACObject returns an empty object of an RBAC kind built by the operator.
+func newRBACObject(kind string) client.Object {
+	switch kind {
+	case accessv1alpha1.KindRole:
+		return &rbacv1.Role{}
+	case accessv1alpha1.KindClusterRole:
+		return &rbacv1.ClusterRole{}
+	case accessv1alpha1.KindClusterRoleBinding:
+		return &rbacv1.ClusterRoleBinding{}
+	}
+	return &rbacv1.RoleBinding{}
+}
+
+func objectKey(obj client.Object) string {
+	return roleKey(kindOf(obj), obj.GetNamespace(), obj.GetName())
+}
+
+func driftOf(obj client.Object, change controller.DriftChange) controller.Drift {
+	return controller.Drift{
+		Kind:      kindOf(obj),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Change:    change,
+	}
+}
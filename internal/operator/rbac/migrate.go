@@ -0,0 +1,167 @@
+package rbac
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+// legacyUIDLength is the number of UID characters operators put in names before they were hashed
+const legacyUIDLength = 8
+
+// MigrateLegacyNames moves the RBAC of bg granted by an operator that named objects
+// "breakglass-<first 8 UID characters>-<suffix>" onto the names resourceName generates. Each labelled
+// legacy object is adopted: a copy with the same rules, subjects and roleRef is applied under the current
+// name before the legacy object is deleted, so access is neither interrupted nor changed by the upgrade.
+// Whether the copies still match the spec is left to drift detection. status.createdResources is
+// rewritten to the current names; the caller writes the status. It reports whether anything was migrated.
+func (o *Operator) MigrateLegacyNames(ctx context.Context, bg *accessv1alpha1.Breakglass) (bool, error) {
+	prefix := legacyNamePrefix(bg)
+	if prefix == "" || !hasLegacyRef(bg.Status.CreatedResources, prefix) {
+		return false, nil
+	}
+
+	live, err := o.labelledObjects(ctx, bg)
+	if err != nil {
+		return false, err
+	}
+	// Roles are copied before the bindings referencing them
+	keys := make([]string, 0, len(live))
+	for key, obj := range live {
+		if strings.HasPrefix(obj.GetName(), prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return migrationOrder(live[keys[i]]) < migrationOrder(live[keys[j]])
+	})
+
+	log := ctrl.LoggerFrom(ctx)
+	labels := o.getBreakglassLabels(bg)
+	for _, key := range keys {
+		legacy := live[key]
+		adopted := adoptLegacy(bg, legacy, prefix, labels)
+		if err := o.applyResourceWithTimeout(ctx, adopted, describeResource(adopted)); err != nil {
+			return false, err
+		}
+		if err := o.deleteResourceWithTimeout(ctx, legacy, describeResource(legacy)); err != nil {
+			return false, err
+		}
+		log.Info("migrated legacy "+strings.ToLower(kindOf(legacy)),
+			"namespace", legacy.GetNamespace(), "name", legacy.GetName(), "to", adopted.GetName())
+	}
+
+	refs := make([]string, 0, len(bg.Status.CreatedResources))
+	for _, ref := range bg.Status.CreatedResources {
+		refs = append(refs, currentRef(bg, ref, prefix))
+	}
+	bg.Status.CreatedResources = refs
+	return true, nil
+}
+
+// legacyNamePrefix returns the prefix of the names bg was granted under before names were hashed.
+func legacyNamePrefix(bg *accessv1alpha1.Breakglass) string {
+	if len(bg.UID) < legacyUIDLength {
+		return ""
+	}
+	return "breakglass-" + string(bg.UID)[:legacyUIDLength] + "-"
+}
+
+// hasLegacyRef reports whether a status.createdResources entry, "<name>" or "<namespace>/<name>", carries
+// a legacy name.
+func hasLegacyRef(refs []string, prefix string) bool {
+	for _, ref := range refs {
+		if _, name, ok := strings.Cut(ref, "/"); ok {
+			ref = name
+		}
+		if strings.HasPrefix(ref, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// currentSuffix maps the suffix of a legacy name to the suffix resourceName is given for the same object.
+// Roles and RoleBindings created for spec.policy were named role-<i> and rolebinding-<i>; RoleBindings of
+// clusterRoles scoped to namespaces keep rolebinding-<clusterRole>.
+func currentSuffix(suffix string, policyBinding bool) string {
+	if strings.HasPrefix(suffix, "role-") || (policyBinding && strings.HasPrefix(suffix, "rolebinding-")) {
+		return "policy-" + suffix
+	}
+	return suffix
+}
+
+// currentRef maps a status.createdResources entry to the current name. Only RoleBindings of scoped
+// clusterRoles were recorded with their namespace.
+func currentRef(bg *accessv1alpha1.Breakglass, ref, prefix string) string {
+	if ns, name, ok := strings.Cut(ref, "/"); ok {
+		if suffix, ok := strings.CutPrefix(name, prefix); ok {
+			return ns + "/" + resourceName(bg, currentSuffix(suffix, false))
+		}
+		return ref
+	}
+	if suffix, ok := strings.CutPrefix(ref, prefix); ok {
+		return resourceName(bg, currentSuffix(suffix, true))
+	}
+	return ref
+}
+
+// adoptLegacy returns a copy of legacy under its current name, carrying the labels of bg. Bindings
+// referencing a legacy role of bg are pointed at the role's current name.
+func adoptLegacy(
+	bg *accessv1alpha1.Breakglass,
+	legacy client.Object,
+	prefix string,
+	labels map[string]string,
+) client.Object {
+	currentName := func(name string, policyBinding bool) string {
+		if suffix, ok := strings.CutPrefix(name, prefix); ok {
+			return resourceName(bg, currentSuffix(suffix, policyBinding))
+		}
+		return name
+	}
+	meta := func(policyBinding bool) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      currentName(legacy.GetName(), policyBinding),
+			Namespace: legacy.GetNamespace(),
+			Labels:    labels,
+		}
+	}
+
+	switch l := legacy.(type) {
+	case *rbacv1.Role:
+		return &rbacv1.Role{ObjectMeta: meta(false), Rules: l.Rules}
+	case *rbacv1.ClusterRole:
+		return &rbacv1.ClusterRole{ObjectMeta: meta(false), Rules: l.Rules}
+	case *rbacv1.RoleBinding:
+		roleRef := l.RoleRef
+		roleRef.Name = currentName(roleRef.Name, false)
+		return &rbacv1.RoleBinding{
+			ObjectMeta: meta(l.RoleRef.Kind == accessv1alpha1.KindRole),
+			Subjects:   l.Subjects,
+			RoleRef:    roleRef,
+		}
+	case *rbacv1.ClusterRoleBinding:
+		roleRef := l.RoleRef
+		roleRef.Name = currentName(roleRef.Name, false)
+		return &rbacv1.ClusterRoleBinding{ObjectMeta: meta(false), Subjects: l.Subjects, RoleRef: roleRef}
+	}
+	return legacy
+}
+
+// migrationOrder sorts roles before bindings.
+func migrationOrder(obj client.Object) int {
+	switch obj.(type) {
+	case *rbacv1.Role, *rbacv1.ClusterRole:
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,31 @@
+package rbac
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+const (
+	// maxNameLength bounds generated names so they are also valid label values
+	maxNameLength = 63
+	// nameHashLength is the number of hex characters of the hash ending every generated name
+	nameHashLength = 16
+)
+
+// resourceName returns the name of an RBAC object bg grants, e.g.
+// breakglass-clusterrolebinding-view-3f2a9c0d1e4b5a67. The readable part is cut to keep the name within
+// maxNameLength. The hash covers the full request UID and suffix, so requests never share a name even
+// when their UIDs share a prefix or long role names are cut.
+func resourceName(bg *accessv1alpha1.Breakglass, suffix string) string {
+	sum := sha256.Sum256([]byte(string(bg.UID) + "/" + suffix))
+	hash := hex.EncodeToString(sum[:])[:nameHashLength]
+
+	readable := "breakglass-" + suffix
+	if limit := maxNameLength - nameHashLength - 1; len(readable) > limit {
+		readable = strings.TrimRight(readable[:limit], "-.:")
+	}
+	return readable + "-" + hash
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
 )
 
 type NoopOperator struct{}
@@ -17,3 +18,25 @@ func (n NoopOperator) RevokeAccess(ctx context.Context, bg *accessv1alpha1.Break
 func (n NoopOperator) ValidateAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
 	return nil
 }
+func (n NoopOperator) CleanupResources(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	return nil
+}
+func (n NoopOperator) PlanAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) (*accessv1alpha1.RBACPlan, error) {
+	return &accessv1alpha1.RBACPlan{}, nil
+}
+func (n NoopOperator) DetectDrift(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+	granted bool,
+) ([]controller.Drift, error) {
+	return nil, nil
+}
+func (n NoopOperator) RepairDrift(ctx context.Context, bg *accessv1alpha1.Breakglass, drift []controller.Drift) error {
+	return nil
+}
+func (n NoopOperator) SyncAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) ([]controller.Drift, error) {
+	return nil, nil
+}
+func (n NoopOperator) MigrateLegacyNames(ctx context.Context, bg *accessv1alpha1.Breakglass) (bool, error) {
+	return false, nil
+}
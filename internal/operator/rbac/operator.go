@@ -19,22 +19,34 @@ package rbac
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/clock"
+	"github.com/cloud-nimbus/firedoor/internal/config"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
 	"github.com/cloud-nimbus/firedoor/internal/errors"
 
-	cronv3 "github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Operator implements the controller.BreakglassOperator interface.
 type Operator struct {
-	client client.Client
+	client              client.Client
+	reader              client.Reader
+	ownerIndex          bool
+	privilegeEscalation bool
+	admissionWebhook    bool
+	restrictToNamespace bool
+	denylist            config.DenylistConfig
+	clock               controller.Clock
 }
 
 // Compile-time assertion: ensure Operator implements controller.BreakglassOperator
@@ -42,32 +54,46 @@ var _ interface {
 	GrantAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) error
 	RevokeAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) error
 	ValidateAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) error
+	PlanAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) (*accessv1alpha1.RBACPlan, error)
+	DetectDrift(ctx context.Context, bg *accessv1alpha1.Breakglass, granted bool) ([]controller.Drift, error)
+	RepairDrift(ctx context.Context, bg *accessv1alpha1.Breakglass, drift []controller.Drift) error
+	SyncAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) ([]controller.Drift, error)
+	MigrateLegacyNames(ctx context.Context, bg *accessv1alpha1.Breakglass) (bool, error)
 } = (*Operator)(nil)
 
-func New(c client.Client) *Operator {
-	return &Operator{client: c}
+func New(c client.Client, opts ...Option) *Operator {
+	o := &Operator{client: c, reader: c, clock: clock.SimpleClock{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
 }
 
 // GrantAccess creates the necessary RBAC resources for a breakglass request
 func (o *Operator) GrantAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
 	log := ctrl.LoggerFrom(ctx)
-	uidSuffix := string(bg.UID)[:8] // Use first 8 chars of UID for brevity
 	labels := o.getBreakglassLabels(bg)
 	createdResources := make([]string, 0)
 
-	// Grant ClusterRoles
-	clusterRoleResources, err := o.createClusterRoleBindings(ctx, bg, uidSuffix, labels)
-	if err != nil {
+	if err := o.preflight(ctx, bg); err != nil {
 		return err
 	}
-	createdResources = append(createdResources, clusterRoleResources...)
 
-	// Grant ad-hoc Policy rules as Roles/RoleBindings
-	policyResources, err := o.createPolicyResources(ctx, bg, uidSuffix, labels)
+	resources, err := o.desiredResources(ctx, bg, labels)
 	if err != nil {
 		return err
 	}
-	createdResources = append(createdResources, policyResources...)
+	for _, r := range resources {
+		// Skip if already created
+		if contains(bg.Status.CreatedResources, r.ref) {
+			continue
+		}
+		if err := o.applyResourceWithTimeout(ctx, r.obj, describeResource(r.obj)); err != nil {
+			return err
+		}
+		createdResources = append(createdResources, r.ref)
+		log.Info("created "+strings.ToLower(kindOf(r.obj)), "namespace", r.obj.GetNamespace(), "name", r.obj.GetName())
+	}
 
 	// Update status with created resources
 	if len(createdResources) > 0 {
@@ -78,137 +104,302 @@ func (o *Operator) GrantAccess(ctx context.Context, bg *accessv1alpha1.Breakglas
 	return nil
 }
 
+// Labels set on every RBAC object created for a breakglass request
+const (
+	LabelName      = "breakglass/name"
+	LabelNamespace = "breakglass/namespace"
+	LabelUID       = "breakglass/uid"
+)
+
 // getBreakglassLabels returns the standard labels for breakglass resources
 func (o *Operator) getBreakglassLabels(bg *accessv1alpha1.Breakglass) map[string]string {
 	return map[string]string{
-		"breakglass/name":      bg.Name,
-		"breakglass/namespace": bg.Namespace,
-		"breakglass/uid":       string(bg.UID),
-		"breakglass-uid":       string(bg.UID), // Additional unique label
+		LabelName:        bg.Name,
+		LabelNamespace:   bg.Namespace,
+		LabelUID:         string(bg.UID),
+		"breakglass-uid": string(bg.UID), // Additional unique label
 	}
 }
 
-// createClusterRoleBindings creates ClusterRoleBindings for the specified cluster roles
-func (o *Operator) createClusterRoleBindings(
+// desiredResource is an RBAC object GrantAccess creates, with the reference it is tracked
+// under in status.createdResources.
+type desiredResource struct {
+	ref string
+	obj client.Object
+}
+
+// desiredResources returns every RBAC object the request grants, in creation order: bindings for
+// the requested ClusterRoles first, then the Roles and bindings for each ad-hoc policy.
+func (o *Operator) desiredResources(
 	ctx context.Context,
 	bg *accessv1alpha1.Breakglass,
-	uidSuffix string,
 	labels map[string]string,
-) ([]string, error) {
-	createdResources := make([]string, 0)
-	log := ctrl.LoggerFrom(ctx)
+) ([]desiredResource, error) {
+	resources, err := o.clusterRoleBindingResources(ctx, bg, labels)
+	if err != nil {
+		return nil, err
+	}
+	return append(resources, policyResources(bg, labels)...), nil
+}
+
+// clusterRoleBindingResources returns ClusterRoleBindings for the specified cluster roles.
+// When a ClusterRoleScope is set the roles are bound per namespace instead.
+func (o *Operator) clusterRoleBindingResources(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+	labels map[string]string,
+) ([]desiredResource, error) {
+	if bg.Spec.ClusterRoleScope != nil {
+		return o.scopedRoleBindingResources(ctx, bg, labels)
+	}
 
+	resources := make([]desiredResource, 0, len(bg.Spec.ClusterRoles))
 	for _, cr := range bg.Spec.ClusterRoles {
-		crbName := fmt.Sprintf("breakglass-%s-clusterrolebinding-%s", uidSuffix, cr)
+		crbName := resourceName(bg, "clusterrolebinding-"+cr)
+		resources = append(resources, desiredResource{
+			ref: crbName,
+			obj: &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   crbName,
+					Labels: labels,
+				},
+				Subjects: bg.Spec.Subjects,
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     cr,
+				},
+			},
+		})
+	}
+	return resources, nil
+}
 
-		// Skip if already created
-		if contains(bg.Status.CreatedResources, crbName) {
-			continue
+// scopedRoleBindingResources binds the specified cluster roles through a RoleBinding in every scoped namespace.
+// Bindings are tracked as namespace/name since the same name is used in each namespace.
+func (o *Operator) scopedRoleBindingResources(
+	ctx context.Context,
+	bg *accessv1alpha1.Breakglass,
+	labels map[string]string,
+) ([]desiredResource, error) {
+	namespaces, err := o.resolveNamespaces(ctx, bg.Spec.ClusterRoleScope)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]desiredResource, 0, len(bg.Spec.ClusterRoles)*len(namespaces))
+	for _, cr := range bg.Spec.ClusterRoles {
+		rbName := resourceName(bg, "rolebinding-"+cr)
+		for _, ns := range namespaces {
+			resources = append(resources, desiredResource{
+				ref: ns + "/" + rbName,
+				obj: &rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      rbName,
+						Namespace: ns,
+						Labels:    labels,
+					},
+					Subjects: bg.Spec.Subjects,
+					RoleRef: rbacv1.RoleRef{
+						APIGroup: "rbac.authorization.k8s.io",
+						Kind:     "ClusterRole",
+						Name:     cr,
+					},
+				},
+			})
 		}
+	}
+	return resources, nil
+}
 
-		crb := &rbacv1.ClusterRoleBinding{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:   crbName,
-				Labels: labels,
-			},
-			Subjects: bg.Spec.Subjects,
-			RoleRef: rbacv1.RoleRef{
-				APIGroup: "rbac.authorization.k8s.io",
-				Kind:     "ClusterRole",
-				Name:     cr,
-			},
+// resolveNamespaces returns the sorted union of the listed namespaces and those matching the selector
+func (o *Operator) resolveNamespaces(ctx context.Context, scope *accessv1alpha1.NamespaceScope) ([]string, error) {
+	namespaces := make(map[string]struct{}, len(scope.Namespaces))
+	for _, ns := range scope.Namespaces {
+		namespaces[ns] = struct{}{}
+	}
+
+	if scope.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(scope.Selector)
+		if err != nil {
+			return nil, errors.NewPermanentRBACError(
+				"resolving", "namespace selector", accessv1alpha1.ReasonInvalidRequest, err)
 		}
 
-		if err := o.createResourceWithTimeout(ctx, crb, "ClusterRoleBinding "+crbName); err != nil {
-			return nil, err
+		childCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		var nsList corev1.NamespaceList
+		if err := o.client.List(childCtx, &nsList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, errors.NewRetryableRBACError("listing", "Namespaces", accessv1alpha1.ReasonRBACTimeout, err)
+		}
+		for i := range nsList.Items {
+			namespaces[nsList.Items[i].Name] = struct{}{}
 		}
-		createdResources = append(createdResources, crbName)
-		log.Info("created clusterrolebinding", "name", crbName)
 	}
 
-	return createdResources, nil
+	resolved := make([]string, 0, len(namespaces))
+	for ns := range namespaces {
+		resolved = append(resolved, ns)
+	}
+	sort.Strings(resolved)
+	return resolved, nil
 }
 
-// createPolicyResources creates Roles and RoleBindings for the specified policies
-func (o *Operator) createPolicyResources(
-	ctx context.Context,
-	bg *accessv1alpha1.Breakglass,
-	uidSuffix string,
-	labels map[string]string,
-) ([]string, error) {
-	createdResources := make([]string, 0)
-	log := ctrl.LoggerFrom(ctx)
+// policyResources returns Roles and RoleBindings for the specified policies.
+// Policies without a namespace are cluster-scoped and get a ClusterRole and ClusterRoleBinding instead.
+func policyResources(bg *accessv1alpha1.Breakglass, labels map[string]string) []desiredResource {
+	resources := make([]desiredResource, 0, 2*len(bg.Spec.Policy))
 
 	for i, policy := range bg.Spec.Policy {
-		roleName := fmt.Sprintf("breakglass-%s-role-%d", uidSuffix, i)
-		rbName := fmt.Sprintf("breakglass-%s-rolebinding-%d", uidSuffix, i)
-
-		// Skip if already created
-		if contains(bg.Status.CreatedResources, roleName) && contains(bg.Status.CreatedResources, rbName) {
+		if policy.Namespace == "" {
+			crName := resourceName(bg, fmt.Sprintf("policy-clusterrole-%d", i))
+			crbName := resourceName(bg, fmt.Sprintf("policy-clusterrolebinding-%d", i))
+			resources = append(resources,
+				desiredResource{
+					ref: crName,
+					obj: &rbacv1.ClusterRole{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   crName,
+							Labels: labels,
+						},
+						Rules: policy.Rules,
+					},
+				},
+				desiredResource{
+					ref: crbName,
+					obj: &rbacv1.ClusterRoleBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   crbName,
+							Labels: labels,
+						},
+						Subjects: bg.Spec.Subjects,
+						RoleRef: rbacv1.RoleRef{
+							APIGroup: "rbac.authorization.k8s.io",
+							Kind:     "ClusterRole",
+							Name:     crName,
+						},
+					},
+				},
+			)
 			continue
 		}
 
-		// Create Role
-		role := &rbacv1.Role{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      roleName,
-				Namespace: policy.Namespace,
-				Labels:    labels,
-			},
-			Rules: policy.Rules,
-		}
-		if err := o.createResourceWithTimeout(
-			ctx,
-			role,
-			fmt.Sprintf("Role %s in %s", roleName, policy.Namespace),
-		); err != nil {
-			return nil, err
-		}
-		createdResources = append(createdResources, roleName)
-		log.Info("created role", "namespace", policy.Namespace, "name", roleName)
-
-		// Create RoleBinding
-		rb := &rbacv1.RoleBinding{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      rbName,
-				Namespace: policy.Namespace,
-				Labels:    labels,
+		roleName := resourceName(bg, fmt.Sprintf("policy-role-%d", i))
+		rbName := resourceName(bg, fmt.Sprintf("policy-rolebinding-%d", i))
+		resources = append(resources,
+			desiredResource{
+				ref: roleName,
+				obj: &rbacv1.Role{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      roleName,
+						Namespace: policy.Namespace,
+						Labels:    labels,
+					},
+					Rules: policy.Rules,
+				},
 			},
-			Subjects: bg.Spec.Subjects,
-			RoleRef: rbacv1.RoleRef{
-				APIGroup: "rbac.authorization.k8s.io",
-				Kind:     "Role",
-				Name:     roleName,
+			desiredResource{
+				ref: rbName,
+				obj: &rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      rbName,
+						Namespace: policy.Namespace,
+						Labels:    labels,
+					},
+					Subjects: bg.Spec.Subjects,
+					RoleRef: rbacv1.RoleRef{
+						APIGroup: "rbac.authorization.k8s.io",
+						Kind:     "Role",
+						Name:     roleName,
+					},
+				},
 			},
-		}
-		if err := o.createResourceWithTimeout(
-			ctx,
-			rb,
-			fmt.Sprintf("RoleBinding %s in %s", rbName, policy.Namespace),
-		); err != nil {
-			return nil, err
-		}
-		createdResources = append(createdResources, rbName)
-		log.Info("created rolebinding", "namespace", policy.Namespace, "name", rbName)
+		)
+	}
+
+	return resources
+}
+
+// kindOf returns the kind of an RBAC object built by the operator, which carries no TypeMeta.
+func kindOf(obj client.Object) string {
+	switch obj.(type) {
+	case *rbacv1.Role:
+		return accessv1alpha1.KindRole
+	case *rbacv1.RoleBinding:
+		return accessv1alpha1.KindRoleBinding
+	case *rbacv1.ClusterRole:
+		return accessv1alpha1.KindClusterRole
+	case *rbacv1.ClusterRoleBinding:
+		return accessv1alpha1.KindClusterRoleBinding
 	}
+	return obj.GetObjectKind().GroupVersionKind().Kind
+}
 
-	return createdResources, nil
+// describeResource returns the resource description used in RBAC errors, e.g. "Role name in namespace".
+func describeResource(obj client.Object) string {
+	if ns := obj.GetNamespace(); ns != "" {
+		return fmt.Sprintf("%s %s in %s", kindOf(obj), obj.GetName(), ns)
+	}
+	return kindOf(obj) + " " + obj.GetName()
 }
 
-// createResourceWithTimeout creates a resource with a 30-second timeout and proper error handling
-func (o *Operator) createResourceWithTimeout(ctx context.Context, obj client.Object, resourceDesc string) error {
+// FieldManager is the field manager RBAC objects are server-side applied with
+const FieldManager = "firedoor"
+
+// getOwnedResourceWithTimeout reads an object with a name firedoor generates. Labelled objects are served by
+// the client given to New, which may be a cache restricted by CacheByObject; only when it does not hold the
+// object is the reader asked, so a same-named object without the labels of a request is still found.
+func (o *Operator) getOwnedResourceWithTimeout(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 	childCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	if err := o.client.Create(childCtx, obj); err != nil {
-		if errors.IsAlreadyExistsError(err) {
-			// Resource already exists, this is not an error
-			return nil
+	err := o.client.Get(childCtx, key, obj)
+	if !errors.IsNotFoundError(err) {
+		return err
+	}
+	return o.reader.Get(childCtx, key, obj)
+}
+
+// applyResourceWithTimeout creates or updates obj through server-side apply. An existing object is only
+// taken over if it carries the breakglass UID of obj, so another request's object is never reused.
+func (o *Operator) applyResourceWithTimeout(ctx context.Context, obj client.Object, resourceDesc string) error {
+	existing := newRBACObject(kindOf(obj))
+	if err := o.getOwnedResourceWithTimeout(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !errors.IsNotFoundError(err) {
+			return errors.NewRetryableRBACError("reading", resourceDesc, accessv1alpha1.ReasonRBACTimeout, err)
 		}
+	} else if err := checkOwnership(existing, obj, "applying", resourceDesc); err != nil {
+		return err
+	}
+	return o.serverSideApply(ctx, obj, resourceDesc)
+}
+
+// serverSideApply applies obj as FieldManager with a 30-second timeout. Conflicting fields set by other
+// managers, such as subjects added by hand, are taken over.
+func (o *Operator) serverSideApply(ctx context.Context, obj client.Object, resourceDesc string) error {
+	childCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Apply patches are sent as the object itself, which must name its kind
+	obj.GetObjectKind().SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind(kindOf(obj)))
+	err := o.client.Patch(childCtx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+	if err != nil {
 		if errors.IsRetryableK8sError(err) {
-			return errors.NewRetryableRBACError("creating", resourceDesc, accessv1alpha1.ReasonRBACTimeout, err)
+			return errors.NewRetryableRBACError("applying", resourceDesc, accessv1alpha1.ReasonRBACTimeout, err)
 		}
-		return errors.NewPermanentRBACError("creating", resourceDesc, accessv1alpha1.ReasonRBACForbidden, err)
+		return errors.NewPermanentRBACError("applying", resourceDesc, accessv1alpha1.ReasonRBACForbidden, err)
+	}
+	return nil
+}
+
+// checkOwnership returns nil if existing carries the breakglass UID label of want, and a permanent
+// ResourceConflict error otherwise.
+func checkOwnership(existing, want client.Object, operation, resourceDesc string) error {
+	uid := want.GetLabels()[LabelUID]
+	if owner := existing.GetLabels()[LabelUID]; uid == "" || owner != uid {
+		return errors.NewPermanentRBACError(operation, resourceDesc, accessv1alpha1.ReasonResourceConflict,
+			fmt.Errorf("exists without the labels of this request (owner uid %q)", owner))
 	}
 	return nil
 }
@@ -223,7 +414,7 @@ func (o *Operator) updateStatusWithCreatedResources(
 	childCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	if err := o.client.Status().Update(childCtx, bg); err != nil {
+	if err := controller.PatchStatus(childCtx, o.client, bg); err != nil {
 		if errors.IsRetryableK8sError(err) {
 			return errors.NewRetryableRBACError("updating", "Breakglass status", accessv1alpha1.ReasonRBACTimeout, err)
 		}
@@ -248,43 +439,41 @@ func (o *Operator) RevokeAccess(ctx context.Context, bg *accessv1alpha1.Breakgla
 	return o.CleanupResources(ctx, bg)
 }
 
-// ValidateAccess validates if the breakglass request can be created
-func (o *Operator) ValidateAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
-	// Validate recurring schedule if cron is set
-	if bg.Spec.Schedule.Cron != "" {
-		// Parse the cron expression to validate it
-		parser := cronv3.NewParser(cronv3.Minute | cronv3.Hour | cronv3.Dom | cronv3.Month | cronv3.Dow)
-		if _, err := parser.Parse(bg.Spec.Schedule.Cron); err != nil {
-			return fmt.Errorf("invalid cron schedule '%s': %w", bg.Spec.Schedule.Cron, err)
-		}
-	}
-	return nil
-}
-
-// CleanupResources deletes all RBAC resources associated with the breakglass request
+// CleanupResources deletes all RBAC resources associated with the breakglass request.
+// The objects are found through the client first and then through the reader, which sees objects created
+// moments ago that a cache may not have caught up with, so nothing labelled for the request is left behind.
 func (o *Operator) CleanupResources(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
 	labels := o.getBreakglassLabels(bg)
 
-	// Delete resources in order: RoleBindings, Roles, ClusterRoleBindings
-	if err := o.deleteRoleBindings(ctx, labels); err != nil {
-		return err
-	}
+	for _, list := range []listFunc{o.listResourcesWithTimeout, o.readResourcesWithTimeout} {
+		// Delete resources in order: RoleBindings, Roles, ClusterRoleBindings, ClusterRoles
+		if err := o.deleteRoleBindings(ctx, labels, list); err != nil {
+			return err
+		}
 
-	if err := o.deleteRoles(ctx, labels); err != nil {
-		return err
-	}
+		if err := o.deleteRoles(ctx, labels, list); err != nil {
+			return err
+		}
 
-	if err := o.deleteClusterRoleBindings(ctx, labels); err != nil {
-		return err
+		if err := o.deleteClusterRoleBindings(ctx, labels, list); err != nil {
+			return err
+		}
+
+		if err := o.deleteClusterRoles(ctx, labels, list); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// listFunc lists the objects carrying labels into list.
+type listFunc func(ctx context.Context, list client.ObjectList, labels map[string]string) error
+
 // deleteRoleBindings deletes all RoleBindings with the specified labels
-func (o *Operator) deleteRoleBindings(ctx context.Context, labels map[string]string) error {
+func (o *Operator) deleteRoleBindings(ctx context.Context, labels map[string]string, list listFunc) error {
 	var rbList rbacv1.RoleBindingList
-	if err := o.listResourcesWithTimeout(ctx, &rbList, labels); err != nil {
+	if err := list(ctx, &rbList, labels); err != nil {
 		return errors.NewRetryableRBACError("listing", "RoleBindings", accessv1alpha1.ReasonRBACTimeout, err)
 	}
 
@@ -304,9 +493,9 @@ func (o *Operator) deleteRoleBindings(ctx context.Context, labels map[string]str
 }
 
 // deleteRoles deletes all Roles with the specified labels
-func (o *Operator) deleteRoles(ctx context.Context, labels map[string]string) error {
+func (o *Operator) deleteRoles(ctx context.Context, labels map[string]string, list listFunc) error {
 	var roleList rbacv1.RoleList
-	if err := o.listResourcesWithTimeout(ctx, &roleList, labels); err != nil {
+	if err := list(ctx, &roleList, labels); err != nil {
 		return errors.NewRetryableRBACError("listing", "Roles", accessv1alpha1.ReasonRBACTimeout, err)
 	}
 
@@ -326,9 +515,9 @@ func (o *Operator) deleteRoles(ctx context.Context, labels map[string]string) er
 }
 
 // deleteClusterRoleBindings deletes all ClusterRoleBindings with the specified labels
-func (o *Operator) deleteClusterRoleBindings(ctx context.Context, labels map[string]string) error {
+func (o *Operator) deleteClusterRoleBindings(ctx context.Context, labels map[string]string, list listFunc) error {
 	var crbList rbacv1.ClusterRoleBindingList
-	if err := o.listResourcesWithTimeout(ctx, &crbList, labels); err != nil {
+	if err := list(ctx, &crbList, labels); err != nil {
 		return errors.NewRetryableRBACError("listing", "ClusterRoleBindings", accessv1alpha1.ReasonRBACTimeout, err)
 	}
 
@@ -347,7 +536,32 @@ func (o *Operator) deleteClusterRoleBindings(ctx context.Context, labels map[str
 	return nil
 }
 
-// listResourcesWithTimeout lists resources with a 30-second timeout
+// deleteClusterRoles deletes all ClusterRoles with the specified labels.
+// Only ClusterRoles created for cluster-scoped policies carry breakglass labels; granted
+// ClusterRoles from spec.clusterRoles are never touched.
+func (o *Operator) deleteClusterRoles(ctx context.Context, labels map[string]string, list listFunc) error {
+	var crList rbacv1.ClusterRoleList
+	if err := list(ctx, &crList, labels); err != nil {
+		return errors.NewRetryableRBACError("listing", "ClusterRoles", accessv1alpha1.ReasonRBACTimeout, err)
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	for i := range crList.Items {
+		cr := &crList.Items[i]
+		if err := o.deleteResourceWithTimeout(
+			ctx,
+			cr,
+			"ClusterRole "+cr.Name,
+		); err != nil {
+			return err
+		}
+		log.Info("deleted clusterrole", "name", cr.Name)
+	}
+	return nil
+}
+
+// listResourcesWithTimeout lists resources with a 30-second timeout. With the owner index only the
+// objects of the request's UID are looked at.
 func (o *Operator) listResourcesWithTimeout(
 	ctx context.Context,
 	list client.ObjectList,
@@ -357,9 +571,25 @@ func (o *Operator) listResourcesWithTimeout(
 	defer cancel()
 
 	listOpts := []client.ListOption{client.MatchingLabels(labels)}
+	if o.ownerIndex {
+		listOpts = append(listOpts, client.MatchingFields{OwnerUIDField: labels[LabelUID]})
+	}
 	return o.client.List(childCtx, list, listOpts...)
 }
 
+// readResourcesWithTimeout lists resources through the reader with a 30-second timeout, bypassing the
+// cache and the owner index.
+func (o *Operator) readResourcesWithTimeout(
+	ctx context.Context,
+	list client.ObjectList,
+	labels map[string]string,
+) error {
+	childCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return o.reader.List(childCtx, list, client.MatchingLabels(labels))
+}
+
 // deleteResourceWithTimeout deletes a resource with a 30-second timeout and proper error handling
 func (o *Operator) deleteResourceWithTimeout(
 	ctx context.Context,
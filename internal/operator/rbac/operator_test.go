@@ -0,0 +1,1129 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/api/v1beta1"
+	"github.com/cloud-nimbus/firedoor/internal/config"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	"github.com/cloud-nimbus/firedoor/internal/controller/mocks"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+func newTestOperator(t *testing.T, objs ...client.Object) (*Operator, client.Client) {
+	t.Helper()
+	c := newTestClient(t, interceptor.Funcs{}, objs...)
+	return New(c, WithPrivilegeEscalation(true)), c
+}
+
+func newTestClient(t *testing.T, funcs interceptor.Funcs, objs ...client.Object) client.Client {
+	t.Helper()
+	if funcs.Patch == nil {
+		funcs.Patch = emulateApply
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, authorizationv1.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&accessv1alpha1.Breakglass{}).
+		WithInterceptorFuncs(funcs).
+		WithObjects(objs...).
+		Build()
+}
+
+// emulateApply stands in for server-side apply, which the fake client does not support: the applied
+// object is created, or replaces the stored one.
+func emulateApply(
+	ctx context.Context,
+	c client.WithWatch,
+	obj client.Object,
+	patch client.Patch,
+	opts ...client.PatchOption,
+) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+	existing := obj.DeepCopyObject().(client.Object)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+func TestOperator_ClusterScopedPolicy(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			Policy: []accessv1alpha1.Policy{
+				{Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get"}}}},
+				{Namespace: "apps", Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"},
+				}}},
+			},
+		},
+	}
+	op, c := newTestOperator(t, bg)
+	ctx := context.Background()
+
+	require.NoError(t, op.GrantAccess(ctx, bg))
+	assert.ElementsMatch(t, []string{
+		resourceName(bg, "policy-clusterrole-0"),
+		resourceName(bg, "policy-clusterrolebinding-0"),
+		resourceName(bg, "policy-role-1"),
+		resourceName(bg, "policy-rolebinding-1"),
+	}, bg.Status.CreatedResources)
+
+	var cr rbacv1.ClusterRole
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: resourceName(bg, "policy-clusterrole-0")}, &cr))
+	assert.Equal(t, bg.Spec.Policy[0].Rules, cr.Rules)
+	assert.Equal(t, "bg", cr.Labels["breakglass/name"])
+
+	var crb rbacv1.ClusterRoleBinding
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: resourceName(bg, "policy-clusterrolebinding-0")}, &crb))
+	assert.Equal(t, "ClusterRole", crb.RoleRef.Kind)
+	assert.Equal(t, cr.Name, crb.RoleRef.Name)
+	assert.Equal(t, bg.Spec.Subjects, crb.Subjects)
+
+	var role rbacv1.Role
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "apps", Name: resourceName(bg, "policy-role-1")}, &role))
+
+	require.NoError(t, op.CleanupResources(ctx, bg))
+
+	var crs rbacv1.ClusterRoleList
+	require.NoError(t, c.List(ctx, &crs))
+	assert.Empty(t, crs.Items)
+	var crbs rbacv1.ClusterRoleBindingList
+	require.NoError(t, c.List(ctx, &crbs))
+	assert.Empty(t, crbs.Items)
+	var roles rbacv1.RoleList
+	require.NoError(t, c.List(ctx, &roles))
+	assert.Empty(t, roles.Items)
+}
+
+func TestOperator_ScopedClusterRoles(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			ClusterRoles: []string{"edit"},
+			ClusterRoleScope: &accessv1alpha1.NamespaceScope{
+				Namespaces: []string{"payments"},
+				Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			},
+		},
+	}
+	labelled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: "payments-worker", Labels: map[string]string{"team": "payments"},
+	}}
+	other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "billing"}}
+	op, c := newTestOperator(t, bg, labelled, other)
+	ctx := context.Background()
+
+	require.NoError(t, op.GrantAccess(ctx, bg))
+	assert.Equal(t, []string{
+		"payments/" + resourceName(bg, "rolebinding-edit"),
+		"payments-worker/" + resourceName(bg, "rolebinding-edit"),
+	}, bg.Status.CreatedResources)
+
+	var rb rbacv1.RoleBinding
+	key := client.ObjectKey{Namespace: "payments-worker", Name: resourceName(bg, "rolebinding-edit")}
+	require.NoError(t, c.Get(ctx, key, &rb))
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "edit"}, rb.RoleRef)
+
+	var crbs rbacv1.ClusterRoleBindingList
+	require.NoError(t, c.List(ctx, &crbs))
+	assert.Empty(t, crbs.Items, "scoped cluster roles are not bound cluster-wide")
+
+	require.NoError(t, op.CleanupResources(ctx, bg))
+	var rbs rbacv1.RoleBindingList
+	require.NoError(t, c.List(ctx, &rbs))
+	assert.Empty(t, rbs.Items)
+}
+
+func TestOperator_CreatedResourcesConvert(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			// A name long enough to be cut still converts by the kind it starts with
+			ClusterRoles: []string{"view", "aggregate-to-a-rather-long-cluster-role-name"},
+			Policy: []accessv1alpha1.Policy{
+				{Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get"}}}},
+				{Namespace: "apps", Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"},
+				}}},
+			},
+		},
+	}
+	op, c := newTestOperator(t, bg)
+	ctx := context.Background()
+	require.NoError(t, op.GrantAccess(ctx, bg))
+	require.Len(t, bg.Status.CreatedResources, 6)
+
+	spoke := &v1beta1.Breakglass{}
+	require.NoError(t, spoke.ConvertFrom(bg.DeepCopy()))
+	require.Len(t, spoke.Status.CreatedResources, len(bg.Status.CreatedResources))
+	for _, ref := range spoke.Status.CreatedResources {
+		var obj client.Object
+		switch ref.Kind {
+		case v1beta1.KindClusterRole:
+			obj = &rbacv1.ClusterRole{}
+		case v1beta1.KindClusterRoleBinding:
+			obj = &rbacv1.ClusterRoleBinding{}
+		case v1beta1.KindRole:
+			obj = &rbacv1.Role{}
+		case v1beta1.KindRoleBinding:
+			obj = &rbacv1.RoleBinding{}
+		default:
+			t.Fatalf("%s converted without a kind", ref.Name)
+		}
+		assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, obj),
+			"%s %s/%s", ref.Kind, ref.Namespace, ref.Name)
+	}
+
+	back := &accessv1alpha1.Breakglass{}
+	require.NoError(t, spoke.ConvertTo(back))
+	assert.Equal(t, bg.Status.CreatedResources, back.Status.CreatedResources)
+}
+
+func TestOperator_RequesterPermissions(t *testing.T) {
+	edit := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "edit"},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "delete"}},
+			{APIGroups: []string{"apps"}, Resources: []string{"deployments/scale"}, Verbs: []string{"update"}},
+		},
+	}
+	// alice may do anything except delete and anything in apps
+	allow := func(spec authorizationv1.SubjectAccessReviewSpec) bool {
+		if spec.User != "alice" || spec.ResourceAttributes == nil {
+			return false
+		}
+		return spec.ResourceAttributes.Verb != "delete" && spec.ResourceAttributes.Group != "apps"
+	}
+	reviews := 0
+	funcs := interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if sar, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+				reviews++
+				sar.Status.Allowed = allow(sar.Spec)
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}
+
+	tests := []struct {
+		name        string
+		requester   string
+		clusterRole string
+		escalation  bool
+		noWebhook   bool
+		wantDenied  []string
+	}{
+		{
+			name:        "requester holds the permissions",
+			requester:   "alice",
+			clusterRole: "view",
+		},
+		{
+			name:        "requester lacks permissions",
+			requester:   "alice",
+			clusterRole: "edit",
+			wantDenied: []string{
+				`clusterRole "edit".rules[0]: cannot delete pods in namespace payments`,
+				`clusterRole "edit".rules[1]: cannot update deployments/scale.apps in namespace payments`,
+			},
+		},
+		{
+			name:        "escalation enabled skips the check",
+			requester:   "alice",
+			clusterRole: "edit",
+			escalation:  true,
+		},
+		{
+			name:        "unknown requester",
+			clusterRole: "view",
+			wantDenied:  []string{"requester identity is unknown; enable the admission webhook or privilege escalation"},
+		},
+		{
+			// without the webhook anyone can name a privileged requester in the annotations
+			name:        "forged requester without the webhook",
+			requester:   "alice",
+			clusterRole: "view",
+			noWebhook:   true,
+			wantDenied: []string{
+				"requester identity cannot be trusted without the admission webhook; enable it or privilege escalation",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "bg",
+					Namespace:   "default",
+					UID:         "0123456789abcdef",
+					Annotations: map[string]string{},
+				},
+				Spec: accessv1alpha1.BreakglassSpec{
+					Subjects:         []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "bob"}},
+					ClusterRoles:     []string{tt.clusterRole},
+					ClusterRoleScope: &accessv1alpha1.NamespaceScope{Namespaces: []string{"payments"}},
+				},
+			}
+			if tt.requester != "" {
+				bg.Annotations[accessv1alpha1.RequestedByAnnotation] = tt.requester
+			}
+			view := &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "view"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}},
+			}
+			op := New(newTestClient(t, funcs, bg, edit, view),
+				WithPrivilegeEscalation(tt.escalation), WithAdmissionWebhook(!tt.noWebhook))
+			reviews = 0
+
+			err := op.GrantAccess(context.Background(), bg)
+			if len(tt.wantDenied) == 0 {
+				require.NoError(t, err)
+				assert.NotEmpty(t, bg.Status.CreatedResources)
+				return
+			}
+			var escErr *internalerrors.EscalationError
+			require.ErrorAs(t, err, &escErr)
+			assert.Equal(t, tt.wantDenied, escErr.Denied)
+			assert.Empty(t, bg.Status.CreatedResources, "nothing is granted")
+			if tt.noWebhook {
+				assert.Zero(t, reviews, "the forged requester is never reviewed")
+			}
+		})
+	}
+}
+
+func TestOperator_Denylist(t *testing.T) {
+	denylist := config.NewDefaultConfig().Denylist
+	protected := &accessv1alpha1.BreakglassPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "protected"},
+		Spec:       accessv1alpha1.BreakglassPolicySpec{AllowProtected: true},
+	}
+	guarded := &accessv1alpha1.BreakglassPolicy{ObjectMeta: metav1.ObjectMeta{Name: "guarded"}}
+
+	tests := []struct {
+		name        string
+		admittedBy  string
+		wantRefused bool
+	}{
+		{name: "no admitting policy", wantRefused: true},
+		{name: "admitting policy keeps the denylist", admittedBy: "guarded", wantRefused: true},
+		{name: "admitting policy allows protected access", admittedBy: "protected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+				Spec: accessv1alpha1.BreakglassSpec{
+					Subjects:     []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:masters"}},
+					ClusterRoles: []string{"cluster-admin"},
+				},
+				Status: accessv1alpha1.BreakglassStatus{AdmittedByPolicy: tt.admittedBy},
+			}
+			c := newTestClient(t, interceptor.Funcs{}, bg, protected, guarded)
+			op := New(c, WithPrivilegeEscalation(true), WithDenylist(denylist))
+
+			err := op.GrantAccess(context.Background(), bg)
+			if !tt.wantRefused {
+				require.NoError(t, err)
+				return
+			}
+			var denyErr *internalerrors.DenylistError
+			require.ErrorAs(t, err, &denyErr)
+			assert.Equal(t, []string{
+				`clusterRole "cluster-admin" is protected`,
+				`subjects[0]: Group "system:masters" is protected`,
+			}, denyErr.Reasons)
+			assert.Empty(t, bg.Status.CreatedResources, "nothing is granted")
+		})
+	}
+}
+
+func TestOperator_DenylistWildcardClusterRole(t *testing.T) {
+	godMode := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "god-mode"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+	}
+
+	for _, allow := range []bool{false, true} {
+		t.Run(fmt.Sprintf("allowWildcardRules=%t", allow), func(t *testing.T) {
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+				Spec: accessv1alpha1.BreakglassSpec{
+					Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "bob"}},
+					ClusterRoles: []string{"god-mode"},
+				},
+			}
+			denylist := config.NewDefaultConfig().Denylist
+			denylist.AllowWildcardRules = allow
+			op := New(newTestClient(t, interceptor.Funcs{}, bg, godMode),
+				WithPrivilegeEscalation(true), WithDenylist(denylist))
+
+			err := op.GrantAccess(context.Background(), bg)
+			if allow {
+				require.NoError(t, err)
+				return
+			}
+			var denyErr *internalerrors.DenylistError
+			require.ErrorAs(t, err, &denyErr)
+			assert.Equal(t, []string{`clusterRole "god-mode".rules[0] grants all verbs on all resources`},
+				denyErr.Reasons)
+			assert.Empty(t, bg.Status.CreatedResources, "nothing is granted")
+		})
+	}
+}
+
+func TestOperator_NamespaceRestriction(t *testing.T) {
+	crossNamespace := &accessv1alpha1.BreakglassPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cross-namespace"},
+		Spec:       accessv1alpha1.BreakglassPolicySpec{AllowedTargetNamespaces: []string{"kube-system"}},
+	}
+
+	tests := []struct {
+		name        string
+		namespace   string
+		admittedBy  string
+		restrict    bool
+		wantRefused bool
+	}{
+		{name: "unrestricted", namespace: "team-a"},
+		{name: "restricted", namespace: "team-a", restrict: true, wantRefused: true},
+		{name: "restricted with allowance", namespace: "team-a", restrict: true, admittedBy: "cross-namespace"},
+		{name: "own namespace", namespace: "kube-system", restrict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: tt.namespace, UID: "0123456789abcdef"},
+				Spec: accessv1alpha1.BreakglassSpec{
+					Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+					Policy: []accessv1alpha1.Policy{{Namespace: "kube-system", Rules: []rbacv1.PolicyRule{{
+						APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"},
+					}}}},
+				},
+				Status: accessv1alpha1.BreakglassStatus{AdmittedByPolicy: tt.admittedBy},
+			}
+			c := newTestClient(t, interceptor.Funcs{}, bg, crossNamespace)
+			op := New(c, WithPrivilegeEscalation(true), WithNamespaceRestriction(tt.restrict))
+
+			err := op.GrantAccess(context.Background(), bg)
+			if !tt.wantRefused {
+				require.NoError(t, err)
+				assert.NotEmpty(t, bg.Status.CreatedResources)
+				return
+			}
+			var nsErr *internalerrors.NamespaceRestrictionError
+			require.ErrorAs(t, err, &nsErr)
+			assert.Equal(t, []string{`policy[0] namespace "kube-system" is outside namespace "team-a"`}, nsErr.Reasons)
+			assert.Empty(t, bg.Status.CreatedResources, "nothing is granted")
+		})
+	}
+}
+
+func TestOperator_PlanAccess(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			ClusterRoles: []string{"view", "missing"},
+			Policy: []accessv1alpha1.Policy{{Namespace: "apps", Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"},
+			}}}},
+		},
+	}
+	viewRules := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"list"}}}
+	view := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}, Rules: viewRules}
+	op, c := newTestOperator(t, bg, view)
+	ctx := context.Background()
+
+	plan, err := op.PlanAccess(ctx, bg)
+	require.NoError(t, err)
+
+	clusterRoleRef := func(name string) *rbacv1.RoleRef {
+		return &rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: name}
+	}
+	assert.Equal(t, []accessv1alpha1.PlannedObject{
+		{
+			Kind:     accessv1alpha1.KindClusterRoleBinding,
+			Name:     resourceName(bg, "clusterrolebinding-view"),
+			RoleRef:  clusterRoleRef("view"),
+			Subjects: bg.Spec.Subjects,
+			Rules:    viewRules,
+		},
+		{
+			Kind:     accessv1alpha1.KindClusterRoleBinding,
+			Name:     resourceName(bg, "clusterrolebinding-missing"),
+			RoleRef:  clusterRoleRef("missing"),
+			Subjects: bg.Spec.Subjects,
+		},
+		{
+			Kind:      accessv1alpha1.KindRole,
+			Namespace: "apps",
+			Name:      resourceName(bg, "policy-role-0"),
+			Rules:     bg.Spec.Policy[0].Rules,
+		},
+		{
+			Kind:      accessv1alpha1.KindRoleBinding,
+			Namespace: "apps",
+			Name:      resourceName(bg, "policy-rolebinding-0"),
+			RoleRef: &rbacv1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: resourceName(bg, "policy-role-0"),
+			},
+			Subjects: bg.Spec.Subjects,
+			Rules:    bg.Spec.Policy[0].Rules,
+		},
+	}, plan.Objects)
+	assert.Equal(t, []string{"ClusterRole missing does not exist"}, plan.Warnings)
+
+	// Nothing is created
+	var crbs rbacv1.ClusterRoleBindingList
+	require.NoError(t, c.List(ctx, &crbs))
+	assert.Empty(t, crbs.Items)
+	var roles rbacv1.RoleList
+	require.NoError(t, c.List(ctx, &roles))
+	assert.Empty(t, roles.Items)
+	assert.Empty(t, bg.Status.CreatedResources)
+}
+
+func TestOperator_PlanAccessReportsRefusals(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			ClusterRoles: []string{"cluster-admin"},
+		},
+	}
+	admin := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"}}
+	c := newTestClient(t, interceptor.Funcs{}, bg, admin)
+	op := New(c, WithPrivilegeEscalation(true), WithDenylist(config.NewDefaultConfig().Denylist))
+
+	plan, err := op.PlanAccess(context.Background(), bg)
+	require.NoError(t, err)
+	require.Len(t, plan.Objects, 1)
+	require.Len(t, plan.Warnings, 1)
+	assert.Contains(t, plan.Warnings[0], `clusterRole "cluster-admin" is protected`)
+}
+
+func TestOperator_ValidateAccess(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	past := metav1.NewTime(now.Add(-time.Hour))
+	view := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}}
+	prod := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+	deployer := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "default"}}
+
+	tests := []struct {
+		name        string
+		mutate      func(*accessv1alpha1.BreakglassSpec)
+		wantReasons []string
+	}{
+		{
+			name:   "valid request",
+			mutate: func(*accessv1alpha1.BreakglassSpec) {},
+		},
+		{
+			name: "missing references",
+			mutate: func(spec *accessv1alpha1.BreakglassSpec) {
+				spec.ClusterRoles = []string{"view", "edit"}
+				spec.ClusterRoleScope = &accessv1alpha1.NamespaceScope{Namespaces: []string{"prod", "staging"}}
+				spec.Subjects = append(spec.Subjects, rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "ghost"})
+			},
+			wantReasons: []string{
+				`clusterRole "edit" does not exist`,
+				`namespace "staging" does not exist`,
+				`serviceAccount "default/ghost" does not exist`,
+			},
+		},
+		{
+			name: "invalid schedule",
+			mutate: func(spec *accessv1alpha1.BreakglassSpec) {
+				spec.Schedule.Location = "Mars/Olympus_Mons"
+				spec.Schedule.Start = metav1.NewTime(now.Add(-2 * time.Hour))
+			},
+			wantReasons: []string{
+				`location "Mars/Olympus_Mons" is not a valid IANA time zone`,
+				"window ended at 2024-01-01T11:00:00Z",
+			},
+		},
+		{
+			name: "recurring schedule ended",
+			mutate: func(spec *accessv1alpha1.BreakglassSpec) {
+				spec.Schedule.Cron = "0 9 * * *"
+				spec.Schedule.Until = &past
+			},
+			wantReasons: []string{"schedule ended at 2024-01-01T11:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockClock := mocks.NewMockClock(mockCtrl)
+			mockClock.EXPECT().Now().Return(now).AnyTimes()
+
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default"},
+				Spec: accessv1alpha1.BreakglassSpec{
+					Subjects: []rbacv1.Subject{
+						{Kind: rbacv1.UserKind, Name: "alice"},
+						{Kind: rbacv1.ServiceAccountKind, Name: "deployer"},
+					},
+					ClusterRoles: []string{"view"},
+					Schedule: accessv1alpha1.ScheduleSpec{
+						Start:    metav1.NewTime(now.Add(time.Hour)),
+						Duration: metav1.Duration{Duration: time.Hour},
+					},
+				},
+			}
+			tt.mutate(&bg.Spec)
+			op := New(newTestClient(t, interceptor.Funcs{}, view, prod, deployer), WithClock(mockClock))
+
+			err := op.ValidateAccess(context.Background(), bg)
+			if len(tt.wantReasons) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *internalerrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantReasons, validationErr.Reasons)
+		})
+	}
+}
+
+func TestOperator_DriftRepair(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			ClusterRoles: []string{"view"},
+			Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			Policy: []accessv1alpha1.Policy{{Namespace: "apps", Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"},
+			}}}},
+		},
+	}
+	op, c := newTestOperator(t, bg)
+	ctx := context.Background()
+	require.NoError(t, op.GrantAccess(ctx, bg))
+
+	drift, err := op.DetectDrift(ctx, bg, true)
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+
+	// widen the Role, add a subject to the binding, delete the ClusterRoleBinding and plant a copy
+	var role rbacv1.Role
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "apps", Name: resourceName(bg, "policy-role-0")}, &role))
+	role.Rules[0].Verbs = []string{"*"}
+	require.NoError(t, c.Update(ctx, &role))
+	var rb rbacv1.RoleBinding
+	rbKey := client.ObjectKey{Namespace: "apps", Name: resourceName(bg, "policy-rolebinding-0")}
+	require.NoError(t, c.Get(ctx, rbKey, &rb))
+	rb.Subjects = append(rb.Subjects, rbacv1.Subject{Kind: rbacv1.UserKind, Name: "mallory"})
+	require.NoError(t, c.Update(ctx, &rb))
+	var crb rbacv1.ClusterRoleBinding
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: resourceName(bg, "clusterrolebinding-view")}, &crb))
+	require.NoError(t, c.Delete(ctx, &crb))
+	stray := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "copy", Labels: crb.Labels},
+		Subjects:   crb.Subjects,
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+	}
+	require.NoError(t, c.Create(ctx, stray))
+
+	drift, err = op.DetectDrift(ctx, bg, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"ClusterRoleBinding " + resourceName(bg, "clusterrolebinding-view") + " missing",
+		"ClusterRoleBinding copy stray",
+		"Role apps/" + resourceName(bg, "policy-role-0") + " modified",
+		"RoleBinding apps/" + resourceName(bg, "policy-rolebinding-0") + " modified",
+	}, driftStrings(drift))
+
+	require.NoError(t, op.RepairDrift(ctx, bg, drift))
+	drift, err = op.DetectDrift(ctx, bg, true)
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "apps", Name: resourceName(bg, "policy-role-0")}, &role))
+	assert.Equal(t, []string{"get"}, role.Rules[0].Verbs)
+
+	// outside an active window everything labelled is stray
+	drift, err = op.DetectDrift(ctx, bg, false)
+	require.NoError(t, err)
+	assert.Len(t, drift, 3)
+	require.NoError(t, op.RepairDrift(ctx, bg, drift))
+	var rbs rbacv1.RoleBindingList
+	require.NoError(t, c.List(ctx, &rbs))
+	assert.Empty(t, rbs.Items)
+}
+
+func TestOperator_DriftRepairStrippedLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		applied     bool
+		wantDeletes int
+	}{
+		{name: "applied by firedoor is relabelled", applied: true},
+		{name: "without proof of ownership it is replaced", wantDeletes: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := &accessv1alpha1.Breakglass{
+				ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+				Spec: accessv1alpha1.BreakglassSpec{
+					ClusterRoles: []string{"view"},
+					Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+				},
+			}
+			deletes := 0
+			c := newTestClient(t, interceptor.Funcs{
+				// the fake client does not track managed fields, so report the apply firedoor made
+				Get: func(
+					ctx context.Context,
+					c client.WithWatch,
+					key client.ObjectKey,
+					obj client.Object,
+					opts ...client.GetOption,
+				) error {
+					if err := c.Get(ctx, key, obj, opts...); err != nil {
+						return err
+					}
+					if _, ok := obj.(*rbacv1.ClusterRoleBinding); ok && tt.applied {
+						obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+							{Manager: FieldManager, Operation: metav1.ManagedFieldsOperationApply},
+						})
+					}
+					return nil
+				},
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					deletes++
+					return c.Delete(ctx, obj, opts...)
+				},
+			}, bg)
+			op := New(c, WithPrivilegeEscalation(true))
+			ctx := context.Background()
+			require.NoError(t, op.GrantAccess(ctx, bg))
+
+			var crb rbacv1.ClusterRoleBinding
+			key := client.ObjectKey{Name: resourceName(bg, "clusterrolebinding-view")}
+			require.NoError(t, c.Get(ctx, key, &crb))
+			crb.Labels = nil
+			crb.ManagedFields = nil
+			require.NoError(t, c.Update(ctx, &crb))
+
+			drift, err := op.DetectDrift(ctx, bg, true)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"ClusterRoleBinding " + key.Name + " missing"}, driftStrings(drift))
+
+			require.NoError(t, op.RepairDrift(ctx, bg, drift), "stripped labels are drift, not a conflict")
+			assert.Equal(t, tt.wantDeletes, deletes)
+			drift, err = op.DetectDrift(ctx, bg, true)
+			require.NoError(t, err)
+			assert.Empty(t, drift)
+		})
+	}
+}
+
+func TestOperator_MigrateLegacyNames(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			ClusterRoles: []string{"view"},
+			Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			Policy: []accessv1alpha1.Policy{{Namespace: "apps", Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"},
+			}}}},
+		},
+		Status: accessv1alpha1.BreakglassStatus{CreatedResources: []string{
+			"breakglass-01234567-clusterrolebinding-view",
+			"breakglass-01234567-role-0",
+			"breakglass-01234567-rolebinding-0",
+		}},
+	}
+	op, c := newTestOperator(t, bg)
+	ctx := context.Background()
+
+	// RBAC as granted by an operator that named objects after the first 8 UID characters
+	labels := op.getBreakglassLabels(bg)
+	subjects := []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "alice"}}
+	for _, obj := range []client.Object{
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "breakglass-01234567-clusterrolebinding-view", Labels: labels},
+			Subjects:   subjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+		},
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "breakglass-01234567-role-0", Namespace: "apps", Labels: labels},
+			Rules:      bg.Spec.Policy[0].Rules,
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "breakglass-01234567-rolebinding-0", Namespace: "apps", Labels: labels},
+			Subjects:   subjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "breakglass-01234567-role-0"},
+		},
+	} {
+		require.NoError(t, c.Create(ctx, obj))
+	}
+
+	migrated, err := op.MigrateLegacyNames(ctx, bg)
+	require.NoError(t, err)
+	assert.True(t, migrated)
+	assert.Equal(t, []string{
+		resourceName(bg, "clusterrolebinding-view"),
+		resourceName(bg, "policy-role-0"),
+		resourceName(bg, "policy-rolebinding-0"),
+	}, bg.Status.CreatedResources)
+
+	// The adopted objects are exactly what the spec grants, so nothing is reported as tampering
+	drift, err := op.DetectDrift(ctx, bg, true)
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+	var rb rbacv1.RoleBinding
+	rbKey := client.ObjectKey{Namespace: "apps", Name: resourceName(bg, "policy-rolebinding-0")}
+	require.NoError(t, c.Get(ctx, rbKey, &rb))
+	assert.Equal(t, resourceName(bg, "policy-role-0"), rb.RoleRef.Name)
+	err = c.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "breakglass-01234567-role-0"}, &rbacv1.Role{})
+	assert.True(t, apierrors.IsNotFound(err), "legacy objects are deleted once adopted")
+
+	// A granted request needs nothing more, and migrating again is a no-op
+	require.NoError(t, op.GrantAccess(ctx, bg))
+	migrated, err = op.MigrateLegacyNames(ctx, bg)
+	require.NoError(t, err)
+	assert.False(t, migrated)
+}
+
+func TestOperator_SyncAccess(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			ClusterRoles: []string{"view"},
+			Policy: []accessv1alpha1.Policy{{Namespace: "apps", Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"},
+			}}}},
+		},
+	}
+	op, c := newTestOperator(t, bg)
+	ctx := context.Background()
+	require.NoError(t, op.GrantAccess(ctx, bg))
+
+	// swap view for edit, add a subject and widen the ad-hoc rule
+	bg.Spec.ClusterRoles = []string{"edit"}
+	bg.Spec.Subjects = append(bg.Spec.Subjects, rbacv1.Subject{Kind: rbacv1.UserKind, Name: "bob"})
+	bg.Spec.Policy[0].Rules[0].Verbs = []string{"get", "list"}
+
+	changes, err := op.SyncAccess(ctx, bg)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"ClusterRoleBinding " + resourceName(bg, "clusterrolebinding-edit") + " missing",
+		"ClusterRoleBinding " + resourceName(bg, "clusterrolebinding-view") + " stray",
+		"Role apps/" + resourceName(bg, "policy-role-0") + " modified",
+		"RoleBinding apps/" + resourceName(bg, "policy-rolebinding-0") + " modified",
+	}, driftStrings(changes))
+	assert.Equal(t, controller.DriftStray, changes[0].Change, "revoked access is removed first")
+	assert.Equal(t, []string{
+		resourceName(bg, "clusterrolebinding-edit"),
+		resourceName(bg, "policy-role-0"),
+		resourceName(bg, "policy-rolebinding-0"),
+	}, bg.Status.CreatedResources)
+
+	var crbs rbacv1.ClusterRoleBindingList
+	require.NoError(t, c.List(ctx, &crbs))
+	require.Len(t, crbs.Items, 1)
+	assert.Equal(t, "edit", crbs.Items[0].RoleRef.Name)
+	assert.Equal(t, bg.Spec.Subjects, crbs.Items[0].Subjects)
+
+	var role rbacv1.Role
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "apps", Name: resourceName(bg, "policy-role-0")}, &role))
+	assert.Equal(t, []string{"get", "list"}, role.Rules[0].Verbs)
+
+	drift, err := op.DetectDrift(ctx, bg, true)
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+
+	// the new spec goes through the same checks as a grant
+	denied := New(c, WithDenylist(config.DenylistConfig{ClusterRoles: []string{"cluster-admin"}}),
+		WithPrivilegeEscalation(true))
+	bg.Spec.ClusterRoles = []string{"cluster-admin"}
+	_, err = denied.SyncAccess(ctx, bg)
+	var denyErr *internalerrors.DenylistError
+	require.ErrorAs(t, err, &denyErr)
+	require.NoError(t, c.List(ctx, &crbs))
+	assert.Equal(t, "edit", crbs.Items[0].RoleRef.Name, "refused specs leave the live RBAC alone")
+}
+
+func TestOperator_GrantUsesServerSideApply(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			ClusterRoles: []string{"view"},
+		},
+	}
+	var applied []client.PatchOptions
+	funcs := interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*rbacv1.ClusterRoleBinding); ok {
+				t.Errorf("ClusterRoleBinding %s was created instead of applied", obj.GetName())
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+		Patch: func(
+			ctx context.Context,
+			c client.WithWatch,
+			obj client.Object,
+			patch client.Patch,
+			opts ...client.PatchOption,
+		) error {
+			if patch.Type() == types.ApplyPatchType {
+				var po client.PatchOptions
+				po.ApplyOptions(opts)
+				applied = append(applied, po)
+				assert.Equal(t, "ClusterRoleBinding", obj.GetObjectKind().GroupVersionKind().Kind)
+				// the fake client cannot apply, so hand the patch to the emulation
+				return emulateApply(ctx, c, obj, patch)
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}
+	op := New(newTestClient(t, funcs, bg), WithPrivilegeEscalation(true))
+
+	require.NoError(t, op.GrantAccess(context.Background(), bg))
+	require.Len(t, applied, 1)
+	assert.Equal(t, FieldManager, applied[0].FieldManager)
+	require.NotNil(t, applied[0].Force)
+	assert.True(t, *applied[0].Force)
+}
+
+func TestResourceName(t *testing.T) {
+	a := &accessv1alpha1.Breakglass{ObjectMeta: metav1.ObjectMeta{UID: "01234567-aaaa-4000-8000-000000000001"}}
+	b := &accessv1alpha1.Breakglass{ObjectMeta: metav1.ObjectMeta{UID: "01234567-aaaa-4000-8000-000000000002"}}
+
+	assert.Equal(t, resourceName(a, "clusterrolebinding-view"), resourceName(a, "clusterrolebinding-view"))
+	assert.NotEqual(t, resourceName(a, "clusterrolebinding-view"), resourceName(b, "clusterrolebinding-view"),
+		"UIDs sharing a prefix get distinct names")
+
+	long := "clusterrolebinding-" + strings.Repeat("x", 80)
+	assert.LessOrEqual(t, len(resourceName(a, long)), maxNameLength)
+	assert.NotEqual(t, resourceName(a, long+"-1"), resourceName(a, long+"-2"),
+		"suffixes cut to the same prefix get distinct names")
+	assert.Regexp(t, `^breakglass-clusterrolebinding-x+-[0-9a-f]{16}$`, resourceName(a, long))
+}
+
+func TestOperator_GrantRefusesForeignObject(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			ClusterRoles: []string{"view"},
+		},
+	}
+	foreign := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   resourceName(bg, "clusterrolebinding-view"),
+			Labels: map[string]string{LabelUID: "fedcba9876543210"},
+		},
+		RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+	}
+	op, c := newTestOperator(t, bg, foreign)
+	ctx := context.Background()
+
+	err := op.GrantAccess(ctx, bg)
+	require.Error(t, err)
+	var rbacErr *internalerrors.RBACError
+	require.ErrorAs(t, err, &rbacErr)
+	assert.Equal(t, accessv1alpha1.ReasonResourceConflict, rbacErr.Condition)
+	assert.False(t, rbacErr.IsRetryable())
+
+	var crb rbacv1.ClusterRoleBinding
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(foreign), &crb))
+	assert.Equal(t, "cluster-admin", crb.RoleRef.Name, "the foreign object is left untouched")
+	assert.Equal(t, "fedcba9876543210", crb.Labels[LabelUID])
+}
+
+func TestOperator_OwnerIndex(t *testing.T) {
+	mine := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "mine", Namespace: "default", UID: "0123456789abcdef"},
+		Spec:       accessv1alpha1.BreakglassSpec{ClusterRoles: []string{"edit"}},
+	}
+	other := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", UID: "fedcba9876543210"},
+	}
+	binding := func(bg *accessv1alpha1.Breakglass) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   resourceName(bg, "clusterrolebinding-edit"),
+				Labels: (&Operator{}).getBreakglassLabels(bg),
+			},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+		}
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+
+	// The cached client only holds labelled objects; the granted ClusterRole is only seen by the reader
+	listed := 0
+	builder := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(binding(mine), binding(other)).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listed++
+				lo := (&client.ListOptions{}).ApplyOptions(opts)
+				assert.Equal(t, OwnerUIDField+"=0123456789abcdef", lo.FieldSelector.String())
+				return c.List(ctx, list, opts...)
+			},
+		})
+	for _, obj := range rbacKinds() {
+		builder = builder.WithIndex(obj, OwnerUIDField, OwnerUIDIndexer)
+	}
+	cached := builder.Build()
+	reader := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "edit"}}).
+		Build()
+	op := New(cached, WithPrivilegeEscalation(true), WithReader(reader), WithOwnerIndex())
+	ctx := context.Background()
+
+	missing, err := op.missingClusterRoles(ctx, mine)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+
+	require.NoError(t, op.CleanupResources(ctx, mine))
+	assert.Equal(t, 4, listed, "one indexed lookup per kind")
+	var crb rbacv1.ClusterRoleBinding
+	assert.True(t, apierrors.IsNotFound(cached.Get(ctx, client.ObjectKeyFromObject(binding(mine)), &crb)))
+	require.NoError(t, cached.Get(ctx, client.ObjectKeyFromObject(binding(other)), &crb))
+}
+
+func TestOperator_CleanupBypassesStaleCache(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+	}
+	// Granted moments ago: the API server has the binding, the cache has not seen it yet
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName(bg, "rolebinding-edit"),
+			Namespace: "payments",
+			Labels:    (&Operator{}).getBreakglassLabels(bg),
+		},
+		RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+	}
+	server := newTestClient(t, interceptor.Funcs{}, rb)
+	cached := interceptor.NewClient(server.(client.WithWatch), interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			return nil
+		},
+	})
+	op := New(cached, WithPrivilegeEscalation(true), WithReader(server))
+	ctx := context.Background()
+
+	require.NoError(t, op.CleanupResources(ctx, bg))
+	err := server.Get(ctx, client.ObjectKeyFromObject(rb), &rbacv1.RoleBinding{})
+	assert.True(t, apierrors.IsNotFound(err), "revoke deletes the binding the cache missed: %v", err)
+}
+
+func TestOperator_OwnedReads(t *testing.T) {
+	bg := &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "0123456789abcdef"},
+	}
+	labels := (&Operator{}).getBreakglassLabels(bg)
+	binding := func(name string, labels map[string]string) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName(bg, name), Labels: labels},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+		}
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+
+	// The cache holds the labelled binding; the reader also sees an unlabelled one taking a generated name
+	cached := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(binding("clusterrolebinding-edit", labels)).
+		WithInterceptorFuncs(interceptor.Funcs{Patch: emulateApply}).
+		Build()
+	read := 0
+	reader := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(binding("clusterrolebinding-edit", labels), binding("clusterrolebinding-view", nil)).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(
+				ctx context.Context,
+				c client.WithWatch,
+				key client.ObjectKey,
+				obj client.Object,
+				opts ...client.GetOption,
+			) error {
+				read++
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+	op := New(cached, WithReader(reader))
+	ctx := context.Background()
+
+	owned := binding("clusterrolebinding-edit", labels)
+	require.NoError(t, op.applyResourceWithTimeout(ctx, owned, "owned"))
+	require.NoError(t, op.restoreResource(ctx, owned))
+	assert.Zero(t, read, "objects in the cache are not read from the API server")
+
+	err := op.applyResourceWithTimeout(ctx, binding("clusterrolebinding-view", labels), "taken")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exists without the labels of this request")
+	assert.Equal(t, 1, read, "a name the cache does not hold is looked up with the reader")
+}
+
+func TestCacheByObject(t *testing.T) {
+	byObject := CacheByObject()
+	require.Len(t, byObject, 4)
+	for obj, opts := range byObject {
+		assert.True(t, opts.Label.Matches(labels.Set{LabelUID: "0123456789abcdef"}), "%T", obj)
+		assert.False(t, opts.Label.Matches(labels.Set{"app": "payments"}), "%T", obj)
+	}
+}
+
+func driftStrings(drift []controller.Drift) []string {
+	out := make([]string, 0, len(drift))
+	for _, d := range drift {
+		out = append(out, d.String())
+	}
+	return out
+}
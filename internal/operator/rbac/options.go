@@ -0,0 +1,71 @@
+package rbac
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloud-nimbus/firedoor/internal/config"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+)
+
+// Option configures the Operator.
+type Option func(*Operator)
+
+// WithPrivilegeEscalation lets the operator grant permissions the requester does not hold.
+// When disabled, every requested permission is checked against the requester with
+// SubjectAccessReviews before anything is created.
+func WithPrivilegeEscalation(enabled bool) Option {
+	return func(o *Operator) {
+		o.privilegeEscalation = enabled
+	}
+}
+
+// WithAdmissionWebhook tells the operator whether the admission webhook stamps the requester annotations.
+// Without it the annotations can be written by the requester, so the permission check refuses every request
+// instead of reviewing whoever they name.
+func WithAdmissionWebhook(enabled bool) Option {
+	return func(o *Operator) {
+		o.admissionWebhook = enabled
+	}
+}
+
+// WithDenylist refuses to bind the ClusterRoles and subjects listed in denylist, and wildcard
+// rules unless it allows them, except for requests admitted by a policy with allowProtected set.
+func WithDenylist(denylist config.DenylistConfig) Option {
+	return func(o *Operator) {
+		o.denylist = denylist
+	}
+}
+
+// WithNamespaceRestriction limits namespaced Breakglass requests to granting access in their own
+// namespace, unless the admitting policy allows a cluster-wide grant or further target namespaces.
+func WithNamespaceRestriction(enabled bool) Option {
+	return func(o *Operator) {
+		o.restrictToNamespace = enabled
+	}
+}
+
+// WithClock sets the clock ValidateAccess checks schedule windows against. The wall clock is used
+// by default.
+func WithClock(clock controller.Clock) Option {
+	return func(o *Operator) {
+		o.clock = clock
+	}
+}
+
+// WithReader sets the reader for objects firedoor did not create, which a cache restricted by
+// CacheByObject does not hold: the ClusterRoles a request binds, the namespaces and service accounts it
+// names, and objects taking a generated name that the client given to New does not find. That client is
+// used by default.
+func WithReader(reader client.Reader) Option {
+	return func(o *Operator) {
+		o.reader = reader
+	}
+}
+
+// WithOwnerIndex finds the RBAC objects of a request through the OwnerUIDField index instead of
+// filtering every object by label. The client given to New must serve that index.
+func WithOwnerIndex() Option {
+	return func(o *Operator) {
+		o.ownerIndex = true
+	}
+}
@@ -0,0 +1,153 @@
+package rbac
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+// PlanAccess renders the RBAC objects GrantAccess would create for bg without creating anything.
+// Bindings carry the effective rules of the role they reference, with existing ClusterRoles expanded.
+// Refusals GrantAccess would hit, such as denylisted roles or missing requester permissions, are
+// reported as warnings rather than errors so the plan can still be reviewed.
+func (o *Operator) PlanAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) (*accessv1alpha1.RBACPlan, error) {
+	plan := &accessv1alpha1.RBACPlan{}
+
+	if err := o.preflight(ctx, bg); err != nil {
+		if !isRefusal(err) {
+			return nil, err
+		}
+		plan.Warnings = append(plan.Warnings, err.Error())
+	}
+
+	resources, err := o.desiredResources(ctx, bg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Roles created by the plan itself are resolved from the plan rather than the cluster
+	planned := make(map[string][]rbacv1.PolicyRule)
+	for _, r := range resources {
+		switch obj := r.obj.(type) {
+		case *rbacv1.Role:
+			planned[roleKey(accessv1alpha1.KindRole, obj.Namespace, obj.Name)] = obj.Rules
+		case *rbacv1.ClusterRole:
+			planned[roleKey(accessv1alpha1.KindClusterRole, "", obj.Name)] = obj.Rules
+		}
+	}
+
+	expanded := make(map[string][]rbacv1.PolicyRule)
+	for _, r := range resources {
+		obj := accessv1alpha1.PlannedObject{
+			Kind:      kindOf(r.obj),
+			Namespace: r.obj.GetNamespace(),
+			Name:      r.obj.GetName(),
+		}
+		var roleRef rbacv1.RoleRef
+		switch typed := r.obj.(type) {
+		case *rbacv1.Role:
+			obj.Rules = typed.Rules
+		case *rbacv1.ClusterRole:
+			obj.Rules = typed.Rules
+		case *rbacv1.RoleBinding:
+			roleRef, obj.Subjects = typed.RoleRef, typed.Subjects
+		case *rbacv1.ClusterRoleBinding:
+			roleRef, obj.Subjects = typed.RoleRef, typed.Subjects
+		}
+
+		if roleRef.Name != "" {
+			obj.RoleRef = &roleRef
+			rules, warning, err := o.effectiveRules(ctx, obj.Namespace, roleRef, planned, expanded)
+			if err != nil {
+				return nil, err
+			}
+			if warning != "" {
+				plan.Warnings = append(plan.Warnings, warning)
+			}
+			obj.Rules = rules
+		}
+		plan.Objects = append(plan.Objects, obj)
+	}
+
+	return plan, nil
+}
+
+// preflight runs the checks GrantAccess makes before creating anything.
+func (o *Operator) preflight(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	// Protected roles and subjects are refused regardless of what the requester holds
+	if err := o.checkDenylist(ctx, bg); err != nil {
+		return err
+	}
+	if o.restrictToNamespace {
+		if err := o.checkTargetNamespaces(ctx, bg); err != nil {
+			return err
+		}
+	}
+
+	// Unless escalation is enabled, the requester must already hold everything being granted
+	if !o.privilegeEscalation {
+		return o.checkRequesterPermissions(ctx, bg)
+	}
+	return nil
+}
+
+// isRefusal reports whether err is a permanent failure GrantAccess would refuse the request with, as
+// opposed to a transient failure talking to the API server.
+func isRefusal(err error) bool {
+	var (
+		escErr  *errors.EscalationError
+		denyErr *errors.DenylistError
+		nsErr   *errors.NamespaceRestrictionError
+		rbacErr *errors.RBACError
+	)
+	if stderrors.As(err, &rbacErr) {
+		return !rbacErr.IsRetryable()
+	}
+	return stderrors.As(err, &escErr) || stderrors.As(err, &denyErr) || stderrors.As(err, &nsErr)
+}
+
+// effectiveRules returns the rules a binding in namespace grants through ref. ClusterRoles that are not
+// part of the plan are read from the cluster once and cached in expanded. A missing role yields a warning.
+func (o *Operator) effectiveRules(
+	ctx context.Context,
+	namespace string,
+	ref rbacv1.RoleRef,
+	planned, expanded map[string][]rbacv1.PolicyRule,
+) ([]rbacv1.PolicyRule, string, error) {
+	refNamespace := ""
+	if ref.Kind == accessv1alpha1.KindRole {
+		refNamespace = namespace
+	}
+	key := roleKey(ref.Kind, refNamespace, ref.Name)
+	if rules, ok := planned[key]; ok {
+		return rules, "", nil
+	}
+	if rules, ok := expanded[key]; ok {
+		return rules, "", nil
+	}
+	if ref.Kind != accessv1alpha1.KindClusterRole {
+		return nil, fmt.Sprintf("%s %s is not part of the plan", ref.Kind, ref.Name), nil
+	}
+
+	var cr rbacv1.ClusterRole
+	if err := o.getResourceWithTimeout(ctx, client.ObjectKey{Name: ref.Name}, &cr); err != nil {
+		if errors.IsNotFoundError(err) {
+			expanded[key] = nil
+			return nil, fmt.Sprintf("ClusterRole %s does not exist", ref.Name), nil
+		}
+		return nil, "", errors.NewRetryableRBACError("reading", "ClusterRole "+ref.Name,
+			accessv1alpha1.ReasonRBACTimeout, err)
+	}
+	expanded[key] = cr.Rules
+	return cr.Rules, "", nil
+}
+
+func roleKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
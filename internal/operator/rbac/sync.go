@@ -0,0 +1,47 @@
+package rbac
+
+import (
+	"context"
+	"sort"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+)
+
+// SyncAccess converges the live RBAC of an active bg on its current spec after the spec changed. The new
+// spec goes through the same checks as GrantAccess. Objects no longer granted are deleted before new or
+// changed ones are written, so access never briefly covers both specs. It returns the changes made and
+// records the granted objects in status.createdResources; the caller writes the status.
+func (o *Operator) SyncAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) ([]controller.Drift, error) {
+	if err := o.preflight(ctx, bg); err != nil {
+		return nil, err
+	}
+
+	drift, err := o.DetectDrift(ctx, bg, true)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(drift, func(i, j int) bool {
+		return drift[i].Change == controller.DriftStray && drift[j].Change != controller.DriftStray
+	})
+	if err := o.RepairDrift(ctx, bg, drift); err != nil {
+		return nil, err
+	}
+
+	resources, err := o.desiredResources(ctx, bg, nil)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]string, 0, len(resources))
+	for _, r := range resources {
+		refs = append(refs, r.ref)
+	}
+	bg.Status.CreatedResources = refs
+
+	if len(drift) > 0 {
+		ctrl.LoggerFrom(ctx).Info("synced breakglass RBAC with spec", "changes", len(drift), "resources", refs)
+	}
+	return drift, nil
+}
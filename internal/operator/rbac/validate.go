@@ -0,0 +1,153 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cronv3 "github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+// ValidateAccess checks that bg can be granted as written: its schedule is valid and has not ended,
+// and the ClusterRoles, target namespaces and ServiceAccount subjects it references exist. Every
+// problem found is reported in a single ValidationError. Failures reading the cluster are returned
+// as retryable RBACErrors.
+func (o *Operator) ValidateAccess(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	reasons := validateSchedule(&bg.Spec.Schedule, o.clock.Now())
+	for _, check := range []func(context.Context, *accessv1alpha1.Breakglass) ([]string, error){
+		o.missingClusterRoles,
+		o.missingNamespaces,
+		o.missingServiceAccounts,
+	} {
+		missing, err := check(ctx, bg)
+		if err != nil {
+			return err
+		}
+		reasons = append(reasons, missing...)
+	}
+
+	if len(reasons) > 0 {
+		return errors.NewValidationError(reasons)
+	}
+	return nil
+}
+
+// validateSchedule reports an unknown location, an unparsable cron expression, and schedules that
+// can no longer open a window at now.
+func validateSchedule(schedule *accessv1alpha1.ScheduleSpec, now time.Time) []string {
+	var reasons []string
+	if schedule.Location != "" {
+		if _, err := time.LoadLocation(schedule.Location); err != nil {
+			reasons = append(reasons, fmt.Sprintf("location %q is not a valid IANA time zone", schedule.Location))
+		}
+	}
+	if schedule.Cron != "" {
+		parser := cronv3.NewParser(cronv3.Minute | cronv3.Hour | cronv3.Dom | cronv3.Month | cronv3.Dow)
+		if _, err := parser.Parse(schedule.Cron); err != nil {
+			reasons = append(reasons, fmt.Sprintf("invalid cron schedule %q: %v", schedule.Cron, err))
+		}
+	}
+
+	switch {
+	case schedule.Until != nil && !schedule.Until.After(now):
+		reasons = append(reasons, fmt.Sprintf("schedule ended at %s", schedule.Until.UTC().Format(time.RFC3339)))
+	case schedule.Cron == "" && !schedule.Start.IsZero() && schedule.Duration.Duration > 0:
+		if end := schedule.Start.Add(schedule.Duration.Duration); !end.After(now) {
+			reasons = append(reasons, fmt.Sprintf("window ended at %s", end.UTC().Format(time.RFC3339)))
+		}
+	}
+	return reasons
+}
+
+// missingClusterRoles reports the ClusterRoles in spec.clusterRoles that do not exist.
+func (o *Operator) missingClusterRoles(ctx context.Context, bg *accessv1alpha1.Breakglass) ([]string, error) {
+	var reasons []string
+	for _, name := range bg.Spec.ClusterRoles {
+		missing, err := o.isMissing(ctx, client.ObjectKey{Name: name}, &rbacv1.ClusterRole{}, "ClusterRole "+name)
+		if err != nil {
+			return nil, err
+		}
+		if missing {
+			reasons = append(reasons, fmt.Sprintf("clusterRole %q does not exist", name))
+		}
+	}
+	return reasons, nil
+}
+
+// missingNamespaces reports the namespaces named by spec.policy and spec.clusterRoleScope that do
+// not exist. Namespaces matched by a selector exist by definition.
+func (o *Operator) missingNamespaces(ctx context.Context, bg *accessv1alpha1.Breakglass) ([]string, error) {
+	var names []string
+	for _, p := range bg.Spec.Policy {
+		if p.Namespace != "" {
+			names = append(names, p.Namespace)
+		}
+	}
+	if scope := bg.Spec.ClusterRoleScope; scope != nil {
+		names = append(names, scope.Namespaces...)
+	}
+
+	var reasons []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		missing, err := o.isMissing(ctx, client.ObjectKey{Name: name}, &corev1.Namespace{}, "Namespace "+name)
+		if err != nil {
+			return nil, err
+		}
+		if missing {
+			reasons = append(reasons, fmt.Sprintf("namespace %q does not exist", name))
+		}
+	}
+	return reasons, nil
+}
+
+// missingServiceAccounts reports the ServiceAccount subjects that do not exist. Subjects without a
+// namespace are looked up in the namespace of the request.
+func (o *Operator) missingServiceAccounts(ctx context.Context, bg *accessv1alpha1.Breakglass) ([]string, error) {
+	var reasons []string
+	for _, subject := range bg.Spec.Subjects {
+		if subject.Kind != rbacv1.ServiceAccountKind {
+			continue
+		}
+		namespace := subject.Namespace
+		if namespace == "" {
+			namespace = bg.Namespace
+		}
+		if namespace == "" {
+			reasons = append(reasons, fmt.Sprintf("serviceAccount %q has no namespace", subject.Name))
+			continue
+		}
+		key := client.ObjectKey{Namespace: namespace, Name: subject.Name}
+		missing, err := o.isMissing(ctx, key, &corev1.ServiceAccount{}, "ServiceAccount "+key.String())
+		if err != nil {
+			return nil, err
+		}
+		if missing {
+			reasons = append(reasons, fmt.Sprintf("serviceAccount %q does not exist", key.String()))
+		}
+	}
+	return reasons, nil
+}
+
+// isMissing reports whether the object at key does not exist.
+func (o *Operator) isMissing(ctx context.Context, key client.ObjectKey, obj client.Object, desc string) (bool, error) {
+	err := o.getResourceWithTimeout(ctx, key, obj)
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.IsNotFoundError(err):
+		return true, nil
+	default:
+		return false, errors.NewRetryableRBACError("reading", desc, accessv1alpha1.ReasonRBACTimeout, err)
+	}
+}
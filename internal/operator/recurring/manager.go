@@ -82,7 +82,7 @@ func New(clock controller.Clock) *Manager {
 func (m *Manager) ProcessRecurring(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
 	log := logFromContext(ctx)
 
-	// Check activation limits (maxActivations, end time)
+	// Check activation limits (maxActivations, until)
 	if m.checkActivationLimits(bg, log) {
 		return nil
 	}
@@ -129,6 +129,10 @@ func (m *Manager) ProcessRecurring(ctx context.Context, bg *accessv1alpha1.Break
 			windowEnd := bg.Status.NextActivationAt.Time.Add(duration)
 			if now.After(windowEnd) {
 				next := m.calculateNextActivation(sched, now, &bg.Spec.Schedule)
+				if next == nil && bg.Spec.Schedule.Until != nil {
+					m.endSchedule(bg, log)
+					return nil
+				}
 				if next != nil {
 					bg.Status.NextActivationAt = &metav1.Time{Time: *next}
 					log.V(3).Info("missed activation window; rescheduled", "nextActivation", next)
@@ -170,6 +174,9 @@ func (m *Manager) ShouldActivate(ctx context.Context, bg *accessv1alpha1.Breakgl
 	if !bg.Spec.Schedule.Start.IsZero() && m.clock.Now().Before(bg.Spec.Schedule.Start.Time) {
 		return false
 	}
+	if untilReached(&bg.Spec.Schedule, m.clock.Now()) {
+		return false
+	}
 	return !m.clock.Now().Before(bg.Status.NextActivationAt.Time)
 }
 
@@ -204,6 +211,13 @@ func (m *Manager) OnActivationGranted(ctx context.Context, bg *accessv1alpha1.Br
 	}
 
 	next := m.calculateNextActivation(sched, ref, &bg.Spec.Schedule)
+	if next == nil && bg.Spec.Schedule.Until != nil {
+		// The schedule ends before another window opens; this is the last activation.
+		bg.Status.ActivationCount++
+		bg.Status.NextActivationAt = nil
+		log.V(3).Info("no activation before schedule end; not rescheduling", "until", bg.Spec.Schedule.Until)
+		return nil
+	}
 	if next == nil {
 		return errors.New("could not calculate next activation time after grant")
 	}
@@ -304,6 +318,9 @@ func (m *Manager) calculateNextActivation(
 	if !schedule.Start.IsZero() && candidate.Before(schedule.Start.Time) {
 		candidate = schedule.Start.Time
 	}
+	if untilReached(schedule, candidate) {
+		return nil
+	}
 
 	return &candidate
 }
@@ -393,8 +410,30 @@ func (m *Manager) checkActivationLimits(bg *accessv1alpha1.Breakglass, log logge
 		return true
 	}
 
+	if untilReached(schedule, m.clock.Now()) {
+		m.endSchedule(bg, log)
+		return true
+	}
+
 	return false
+}
+
+// endSchedule stops further activations once schedule.until has passed.
+func (m *Manager) endSchedule(bg *accessv1alpha1.Breakglass, log logger) {
+	log.V(3).Info("no activations left before schedule end", "until", bg.Spec.Schedule.Until)
+	bg.Status.NextActivationAt = nil
+	m.setCondition(
+		bg,
+		accessv1alpha1.ConditionExpired,
+		accessv1alpha1.ReasonScheduleEnded,
+		fmt.Sprintf("Schedule ended at %s", bg.Spec.Schedule.Until.Format(time.RFC3339)),
+		bg.Generation,
+	)
+}
 
+// untilReached reports whether t is at or after schedule.until.
+func untilReached(schedule *accessv1alpha1.ScheduleSpec, t time.Time) bool {
+	return schedule.Until != nil && !t.Before(schedule.Until.Time)
 }
 
 // handleInitialActivation schedules the first activation if needed
@@ -405,7 +444,7 @@ func (m *Manager) handleInitialActivation(
 	log logger,
 ) error {
 	nextActivation := m.calculateNextActivation(sched, now, &bg.Spec.Schedule)
-	if nextActivation == nil {
+	if nextActivation == nil && bg.Spec.Schedule.Until == nil {
 		log.Error(errors.New("no next activation"), "could not calculate next activation time")
 		m.setCondition(
 			bg,
@@ -433,6 +472,11 @@ func (m *Manager) handleInitialActivation(
 		}
 	}
 
+	if nextActivation == nil {
+		m.endSchedule(bg, log)
+		return nil
+	}
+
 	bg.Status.NextActivationAt = &metav1.Time{Time: *nextActivation}
 	log.V(3).Info("scheduled initial activation", "nextActivation", nextActivation)
 	m.setCondition(
@@ -609,3 +609,63 @@ func int32Ptr(i int32) *int32 { return &i }
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestManager_Until(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	until := metav1.NewTime(baseTime.Add(3 * time.Minute))
+
+	t.Run("last activation before until is not rescheduled", func(t *testing.T) {
+		bg := &accessv1alpha1.Breakglass{
+			Spec: accessv1alpha1.BreakglassSpec{
+				Schedule: accessv1alpha1.ScheduleSpec{
+					Cron:     "*/5 * * * *",
+					Duration: metav1.Duration{Duration: time.Minute},
+					Until:    &until,
+				},
+			},
+			Status: accessv1alpha1.BreakglassStatus{
+				GrantedAt: &metav1.Time{Time: baseTime},
+			},
+		}
+
+		manager := New(&mockClock{now: baseTime})
+		if err := manager.OnActivationGranted(context.Background(), bg); err != nil {
+			t.Fatalf("OnActivationGranted returned error: %v", err)
+		}
+		if bg.Status.NextActivationAt != nil {
+			t.Fatalf("expected NextActivationAt to be nil, got %v", bg.Status.NextActivationAt)
+		}
+		if bg.Status.ActivationCount != 1 {
+			t.Fatalf("expected ActivationCount 1, got %d", bg.Status.ActivationCount)
+		}
+	})
+
+	t.Run("pending schedule past until expires", func(t *testing.T) {
+		bg := &accessv1alpha1.Breakglass{
+			Spec: accessv1alpha1.BreakglassSpec{
+				Schedule: accessv1alpha1.ScheduleSpec{
+					Cron:     "*/5 * * * *",
+					Duration: metav1.Duration{Duration: time.Minute},
+					Until:    &until,
+				},
+			},
+			Status: accessv1alpha1.BreakglassStatus{
+				NextActivationAt: &metav1.Time{Time: baseTime.Add(5 * time.Minute)},
+			},
+		}
+
+		manager := New(&mockClock{now: baseTime.Add(4 * time.Minute)})
+		if err := manager.ProcessRecurring(context.Background(), bg); err != nil {
+			t.Fatalf("ProcessRecurring returned error: %v", err)
+		}
+		if manager.ShouldActivate(context.Background(), bg) {
+			t.Fatalf("expected no activation after until")
+		}
+		last := bg.Status.Conditions[len(bg.Status.Conditions)-1]
+		if last.Type != string(accessv1alpha1.ConditionExpired) ||
+			last.Reason != string(accessv1alpha1.ReasonScheduleEnded) {
+			t.Fatalf("expected %s/%s, got %s/%s", accessv1alpha1.ConditionExpired,
+				accessv1alpha1.ReasonScheduleEnded, last.Type, last.Reason)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Cloud-Nimbus Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/config"
+)
+
+// CheckDenylist returns the reasons bg targets ClusterRoles, subjects or wildcard rules
+// refused by denylist, or nil when it does not. clusterRoles are the requested ClusterRoles as read
+// by RequestedClusterRoles; their rules are checked for wildcards like the rules of spec.policy.
+func CheckDenylist(
+	denylist config.DenylistConfig,
+	bg *accessv1alpha1.Breakglass,
+	clusterRoles []rbacv1.ClusterRole,
+) []string {
+	var reasons []string
+
+	for _, cr := range bg.Spec.ClusterRoles {
+		if denylisted(denylist.ClusterRoles, cr) {
+			reasons = append(reasons, fmt.Sprintf("clusterRole %q is protected", cr))
+		}
+	}
+	for i, subject := range bg.Spec.Subjects {
+		var patterns []string
+		switch subject.Kind {
+		case rbacv1.UserKind:
+			patterns = denylist.Users
+		case rbacv1.GroupKind:
+			patterns = denylist.Groups
+		default:
+			continue
+		}
+		if denylisted(patterns, subject.Name) {
+			reasons = append(reasons, fmt.Sprintf("subjects[%d]: %s %q is protected", i, subject.Kind, subject.Name))
+		}
+	}
+	if !denylist.AllowWildcardRules {
+		for i, entry := range bg.Spec.Policy {
+			reasons = append(reasons, wildcardRules(fmt.Sprintf("policy[%d]", i), entry.Rules)...)
+		}
+		for _, cr := range clusterRoles {
+			reasons = append(reasons, wildcardRules(fmt.Sprintf("clusterRole %q", cr.Name), cr.Rules)...)
+		}
+	}
+	return reasons
+}
+
+// wildcardRules returns a reason for each rule granting all verbs on all resources.
+func wildcardRules(source string, rules []rbacv1.PolicyRule) []string {
+	var reasons []string
+	for j, rule := range rules {
+		if slices.Contains(rule.Verbs, wildcard) && slices.Contains(rule.Resources, wildcard) {
+			reasons = append(reasons, fmt.Sprintf("%s.rules[%d] grants all verbs on all resources", source, j))
+		}
+	}
+	return reasons
+}
+
+// RequestedClusterRoles reads the ClusterRoles bg requests for CheckDenylist. ClusterRoles that do
+// not exist are skipped; the request fails validation for them elsewhere.
+func RequestedClusterRoles(
+	ctx context.Context,
+	reader client.Reader,
+	bg *accessv1alpha1.Breakglass,
+) ([]rbacv1.ClusterRole, error) {
+	roles := make([]rbacv1.ClusterRole, 0, len(bg.Spec.ClusterRoles))
+	for _, name := range bg.Spec.ClusterRoles {
+		var cr rbacv1.ClusterRole
+		if err := reader.Get(ctx, client.ObjectKey{Name: name}, &cr); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("get clusterrole %s: %w", name, err)
+		}
+		roles = append(roles, cr)
+	}
+	return roles, nil
+}
+
+// denylisted reports whether value matches one of patterns. A trailing "*" matches by prefix.
+func denylisted(patterns []string, value string) bool {
+	for _, p := range patterns {
+		if p == value {
+			return true
+		}
+		if strings.HasSuffix(p, wildcard) && strings.HasPrefix(value, strings.TrimSuffix(p, wildcard)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/config"
+)
+
+func TestCheckDenylist(t *testing.T) {
+	denylist := config.DenylistConfig{
+		ClusterRoles: []string{"cluster-admin"},
+		Groups:       []string{"system:masters", "system:*"},
+		Users:        []string{"system:*"},
+	}
+	allRules := []accessv1alpha1.Policy{{
+		Namespace: "payments",
+		Rules:     []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+	}}
+
+	viewRole := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "view"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"*"}, Verbs: []string{"get"}}},
+	}
+	godMode := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "god-mode"},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}},
+			{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		denylist config.DenylistConfig
+		mutate   func(bg *accessv1alpha1.Breakglass)
+		roles    []rbacv1.ClusterRole
+		want     []string
+	}{
+		{
+			name:     "ordinary request",
+			denylist: denylist,
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Subjects = []rbacv1.Subject{
+					{Kind: rbacv1.UserKind, Name: "alice"},
+					{Kind: rbacv1.ServiceAccountKind, Name: "system-helper", Namespace: "payments"},
+				}
+			},
+		},
+		{
+			name:     "protected clusterRole",
+			denylist: denylist,
+			mutate:   func(bg *accessv1alpha1.Breakglass) { bg.Spec.ClusterRoles = []string{"edit", "cluster-admin"} },
+			want:     []string{`clusterRole "cluster-admin" is protected`},
+		},
+		{
+			name:     "protected subjects match by prefix",
+			denylist: denylist,
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Subjects = []rbacv1.Subject{
+					{Kind: rbacv1.GroupKind, Name: "system:authenticated"},
+					{Kind: rbacv1.UserKind, Name: "system:admin"},
+					{Kind: rbacv1.UserKind, Name: "systemd"},
+				}
+			},
+			want: []string{
+				`subjects[0]: Group "system:authenticated" is protected`,
+				`subjects[1]: User "system:admin" is protected`,
+			},
+		},
+		{
+			name:     "wildcard rule",
+			denylist: denylist,
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoles = nil
+				bg.Spec.Policy = allRules
+			},
+			want: []string{"policy[0].rules[0] grants all verbs on all resources"},
+		},
+		{
+			name:     "wildcard rule allowed",
+			denylist: config.DenylistConfig{AllowWildcardRules: true},
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoles = nil
+				bg.Spec.Policy = allRules
+			},
+		},
+		{
+			name:     "wildcard rule in a requested clusterRole",
+			denylist: denylist,
+			mutate:   func(bg *accessv1alpha1.Breakglass) { bg.Spec.ClusterRoles = []string{"view", "god-mode"} },
+			roles:    []rbacv1.ClusterRole{viewRole, godMode},
+			want:     []string{`clusterRole "god-mode".rules[1] grants all verbs on all resources`},
+		},
+		{
+			name:     "wildcard clusterRole allowed",
+			denylist: config.DenylistConfig{AllowWildcardRules: true},
+			mutate:   func(bg *accessv1alpha1.Breakglass) { bg.Spec.ClusterRoles = []string{"god-mode"} },
+			roles:    []rbacv1.ClusterRole{godMode},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := newBreakglass()
+			bg.Spec.Subjects = []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}}
+			tt.mutate(bg)
+			assert.Equal(t, tt.want, CheckDenylist(tt.denylist, bg, tt.roles))
+		})
+	}
+}
+
+func TestRequestedClusterRoles(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+	view := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(view).Build()
+
+	bg := newBreakglass()
+	bg.Spec.ClusterRoles = []string{"view", "missing"}
+	roles, err := RequestedClusterRoles(context.Background(), reader, bg)
+	require.NoError(t, err)
+	require.Len(t, roles, 1, "missing ClusterRoles are left to validation")
+	assert.Equal(t, "view", roles[0].Name)
+}
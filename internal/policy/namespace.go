@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Cloud-Nimbus Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"slices"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+// CheckTargetNamespaces returns the reasons a namespaced bg grants access outside its own namespace
+// without an allowance from admitted, the policy that admitted it, or nil when it does not.
+// Cluster-wide grants need allowClusterScope and other namespaces must be listed in
+// allowedTargetNamespaces. A ClusterBreakglass is never restricted.
+func CheckTargetNamespaces(bg *accessv1alpha1.Breakglass, admitted *accessv1alpha1.BreakglassPolicy) []string {
+	if bg.IsClusterScoped() {
+		return nil
+	}
+
+	var allowCluster bool
+	var allowed []string
+	if admitted != nil {
+		allowCluster = admitted.Spec.AllowClusterScope
+		allowed = admitted.Spec.AllowedTargetNamespaces
+	}
+	allowAny := slices.Contains(allowed, wildcard)
+	allows := func(namespace string) bool {
+		return namespace == bg.Namespace || allowAny || slices.Contains(allowed, namespace)
+	}
+
+	var reasons []string
+	if scope := bg.Spec.ClusterRoleScope; len(bg.Spec.ClusterRoles) > 0 {
+		switch {
+		case scope == nil && !allowCluster:
+			reasons = append(reasons, fmt.Sprintf("clusterRoles must be scoped to namespace %q", bg.Namespace))
+		case scope != nil:
+			for _, ns := range scope.Namespaces {
+				if !allows(ns) {
+					reasons = append(reasons, fmt.Sprintf("clusterRoleScope namespace %q is outside namespace %q",
+						ns, bg.Namespace))
+				}
+			}
+			if scope.Selector != nil && !allowAny {
+				reasons = append(reasons, fmt.Sprintf("clusterRoleScope.selector may select namespaces outside %q",
+					bg.Namespace))
+			}
+		}
+	}
+	for i, entry := range bg.Spec.Policy {
+		switch {
+		case entry.Namespace == "" && !allowCluster:
+			reasons = append(reasons, fmt.Sprintf("policy[%d] grants access cluster-wide", i))
+		case entry.Namespace != "" && !allows(entry.Namespace):
+			reasons = append(reasons, fmt.Sprintf("policy[%d] namespace %q is outside namespace %q",
+				i, entry.Namespace, bg.Namespace))
+		}
+	}
+	return reasons
+}
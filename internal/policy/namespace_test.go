@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+func TestCheckTargetNamespaces(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(bg *accessv1alpha1.Breakglass)
+		admitted func(spec *accessv1alpha1.BreakglassPolicySpec)
+		want     []string
+	}{
+		{
+			name:   "own namespace",
+			mutate: func(bg *accessv1alpha1.Breakglass) {},
+		},
+		{
+			name: "unscoped clusterRoles",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoleScope = nil
+			},
+			want: []string{`clusterRoles must be scoped to namespace "payments"`},
+		},
+		{
+			name: "unscoped clusterRoles allowed cluster-wide",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoleScope = nil
+			},
+			admitted: func(spec *accessv1alpha1.BreakglassPolicySpec) { spec.AllowClusterScope = true },
+		},
+		{
+			name: "other namespaces",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoleScope = &accessv1alpha1.NamespaceScope{
+					Namespaces: []string{"payments", "kube-system"},
+					Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				}
+			},
+			want: []string{
+				`clusterRoleScope namespace "kube-system" is outside namespace "payments"`,
+				`clusterRoleScope.selector may select namespaces outside "payments"`,
+			},
+		},
+		{
+			name: "other namespaces allowed",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoleScope.Namespaces = []string{"payments", "payments-batch"}
+			},
+			admitted: func(spec *accessv1alpha1.BreakglassPolicySpec) {
+				spec.AllowedTargetNamespaces = []string{"payments-batch"}
+			},
+		},
+		{
+			name: "selector allowed by wildcard",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoleScope.Selector = &metav1.LabelSelector{}
+			},
+			admitted: func(spec *accessv1alpha1.BreakglassPolicySpec) {
+				spec.AllowedTargetNamespaces = []string{"*"}
+			},
+		},
+		{
+			name: "policy entries",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoles = nil
+				bg.Spec.Policy = []accessv1alpha1.Policy{{Namespace: "payments"}, {}, {Namespace: "kube-system"}}
+			},
+			want: []string{
+				"policy[1] grants access cluster-wide",
+				`policy[2] namespace "kube-system" is outside namespace "payments"`,
+			},
+		},
+		{
+			name: "cluster-scoped request",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Namespace = ""
+				bg.Spec.ClusterRoleScope = nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := newBreakglass()
+			tt.mutate(bg)
+			var admitted *accessv1alpha1.BreakglassPolicy
+			if tt.admitted != nil {
+				p := newPolicy("payments", tt.admitted)
+				admitted = &p
+			}
+			assert.Equal(t, tt.want, CheckTargetNamespaces(bg, admitted))
+		})
+	}
+}
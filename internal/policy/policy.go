@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The Cloud-Nimbus Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy evaluates Breakglass requests against the cluster's BreakglassPolicy guardrails.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+// wildcard matches any value in an RBAC rule field.
+const wildcard = "*"
+
+// Violation lists why a single policy did not admit a request.
+type Violation struct {
+	Policy  string
+	Reasons []string
+}
+
+// ViolationError is returned when no BreakglassPolicy admits a request.
+type ViolationError struct {
+	Namespace  string
+	Violations []Violation
+}
+
+// Error implements error.
+func (e *ViolationError) Error() string {
+	if len(e.Violations) == 0 {
+		return fmt.Sprintf("no BreakglassPolicy governs namespace %q", e.Namespace)
+	}
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		parts = append(parts, fmt.Sprintf("%s: %s", v.Policy, strings.Join(v.Reasons, "; ")))
+	}
+	return "request is not admitted by any BreakglassPolicy: " + strings.Join(parts, ", ")
+}
+
+// Admit lists the BreakglassPolicies and returns the first, by name, that admits bg.
+// When no policies exist nil and no error are returned.
+func Admit(
+	ctx context.Context,
+	reader client.Reader,
+	bg *accessv1alpha1.Breakglass,
+) (*accessv1alpha1.BreakglassPolicy, error) {
+	var list accessv1alpha1.BreakglassPolicyList
+	if err := reader.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("list breakglass policies: %w", err)
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+	return Evaluate(bg, list.Items)
+}
+
+// Evaluate returns the first policy, by name, that governs the namespace of bg and admits it.
+// A *ViolationError is returned when none does.
+func Evaluate(
+	bg *accessv1alpha1.Breakglass,
+	policies []accessv1alpha1.BreakglassPolicy,
+) (*accessv1alpha1.BreakglassPolicy, error) {
+	sorted := slices.Clone(policies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	verr := &ViolationError{Namespace: bg.Namespace}
+	for i := range sorted {
+		p := &sorted[i]
+		if !Governs(p, bg.Namespace) {
+			continue
+		}
+		reasons := Check(p, bg)
+		if len(reasons) == 0 {
+			return p, nil
+		}
+		verr.Violations = append(verr.Violations, Violation{Policy: p.Name, Reasons: reasons})
+	}
+	return nil, verr
+}
+
+// Governs reports whether p applies to Breakglass requests in namespace.
+func Governs(p *accessv1alpha1.BreakglassPolicy, namespace string) bool {
+	return len(p.Spec.Namespaces) == 0 || slices.Contains(p.Spec.Namespaces, namespace)
+}
+
+// Check returns the reasons p does not admit bg, or nil when it does.
+func Check(p *accessv1alpha1.BreakglassPolicy, bg *accessv1alpha1.Breakglass) []string {
+	var reasons []string
+	spec := &p.Spec
+
+	for _, cr := range bg.Spec.ClusterRoles {
+		if !slices.Contains(spec.AllowedClusterRoles, wildcard) && !slices.Contains(spec.AllowedClusterRoles, cr) {
+			reasons = append(reasons, fmt.Sprintf("clusterRole %q is not allowed", cr))
+		}
+	}
+	if len(bg.Spec.ClusterRoles) > 0 && bg.Spec.ClusterRoleScope == nil && !spec.AllowClusterScope {
+		reasons = append(reasons, "clusterRoles must be scoped to namespaces")
+	}
+	for i, entry := range bg.Spec.Policy {
+		if entry.Namespace == "" && !spec.AllowClusterScope {
+			reasons = append(reasons, fmt.Sprintf("policy[%d] must set a namespace", i))
+		}
+		for j, rule := range entry.Rules {
+			if !Covers(spec.AllowedRules, rule) {
+				reasons = append(reasons, fmt.Sprintf("policy[%d].rules[%d] is not covered by the allowed rules", i, j))
+			}
+		}
+	}
+
+	if spec.MaxDuration != nil {
+		duration := totalDuration(bg)
+		if duration <= 0 || duration > spec.MaxDuration.Duration {
+			reasons = append(reasons, fmt.Sprintf("duration %s exceeds the maximum of %s",
+				durationString(duration), spec.MaxDuration.Duration))
+		}
+	}
+	if spec.MaxActivations != nil && bg.Spec.Schedule.Cron != "" {
+		maxActivations := bg.Spec.Schedule.MaxActivations
+		if maxActivations == nil || *maxActivations > *spec.MaxActivations {
+			reasons = append(reasons, fmt.Sprintf("maxActivations must be set to at most %d", *spec.MaxActivations))
+		}
+	}
+
+	if len(spec.RequesterGroups) > 0 && !inAnyGroup(bg, spec.RequesterGroups) {
+		reasons = append(reasons, "requester is not a member of an eligible group")
+	}
+	if spec.RequireApproval && (bg.Spec.Approval == nil || !bg.Spec.Approval.Required) {
+		reasons = append(reasons, "approval is required")
+	}
+	return reasons
+}
+
+// Covers reports whether rule is fully covered by one of allowed.
+func Covers(allowed []rbacv1.PolicyRule, rule rbacv1.PolicyRule) bool {
+	for _, a := range allowed {
+		if covers(a, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func covers(allowed, rule rbacv1.PolicyRule) bool {
+	if !subset(allowed.Verbs, rule.Verbs) ||
+		!subset(allowed.APIGroups, rule.APIGroups) ||
+		!subset(allowed.Resources, rule.Resources) {
+		return false
+	}
+	// An unrestricted request is only covered by an unrestricted allowed rule
+	if len(allowed.ResourceNames) > 0 &&
+		(len(rule.ResourceNames) == 0 || !subset(allowed.ResourceNames, rule.ResourceNames)) {
+		return false
+	}
+	for _, url := range rule.NonResourceURLs {
+		if !nonResourceURLAllowed(allowed.NonResourceURLs, url) {
+			return false
+		}
+	}
+	return true
+}
+
+// subset reports whether every requested value is allowed, treating "*" as a wildcard on either side.
+func subset(allowed, requested []string) bool {
+	if slices.Contains(allowed, wildcard) {
+		return true
+	}
+	for _, r := range requested {
+		if r == wildcard || !slices.Contains(allowed, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func nonResourceURLAllowed(allowed []string, url string) bool {
+	for _, a := range allowed {
+		if a == url || a == wildcard {
+			return true
+		}
+		if strings.HasSuffix(a, wildcard) && strings.HasPrefix(url, strings.TrimSuffix(a, wildcard)) {
+			return true
+		}
+	}
+	return false
+}
+
+// totalDuration is the requested window length plus every requested extension.
+func totalDuration(bg *accessv1alpha1.Breakglass) time.Duration {
+	duration := bg.Spec.Schedule.Duration.Duration
+	if duration <= 0 {
+		return 0
+	}
+	for _, ext := range bg.Spec.Extensions {
+		duration += ext.Duration.Duration
+	}
+	return duration
+}
+
+func durationString(d time.Duration) string {
+	if d <= 0 {
+		return "unbounded"
+	}
+	return d.String()
+}
+
+// inAnyGroup reports whether the stamped requester belongs to one of groups.
+func inAnyGroup(bg *accessv1alpha1.Breakglass, groups []string) bool {
+	stamped := bg.Annotations[accessv1alpha1.RequestedByGroupsAnnotation]
+	if stamped == "" {
+		return false
+	}
+	for _, g := range strings.Split(stamped, ",") {
+		if slices.Contains(groups, g) {
+			return true
+		}
+	}
+	return false
+}
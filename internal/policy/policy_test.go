@@ -0,0 +1,205 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+func newBreakglass() *accessv1alpha1.Breakglass {
+	return &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "bg",
+			Namespace:   "payments",
+			Annotations: map[string]string{accessv1alpha1.RequestedByGroupsAnnotation: "dev,sre"},
+		},
+		Spec: accessv1alpha1.BreakglassSpec{
+			ClusterRoles:     []string{"edit"},
+			ClusterRoleScope: &accessv1alpha1.NamespaceScope{Namespaces: []string{"payments"}},
+			Approval:         &accessv1alpha1.ApprovalSpec{Required: true},
+			Schedule:         accessv1alpha1.ScheduleSpec{Duration: metav1.Duration{Duration: time.Hour}},
+		},
+	}
+}
+
+func newPolicy(name string, mutate func(spec *accessv1alpha1.BreakglassPolicySpec)) accessv1alpha1.BreakglassPolicy {
+	p := accessv1alpha1.BreakglassPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: accessv1alpha1.BreakglassPolicySpec{
+			AllowedClusterRoles: []string{"view", "edit"},
+			AllowedRules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"pods", "pods/log"},
+				Verbs:     []string{"get", "list"},
+			}},
+			MaxDuration:     &metav1.Duration{Duration: 2 * time.Hour},
+			RequesterGroups: []string{"sre"},
+			RequireApproval: true,
+		},
+	}
+	if mutate != nil {
+		mutate(&p.Spec)
+	}
+	return p
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(bg *accessv1alpha1.Breakglass)
+		wantErr string
+	}{
+		{
+			name:   "admitted",
+			mutate: func(bg *accessv1alpha1.Breakglass) {},
+		},
+		{
+			name: "cluster role not allowed",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoles = []string{"cluster-admin"}
+			},
+			wantErr: `clusterRole "cluster-admin" is not allowed`,
+		},
+		{
+			name: "unscoped cluster role",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoleScope = nil
+			},
+			wantErr: "clusterRoles must be scoped to namespaces",
+		},
+		{
+			name: "covered rule",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoles, bg.Spec.ClusterRoleScope = nil, nil
+				bg.Spec.Policy = []accessv1alpha1.Policy{{Namespace: "payments", Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""}, Resources: []string{"pods/log"}, Verbs: []string{"get"},
+				}}}}
+			},
+		},
+		{
+			name: "rule not covered",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoles, bg.Spec.ClusterRoleScope = nil, nil
+				bg.Spec.Policy = []accessv1alpha1.Policy{{Namespace: "payments", Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"*"},
+				}}}}
+			},
+			wantErr: "policy[0].rules[0] is not covered",
+		},
+		{
+			name: "cluster-scoped rule",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoles, bg.Spec.ClusterRoleScope = nil, nil
+				bg.Spec.Policy = []accessv1alpha1.Policy{{Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"},
+				}}}}
+			},
+			wantErr: "policy[0] must set a namespace",
+		},
+		{
+			name: "duration too long with extensions",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Extensions = []accessv1alpha1.ExtensionRequest{
+					{Name: "a", Duration: metav1.Duration{Duration: 90 * time.Minute}},
+				}
+			},
+			wantErr: "exceeds the maximum of 2h0m0s",
+		},
+		{
+			name: "requester not eligible",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Annotations[accessv1alpha1.RequestedByGroupsAnnotation] = "dev"
+			},
+			wantErr: "eligible group",
+		},
+		{
+			name: "approval required",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Approval = nil
+			},
+			wantErr: "approval is required",
+		},
+		{
+			name: "recurring without maxActivations",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Schedule.Cron = "0 2 * * *"
+			},
+			wantErr: "maxActivations must be set to at most 5",
+		},
+	}
+
+	p := newPolicy("payments", func(spec *accessv1alpha1.BreakglassPolicySpec) {
+		spec.MaxActivations = ptr(int32(5))
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bg := newBreakglass()
+			tt.mutate(bg)
+
+			reasons := Check(&p, bg)
+			if tt.wantErr == "" {
+				assert.Empty(t, reasons)
+				return
+			}
+			require.NotEmpty(t, reasons)
+			assert.Contains(t, reasons[0], tt.wantErr)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	bg := newBreakglass()
+	strict := newPolicy("a-strict", func(spec *accessv1alpha1.BreakglassPolicySpec) {
+		spec.AllowedClusterRoles = []string{"view"}
+	})
+	other := newPolicy("b-other", func(spec *accessv1alpha1.BreakglassPolicySpec) {
+		spec.Namespaces = []string{"billing"}
+	})
+	lenient := newPolicy("c-lenient", nil)
+
+	admitted, err := Evaluate(bg, []accessv1alpha1.BreakglassPolicy{lenient, other, strict})
+	require.NoError(t, err)
+	assert.Equal(t, "c-lenient", admitted.Name)
+
+	_, err = Evaluate(bg, []accessv1alpha1.BreakglassPolicy{strict, other})
+	var verr *ViolationError
+	require.True(t, errors.As(err, &verr))
+	require.Len(t, verr.Violations, 1, "policies for other namespaces are not evaluated")
+	assert.Equal(t, "a-strict", verr.Violations[0].Policy)
+
+	_, err = Evaluate(bg, []accessv1alpha1.BreakglassPolicy{other})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no BreakglassPolicy governs namespace "payments"`)
+}
+
+func TestAdmit_NoPolicies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	admitted, err := Admit(context.Background(), c, newBreakglass())
+	require.NoError(t, err)
+	assert.Nil(t, admitted)
+
+	p := newPolicy("payments", nil)
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(client.Object(&p)).Build()
+	admitted, err = Admit(context.Background(), c, newBreakglass())
+	require.NoError(t, err)
+	require.NotNil(t, admitted)
+	assert.Equal(t, "payments", admitted.Name)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
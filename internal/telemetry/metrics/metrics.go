@@ -31,6 +31,10 @@ const (
 	MetricAlertsSentTotal   = "firedoor_alerts_sent_total"
 	MetricAlertSendDuration = "firedoor_alert_send_duration_seconds"
 	MetricAlertSendErrors   = "firedoor_alert_send_errors_total"
+
+	// Drift
+	MetricRBACDriftTotal          = "firedoor_rbac_drift_total"
+	MetricRBACOrphansRemovedTotal = "firedoor_rbac_orphans_removed_total"
 )
 
 // Label names – ALL BOUNDED ENUMS
@@ -51,6 +55,12 @@ const (
 	LAlertType = "alert_type" // active|expired
 	LSeverity  = "severity"   // warning|critical|info
 
+	// drift
+	LKind   = "kind"   // Role|RoleBinding|ClusterRole|ClusterRoleBinding
+	LChange = "change" // missing|modified|stray
+	LAction = "action" // repair|revoke|remove
+	LOwner  = "owner"  // missing|inactive
+
 	// reconcile_duration seconds histogram needs no extra labels
 	RoleUnknown = "unknown"
 )
@@ -72,6 +82,10 @@ var (
 	alertSendDuration *prometheus.HistogramVec
 	alertSendErrors   *prometheus.CounterVec
 
+	// drift
+	rbacDriftTotal          *prometheus.CounterVec
+	rbacOrphansRemovedTotal *prometheus.CounterVec
+
 	initOnce sync.Once
 )
 
@@ -145,11 +159,25 @@ func register(cfg *config.Config) {
 		[]string{LAlertType, LSeverity, LNamespaceBucket},
 	)
 
+	// --- drift ---------------------------------------------------------------
+	rbacDriftTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: MetricRBACDriftTotal, Help: "Granted RBAC objects found edited, deleted or stray"},
+		[]string{LKind, LChange, LAction},
+	)
+	rbacOrphansRemovedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricRBACOrphansRemovedTotal,
+			Help: "RBAC objects deleted because their Breakglass is gone or outside an active window",
+		},
+		[]string{LKind, LOwner},
+	)
+
 	collectors := []prometheus.Collector{
 		stateTotal, activeGauge, durationHist,
 		operationsTotal, reconcileDuration,
 		recurringActivationTotal, recurringExpirationTotal, recurringActiveGauge,
 		alertsSentTotal, alertSendDuration, alertSendErrors,
+		rbacDriftTotal, rbacOrphansRemovedTotal,
 	}
 	metrics.Registry.MustRegister(collectors...)
 }
@@ -205,6 +233,26 @@ func RecordAlertSendError(alertType, severity, namespace string) {
 	alertSendErrors.WithLabelValues(alertType, severity, nb).Inc()
 }
 
+// Drift helpers --------------------------------------------------------------------
+
+// RecordRBACDrift counts a drifted RBAC object and what was done about it. It is a no-op until Init has run.
+func RecordRBACDrift(kind, change, action string) {
+	if rbacDriftTotal == nil {
+		return
+	}
+	rbacDriftTotal.WithLabelValues(kind, change, action).Inc()
+}
+
+// RecordRBACOrphanRemoved counts an RBAC object removed by the orphan collector. owner is "missing" when
+// its Breakglass no longer exists and "inactive" when it is outside an active window.
+// It is a no-op until Init has run.
+func RecordRBACOrphanRemoved(kind, owner string) {
+	if rbacOrphansRemovedTotal == nil {
+		return
+	}
+	rbacOrphansRemovedTotal.WithLabelValues(kind, owner).Inc()
+}
+
 // -----------------------------------------------------------------------------
 //  Bucketing helpers  (keep cardinality ≤ 16)
 // -----------------------------------------------------------------------------
@@ -0,0 +1,78 @@
+package ticket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+// FileProvider reads ticket statuses from a YAML or JSON file mapping ticket IDs to their status.
+// The file is read on every lookup, so editing it opens and closes tickets. Comments are kept in
+// memory. It is meant for tests and development.
+type FileProvider struct {
+	path   string
+	closed statusSet
+
+	mu       sync.Mutex
+	comments map[string][]string
+}
+
+var _ controller.TicketProvider = &FileProvider{}
+
+// NewFileProvider creates a FileProvider reading path.
+func NewFileProvider(path string, closedStatuses []string) *FileProvider {
+	return &FileProvider{
+		path:     path,
+		closed:   newStatusSet(closedStatuses),
+		comments: make(map[string][]string),
+	}
+}
+
+// IsOpen implements controller.TicketProvider.
+func (p *FileProvider) IsOpen(_ context.Context, id string) (bool, error) {
+	status, err := p.status(id)
+	if err != nil {
+		return false, err
+	}
+	return !p.closed.has(status), nil
+}
+
+// Comment implements controller.TicketProvider.
+func (p *FileProvider) Comment(_ context.Context, id, body string) error {
+	if _, err := p.status(id); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.comments[id] = append(p.comments[id], body)
+	return nil
+}
+
+// Comments returns the comments posted to ticket id so far.
+func (p *FileProvider) Comments(id string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.comments[id]...)
+}
+
+func (p *FileProvider) status(id string) (string, error) {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", fmt.Errorf("reading ticket file: %w", err)
+	}
+	tickets := map[string]string{}
+	if err := yaml.Unmarshal(data, &tickets); err != nil {
+		return "", fmt.Errorf("parsing ticket file %s: %w", p.path, err)
+	}
+	status, ok := tickets[id]
+	if !ok {
+		return "", internalerrors.NewTicketNotFoundError(id)
+	}
+	return status, nil
+}
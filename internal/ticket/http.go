@@ -0,0 +1,126 @@
+package ticket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/cloud-nimbus/firedoor/internal/config"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+// HTTPProvider talks to a ticket system with a JSON API. A ticket is read with a GET of the ticket
+// URL and its status taken from the configured field. Comments are POSTed as {"body": "..."}.
+type HTTPProvider struct {
+	client      *http.Client
+	ticketURL   string
+	commentURL  string
+	statusField []string
+	closed      statusSet
+	token       string
+	basicAuth   config.BasicAuthConfig
+}
+
+var _ controller.TicketProvider = &HTTPProvider{}
+
+// NewHTTPProvider creates an HTTPProvider. The bearer token, if configured, is read once here.
+func NewHTTPProvider(cfg config.TicketHTTPConfig, closedStatuses []string) (*HTTPProvider, error) {
+	p := &HTTPProvider{
+		client:      &http.Client{Timeout: cfg.Timeout},
+		ticketURL:   cfg.URL,
+		commentURL:  cfg.CommentURL,
+		statusField: strings.Split(cfg.StatusField, "."),
+		closed:      newStatusSet(closedStatuses),
+		basicAuth:   cfg.BasicAuth,
+	}
+	if cfg.TokenFile != "" {
+		token, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading ticket token: %w", err)
+		}
+		p.token = strings.TrimSpace(string(token))
+	}
+	return p, nil
+}
+
+// IsOpen implements controller.TicketProvider.
+func (p *HTTPProvider) IsOpen(ctx context.Context, id string) (bool, error) {
+	var ticket map[string]any
+	if err := p.do(ctx, http.MethodGet, p.ticketURL, id, nil, &ticket); err != nil {
+		return false, err
+	}
+
+	var value any = ticket
+	for _, key := range p.statusField {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			value = nil
+			break
+		}
+		value = obj[key]
+	}
+	status, ok := value.(string)
+	if !ok {
+		return false, fmt.Errorf("ticket %s has no string field %s", id, strings.Join(p.statusField, "."))
+	}
+	return !p.closed.has(status), nil
+}
+
+// Comment implements controller.TicketProvider. It does nothing when no comment URL is configured.
+func (p *HTTPProvider) Comment(ctx context.Context, id, body string) error {
+	if p.commentURL == "" {
+		return nil
+	}
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+	return p.do(ctx, http.MethodPost, p.commentURL, id, payload, nil)
+}
+
+// do sends a request to urlTemplate for ticket id and decodes the response into out, if set.
+func (p *HTTPProvider) do(ctx context.Context, method, urlTemplate, id string, payload []byte, out any) error {
+	target := strings.ReplaceAll(urlTemplate, "{id}", url.PathEscape(id))
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case p.token != "":
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	case p.basicAuth.Username != "":
+		req.SetBasicAuth(p.basicAuth.Username, p.basicAuth.Password)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s ticket %s: %w", method, id, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return internalerrors.NewTicketNotFoundError(id)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s ticket %s: unexpected status %s: %s", method, id, resp.Status, bytes.TrimSpace(msg))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding ticket %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Package ticket implements the ticket systems spec.ticketID is checked against.
+package ticket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloud-nimbus/firedoor/internal/config"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+)
+
+// New builds the provider selected by cfg. It returns nil when no provider is configured.
+func New(cfg config.TicketConfig) (controller.TicketProvider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case "http":
+		p, err := NewHTTPProvider(cfg.HTTP, cfg.ClosedStatuses)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	case "file":
+		return NewFileProvider(cfg.File.Path, cfg.ClosedStatuses), nil
+	default:
+		return nil, fmt.Errorf("unknown ticket provider %q", cfg.Provider)
+	}
+}
+
+// statusSet matches ticket statuses case-insensitively.
+type statusSet map[string]struct{}
+
+func newStatusSet(statuses []string) statusSet {
+	set := make(statusSet, len(statuses))
+	for _, s := range statuses {
+		set[strings.ToLower(strings.TrimSpace(s))] = struct{}{}
+	}
+	return set
+}
+
+func (s statusSet) has(status string) bool {
+	_, ok := s[strings.ToLower(strings.TrimSpace(status))]
+	return ok
+}
@@ -0,0 +1,106 @@
+package ticket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloud-nimbus/firedoor/internal/config"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+)
+
+var closedStatuses = []string{"closed", "Resolved"}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tickets.yaml")
+	if err := os.WriteFile(path, []byte("INC-1: open\nINC-2: resolved\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p := NewFileProvider(path, closedStatuses)
+	ctx := context.Background()
+
+	if open, err := p.IsOpen(ctx, "INC-1"); err != nil || !open {
+		t.Errorf("IsOpen(INC-1) = %v, %v; want open", open, err)
+	}
+	if open, err := p.IsOpen(ctx, "INC-2"); err != nil || open {
+		t.Errorf("IsOpen(INC-2) = %v, %v; want closed", open, err)
+	}
+	if _, err := p.IsOpen(ctx, "INC-3"); !internalerrors.IsTicketNotFoundError(err) {
+		t.Errorf("IsOpen(INC-3) error = %v, want TicketNotFoundError", err)
+	}
+
+	if err := p.Comment(ctx, "INC-1", "granted"); err != nil {
+		t.Fatalf("Comment: %v", err)
+	}
+	if got := p.Comments("INC-1"); len(got) != 1 || got[0] != "granted" {
+		t.Errorf("Comments(INC-1) = %v, want [granted]", got)
+	}
+
+	// the file is read on every lookup
+	if err := os.WriteFile(path, []byte("INC-1: closed\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if open, err := p.IsOpen(ctx, "INC-1"); err != nil || open {
+		t.Errorf("IsOpen(INC-1) after closing = %v, %v; want closed", open, err)
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	var comments []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /tickets/INC-1":
+			_, _ = w.Write([]byte(`{"key": "INC-1", "fields": {"status": {"name": "In Progress"}}}`))
+		case "GET /tickets/INC-2":
+			_, _ = w.Write([]byte(`{"key": "INC-2", "fields": {"status": {"name": "Closed"}}}`))
+		case "POST /tickets/INC-1/comments":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			comments = append(comments, body["body"])
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewHTTPProvider(config.TicketHTTPConfig{
+		URL:         srv.URL + "/tickets/{id}",
+		CommentURL:  srv.URL + "/tickets/{id}/comments",
+		StatusField: "fields.status.name",
+		TokenFile:   tokenFile,
+	}, closedStatuses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if open, err := p.IsOpen(ctx, "INC-1"); err != nil || !open {
+		t.Errorf("IsOpen(INC-1) = %v, %v; want open", open, err)
+	}
+	if open, err := p.IsOpen(ctx, "INC-2"); err != nil || open {
+		t.Errorf("IsOpen(INC-2) = %v, %v; want closed", open, err)
+	}
+	if _, err := p.IsOpen(ctx, "INC-3"); !internalerrors.IsTicketNotFoundError(err) {
+		t.Errorf("IsOpen(INC-3) error = %v, want TicketNotFoundError", err)
+	}
+
+	if err := p.Comment(ctx, "INC-1", "granted"); err != nil {
+		t.Fatalf("Comment: %v", err)
+	}
+	if len(comments) != 1 || comments[0] != "granted" {
+		t.Errorf("comments = %v, want [granted]", comments)
+	}
+}
@@ -0,0 +1,605 @@
+/*
+Copyright 2024 The Cloud-Nimbus Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	cronv3 "github.com/robfig/cron/v3"
+	admissionv1 "k8s.io/api/admission/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/config"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+	"github.com/cloud-nimbus/firedoor/internal/controller/breakglass/usecases"
+	internalerrors "github.com/cloud-nimbus/firedoor/internal/errors"
+	"github.com/cloud-nimbus/firedoor/internal/policy"
+)
+
+var breakglasslog = logf.Log.WithName("breakglass-resource")
+
+var breakglassGK = accessv1alpha1.GroupVersion.WithKind("Breakglass").GroupKind()
+
+// SetupBreakglassWebhookWithManager registers the webhook for Breakglass in the manager.
+// v1alpha1 is the conversion hub, so this also serves /convert for v1beta1 once it is in the scheme.
+func SetupBreakglassWebhookWithManager(
+	mgr ctrl.Manager,
+	cfg *config.Config,
+	operator controller.BreakglassOperator,
+) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&accessv1alpha1.Breakglass{}).
+		WithDefaulter(&BreakglassCustomDefaulter{Defaults: cfg.Breakglass}).
+		WithValidator(&BreakglassCustomValidator{
+			Operator:            operator,
+			Policies:            mgr.GetClient(),
+			Denylist:            cfg.Denylist,
+			ClusterRoles:        mgr.GetAPIReader(),
+			RestrictToNamespace: cfg.Controller.RestrictToNamespace,
+			RequireTicket:       cfg.Tickets.Provider != "" && cfg.Tickets.Required,
+			Approvals:           mgr.GetAPIReader(),
+		}).
+		Complete()
+}
+
+//nolint:lll
+// +kubebuilder:webhook:path=/mutate-access-cloudnimbus-io-v1alpha1-breakglass,mutating=true,failurePolicy=fail,sideEffects=None,groups=access.cloudnimbus.io,resources=breakglasses,verbs=create;update,versions=v1alpha1,name=mbreakglass-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BreakglassCustomDefaulter stamps the requester identity and fills configured defaults on new requests,
+// and stamps the revoker or extension requester identity when a revocation or extension is added.
+type BreakglassCustomDefaulter struct {
+	Defaults config.BreakglassConfig
+}
+
+var _ webhook.CustomDefaulter = &BreakglassCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter.
+// Any requester, revoker or extension requester identity supplied by the client is overwritten
+// with the authenticated user.
+func (d *BreakglassCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	bg, ok := obj.(*accessv1alpha1.Breakglass)
+	if !ok {
+		return fmt.Errorf("expected a Breakglass object but got %T", obj)
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("read admission request: %w", err)
+	}
+
+	switch req.Operation {
+	case admissionv1.Create:
+		d.stampRequester(bg, req)
+		d.applyDefaults(bg)
+	case admissionv1.Update:
+		var oldBg accessv1alpha1.Breakglass
+		if err := json.Unmarshal(req.OldObject.Raw, &oldBg); err != nil {
+			return fmt.Errorf("decode old Breakglass: %w", err)
+		}
+		if bg.Spec.Revocation != nil && oldBg.Spec.Revocation == nil {
+			breakglasslog.Info("stamping revoker", "name", bg.GetName(), "username", req.UserInfo.Username)
+			bg.Spec.Revocation.RevokedBy = userIdentity(req)
+		}
+		d.stampExtensionRequesters(&oldBg, bg, req)
+	}
+	return nil
+}
+
+// stampRequester records the authenticated user in the requester annotations.
+func (d *BreakglassCustomDefaulter) stampRequester(bg *accessv1alpha1.Breakglass, req admission.Request) {
+	breakglasslog.Info("stamping requester", "name", bg.GetName(), "username", req.UserInfo.Username)
+	if bg.Annotations == nil {
+		bg.Annotations = map[string]string{}
+	}
+	bg.Annotations[accessv1alpha1.RequestedByAnnotation] = req.UserInfo.Username
+	bg.Annotations[accessv1alpha1.RequestedByUIDAnnotation] = req.UserInfo.UID
+	bg.Annotations[accessv1alpha1.RequestedByGroupsAnnotation] = strings.Join(req.UserInfo.Groups, ",")
+}
+
+// stampExtensionRequesters records the authenticated user on extension requests not present in oldBg.
+func (d *BreakglassCustomDefaulter) stampExtensionRequesters(
+	oldBg, bg *accessv1alpha1.Breakglass,
+	req admission.Request,
+) {
+	for i := range bg.Spec.Extensions {
+		ext := &bg.Spec.Extensions[i]
+		if findExtension(oldBg.Spec.Extensions, ext.Name) != nil {
+			continue
+		}
+		breakglasslog.Info("stamping extension requester",
+			"name", bg.GetName(), "extension", ext.Name, "username", req.UserInfo.Username)
+		ext.RequestedBy = userIdentity(req)
+	}
+}
+
+// userIdentity converts the admission user info into a UserIdentity.
+func userIdentity(req admission.Request) *accessv1alpha1.UserIdentity {
+	return &accessv1alpha1.UserIdentity{
+		Username: req.UserInfo.Username,
+		UID:      req.UserInfo.UID,
+		Groups:   req.UserInfo.Groups,
+	}
+}
+
+// applyDefaults fills unset schedule and approval fields from configuration.
+func (d *BreakglassCustomDefaulter) applyDefaults(bg *accessv1alpha1.Breakglass) {
+	if bg.Spec.Schedule.Duration.Duration == 0 && d.Defaults.DefaultDuration > 0 {
+		bg.Spec.Schedule.Duration = metav1.Duration{Duration: d.Defaults.DefaultDuration}
+	}
+	if bg.Spec.Schedule.Location == "" && d.Defaults.DefaultLocation != "" {
+		bg.Spec.Schedule.Location = d.Defaults.DefaultLocation
+	}
+	if bg.Spec.Approval == nil && d.Defaults.ApprovalRequired {
+		bg.Spec.Approval = &accessv1alpha1.ApprovalSpec{Required: true}
+	}
+}
+
+//nolint:lll
+// +kubebuilder:webhook:path=/validate-access-cloudnimbus-io-v1alpha1-breakglass,mutating=false,failurePolicy=fail,sideEffects=None,groups=access.cloudnimbus.io,resources=breakglasses,verbs=create;update,versions=v1alpha1,name=vbreakglass-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BreakglassCustomValidator rejects invalid Breakglass requests at admission
+// instead of leaving them to fail during reconcile.
+type BreakglassCustomValidator struct {
+	Operator controller.BreakglassOperator
+	// Policies reads the BreakglassPolicy guardrails. Policies are not enforced when nil.
+	Policies client.Reader
+	// Denylist lists the protected ClusterRoles, subjects and wildcard rules to refuse.
+	Denylist config.DenylistConfig
+	// ClusterRoles reads the requested ClusterRoles, whose rules are checked for wildcards like those of
+	// spec.policy. It should bypass the cache, which only holds the RBAC objects firedoor created.
+	// Requested ClusterRoles are not checked for wildcards when nil.
+	ClusterRoles client.Reader
+	// RestrictToNamespace refuses namespaced requests granting access outside their own namespace
+	// unless the admitting BreakglassPolicy allows it.
+	RestrictToNamespace bool
+	// RequireTicket refuses requests without spec.ticketID. Whether the ticket is open is checked
+	// by the controller before access is granted.
+	RequireTicket bool
+	// Approvals reads the BreakglassApprovals recorded for a request; once there are any its spec is
+	// locked, so approvals are never counted against a spec the approvers did not see. Specs stay
+	// editable until approval when nil.
+	Approvals client.Reader
+	// ClusterApprovalNamespace is the namespace approvals for ClusterBreakglass requests are read from.
+	ClusterApprovalNamespace string
+}
+
+var _ webhook.CustomValidator = &BreakglassCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *BreakglassCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	bg, ok := obj.(*accessv1alpha1.Breakglass)
+	if !ok {
+		return nil, fmt.Errorf("expected a Breakglass object but got %T", obj)
+	}
+	breakglasslog.V(1).Info("validating create", "name", bg.GetName())
+
+	if bg.Spec.Revocation != nil {
+		return nil, apierrors.NewInvalid(breakglassGK, bg.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec", "revocation"), "a request cannot be revoked on creation"),
+		})
+	}
+	if len(bg.Spec.Extensions) > 0 {
+		return nil, apierrors.NewInvalid(breakglassGK, bg.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec", "extensions"), "a request cannot be extended on creation"),
+		})
+	}
+	return nil, v.validate(ctx, bg)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+// Once a request has been approved its spec can no longer change, except for adding a revocation,
+// appending extensions while access is active, or changing its grants when its policy allows it.
+// Before that the spec is locked as soon as any approval has been recorded for the request.
+func (v *BreakglassCustomValidator) ValidateUpdate(
+	ctx context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldBg, ok := oldObj.(*accessv1alpha1.Breakglass)
+	if !ok {
+		return nil, fmt.Errorf("expected a Breakglass object but got %T", oldObj)
+	}
+	newBg, ok := newObj.(*accessv1alpha1.Breakglass)
+	if !ok {
+		return nil, fmt.Errorf("expected a Breakglass object but got %T", newObj)
+	}
+	breakglasslog.V(1).Info("validating update", "name", newBg.GetName())
+
+	if allErrs := validateRequesterUnchanged(oldBg, newBg); len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(breakglassGK, newBg.Name, allErrs)
+	}
+
+	// Never block finalizer removal on an object that is going away.
+	if !newBg.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	allErrs := validateRevocation(oldBg, newBg)
+	allErrs = append(allErrs, validateExtensions(oldBg, newBg)...)
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(breakglassGK, newBg.Name, allErrs)
+	}
+
+	oldSpec, newSpec := oldBg.Spec.DeepCopy(), newBg.Spec.DeepCopy()
+	oldSpec.Revocation, newSpec.Revocation = nil, nil
+	oldSpec.Extensions, newSpec.Extensions = nil, nil
+	if equality.Semantic.DeepEqual(oldSpec, newSpec) {
+		if len(newBg.Spec.Extensions) > len(oldBg.Spec.Extensions) {
+			return nil, v.validatePolicy(ctx, newBg)
+		}
+		return nil, nil
+	}
+	if isApproved(oldBg) {
+		if !onlyGrantsChanged(oldSpec, newSpec) {
+			return nil, apierrors.NewInvalid(breakglassGK, newBg.Name, field.ErrorList{
+				field.Forbidden(field.NewPath("spec"), "spec cannot be changed after the request has been approved"),
+			})
+		}
+		if err := v.validateGrantChange(ctx, newBg); err != nil {
+			return nil, err
+		}
+	} else if err := v.validateNoApprovals(ctx, oldBg); err != nil {
+		return nil, err
+	}
+	return nil, v.validate(ctx, newBg)
+}
+
+// validateNoApprovals refuses spec changes to bg once an approval has been recorded for it.
+// Approvals only carry the identity of the request, so counting them against an edited spec
+// would grant access nobody approved.
+func (v *BreakglassCustomValidator) validateNoApprovals(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	if v.Approvals == nil {
+		return nil
+	}
+	namespace, kind := bg.Namespace, accessv1alpha1.RefKindBreakglass
+	if bg.IsClusterScoped() {
+		if v.ClusterApprovalNamespace == "" {
+			return nil
+		}
+		namespace, kind = v.ClusterApprovalNamespace, accessv1alpha1.RefKindClusterBreakglass
+	}
+
+	var list accessv1alpha1.BreakglassApprovalList
+	if err := v.Approvals.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return apierrors.NewInternalError(fmt.Errorf("list approvals: %w", err))
+	}
+	for _, approval := range list.Items {
+		refKind := approval.Spec.BreakglassKind
+		if refKind == "" {
+			refKind = accessv1alpha1.RefKindBreakglass
+		}
+		if approval.Spec.BreakglassRef != bg.Name || refKind != kind || approval.Spec.BreakglassUID != bg.UID {
+			continue
+		}
+		return apierrors.NewInvalid(breakglassGK, bg.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), fmt.Sprintf(
+				"spec cannot be changed once approvals have been recorded (BreakglassApproval %q); "+
+					"create a new request instead", approval.Name)),
+		})
+	}
+	return nil
+}
+
+// validateGrantChange refuses changes to the grants of an approved request unless the BreakglassPolicy
+// admitting the changed request sets allowGrantChanges.
+func (v *BreakglassCustomValidator) validateGrantChange(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	var admitted *accessv1alpha1.BreakglassPolicy
+	if v.Policies != nil {
+		var err error
+		if admitted, err = policy.Admit(ctx, v.Policies, bg); err != nil {
+			return apierrors.NewInvalid(breakglassGK, bg.Name, field.ErrorList{
+				field.Forbidden(field.NewPath("spec"), err.Error()),
+			})
+		}
+	}
+	if admitted == nil || !admitted.Spec.AllowGrantChanges {
+		return apierrors.NewInvalid(breakglassGK, bg.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), "subjects, clusterRoles, clusterRoleScope and policy cannot be "+
+				"changed after approval unless the admitting BreakglassPolicy sets allowGrantChanges"),
+		})
+	}
+	return nil
+}
+
+// onlyGrantsChanged reports whether the specs differ in nothing but the subjects and roles they grant.
+func onlyGrantsChanged(oldSpec, newSpec *accessv1alpha1.BreakglassSpec) bool {
+	oldRest, newRest := oldSpec.DeepCopy(), newSpec.DeepCopy()
+	for _, spec := range []*accessv1alpha1.BreakglassSpec{oldRest, newRest} {
+		spec.Subjects, spec.ClusterRoles, spec.ClusterRoleScope, spec.Policy = nil, nil, nil, nil
+	}
+	return equality.Semantic.DeepEqual(oldRest, newRest)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *BreakglassCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate runs the field checks and, if they pass, the operator's access validation
+// and the BreakglassPolicy guardrails.
+func (v *BreakglassCustomValidator) validate(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	allErrs := validateBreakglassSpec(&bg.Spec, field.NewPath("spec"))
+	if v.RequireTicket && bg.Spec.TicketID == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "ticketID"), "a ticket is required"))
+	}
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(breakglassGK, bg.Name, allErrs)
+	}
+	if v.Operator != nil {
+		if err := v.Operator.ValidateAccess(ctx, bg); err != nil {
+			return apierrors.NewInvalid(breakglassGK, bg.Name, field.ErrorList{
+				field.Forbidden(field.NewPath("spec"), err.Error()),
+			})
+		}
+	}
+	return v.validatePolicy(ctx, bg)
+}
+
+// validatePolicy refuses requests that no BreakglassPolicy admits, requests targeting
+// denylisted access unless the admitting policy sets allowProtected, and, when restricted,
+// requests granting access outside their namespace without an allowance.
+func (v *BreakglassCustomValidator) validatePolicy(ctx context.Context, bg *accessv1alpha1.Breakglass) error {
+	var admitted *accessv1alpha1.BreakglassPolicy
+	if v.Policies != nil {
+		var err error
+		if admitted, err = policy.Admit(ctx, v.Policies, bg); err != nil {
+			return apierrors.NewInvalid(breakglassGK, bg.Name, field.ErrorList{
+				field.Forbidden(field.NewPath("spec"), err.Error()),
+			})
+		}
+	}
+	var clusterRoles []rbacv1.ClusterRole
+	if v.ClusterRoles != nil && !v.Denylist.AllowWildcardRules {
+		var err error
+		if clusterRoles, err = policy.RequestedClusterRoles(ctx, v.ClusterRoles, bg); err != nil {
+			return apierrors.NewInternalError(err)
+		}
+	}
+	reasons := policy.CheckDenylist(v.Denylist, bg, clusterRoles)
+	if len(reasons) > 0 && (admitted == nil || !admitted.Spec.AllowProtected) {
+		return apierrors.NewInvalid(breakglassGK, bg.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), internalerrors.NewDenylistError(reasons).Error()),
+		})
+	}
+	if !v.RestrictToNamespace {
+		return nil
+	}
+	if reasons := policy.CheckTargetNamespaces(bg, admitted); len(reasons) > 0 {
+		nsErr := internalerrors.NewNamespaceRestrictionError(bg.Namespace, reasons)
+		return apierrors.NewInvalid(breakglassGK, bg.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), nsErr.Error()),
+		})
+	}
+	return nil
+}
+
+// validateBreakglassSpec mirrors the checks the reconciler performs before dispatching a request.
+func validateBreakglassSpec(spec *accessv1alpha1.BreakglassSpec, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	hasPolicy, hasClusterRoles := len(spec.Policy) > 0, len(spec.ClusterRoles) > 0
+	switch {
+	case hasPolicy && hasClusterRoles:
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("clusterRoles"),
+			"policy and clusterRoles are mutually exclusive"))
+	case !hasPolicy && !hasClusterRoles:
+		allErrs = append(allErrs, field.Required(specPath.Child("policy"),
+			"exactly one of policy or clusterRoles must be set"))
+	}
+
+	if spec.ClusterRoleScope != nil {
+		scopePath := specPath.Child("clusterRoleScope")
+		if !hasClusterRoles {
+			allErrs = append(allErrs, field.Forbidden(scopePath, "clusterRoleScope requires clusterRoles"))
+		}
+		allErrs = append(allErrs, validateNamespaceScope(spec.ClusterRoleScope, scopePath)...)
+	}
+
+	return append(allErrs, validateScheduleSpec(&spec.Schedule, specPath.Child("schedule"))...)
+}
+
+// validateNamespaceScope checks that a scope selects namespaces by valid names or a parsable selector.
+func validateNamespaceScope(scope *accessv1alpha1.NamespaceScope, scopePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(scope.Namespaces) == 0 && scope.Selector == nil {
+		return append(allErrs, field.Required(scopePath, "at least one of namespaces or selector must be set"))
+	}
+	for i, ns := range scope.Namespaces {
+		for _, msg := range apivalidation.ValidateNamespaceName(ns, false) {
+			allErrs = append(allErrs, field.Invalid(scopePath.Child("namespaces").Index(i), ns, msg))
+		}
+	}
+	if scope.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(scope.Selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(scopePath.Child("selector"), scope.Selector, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateScheduleSpec validates the timing of a request.
+func validateScheduleSpec(schedule *accessv1alpha1.ScheduleSpec, schedulePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if *schedule == (accessv1alpha1.ScheduleSpec{}) {
+		return append(allErrs, field.Required(schedulePath, "schedule spec is required"))
+	}
+
+	if schedule.Duration.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(schedulePath.Child("duration"),
+			schedule.Duration.Duration.String(), "duration must not be negative"))
+	}
+
+	if schedule.Location != "" {
+		if _, err := time.LoadLocation(schedule.Location); err != nil {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("location"),
+				schedule.Location, fmt.Sprintf("invalid IANA time zone: %v", err)))
+		}
+	}
+
+	if schedule.Until != nil && !schedule.Start.IsZero() && !schedule.Until.After(schedule.Start.Time) {
+		allErrs = append(allErrs, field.Invalid(schedulePath.Child("until"),
+			schedule.Until.Format(time.RFC3339), "until must be after start"))
+	}
+
+	if schedule.Cron == "" {
+		if schedule.Start.IsZero() {
+			allErrs = append(allErrs, field.Required(schedulePath.Child("start"),
+				"start time must be set for one-time schedules"))
+		}
+		if schedule.MaxActivations != nil {
+			allErrs = append(allErrs, field.Forbidden(schedulePath.Child("maxActivations"),
+				"maxActivations only applies to cron schedules"))
+		}
+		return allErrs
+	}
+
+	parser := cronv3.NewParser(cronv3.Minute | cronv3.Hour | cronv3.Dom | cronv3.Month | cronv3.Dow)
+	if _, err := parser.Parse(schedule.Cron); err != nil {
+		allErrs = append(allErrs, field.Invalid(schedulePath.Child("cron"),
+			schedule.Cron, fmt.Sprintf("invalid cron schedule: %v", err)))
+	}
+	if schedule.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(schedulePath.Child("duration"),
+			schedule.Duration.Duration.String(), "duration must be greater than 0 for recurring schedules"))
+	}
+	if schedule.MaxActivations != nil && *schedule.MaxActivations < 1 {
+		allErrs = append(allErrs, field.Invalid(schedulePath.Child("maxActivations"),
+			*schedule.MaxActivations, "maxActivations must be at least 1"))
+	}
+	return allErrs
+}
+
+// validateRequesterUnchanged refuses any edit to the requester identity annotations.
+func validateRequesterUnchanged(oldBg, newBg *accessv1alpha1.Breakglass) field.ErrorList {
+	var allErrs field.ErrorList
+	annotationsPath := field.NewPath("metadata", "annotations")
+	for _, key := range accessv1alpha1.RequesterAnnotations {
+		oldValue, oldOK := oldBg.Annotations[key]
+		newValue, newOK := newBg.Annotations[key]
+		if oldOK != newOK || oldValue != newValue {
+			allErrs = append(allErrs, field.Forbidden(annotationsPath.Key(key), "requester identity is immutable"))
+		}
+	}
+	return allErrs
+}
+
+// validateRevocation checks that a revocation carries a revoker and is never changed once set.
+func validateRevocation(oldBg, newBg *accessv1alpha1.Breakglass) field.ErrorList {
+	var allErrs field.ErrorList
+	revocationPath := field.NewPath("spec", "revocation")
+	if oldBg.Spec.Revocation != nil {
+		if !equality.Semantic.DeepEqual(oldBg.Spec.Revocation, newBg.Spec.Revocation) {
+			allErrs = append(allErrs, field.Forbidden(revocationPath, "revocation is immutable once set"))
+		}
+		return allErrs
+	}
+	if newBg.Spec.Revocation == nil {
+		return allErrs
+	}
+	if newBg.Spec.Revocation.Reason == "" {
+		allErrs = append(allErrs, field.Required(revocationPath.Child("reason"), "a reason is required when revoking"))
+	}
+	if newBg.Spec.Revocation.RevokedBy == nil || newBg.Spec.Revocation.RevokedBy.Username == "" {
+		allErrs = append(allErrs, field.Required(revocationPath.Child("revokedBy"), "revoker identity was not stamped"))
+	}
+	return allErrs
+}
+
+// validateExtensions checks that extensions are only appended, only while access is active,
+// and that every new entry is well formed.
+func validateExtensions(oldBg, newBg *accessv1alpha1.Breakglass) field.ErrorList {
+	var allErrs field.ErrorList
+	extensionsPath := field.NewPath("spec", "extensions")
+
+	if len(newBg.Spec.Extensions) < len(oldBg.Spec.Extensions) {
+		return append(allErrs, field.Forbidden(extensionsPath, "extensions cannot be removed"))
+	}
+	for i := range oldBg.Spec.Extensions {
+		if !equality.Semantic.DeepEqual(oldBg.Spec.Extensions[i], newBg.Spec.Extensions[i]) {
+			allErrs = append(allErrs, field.Forbidden(extensionsPath.Index(i), "extensions are immutable once added"))
+		}
+	}
+	if len(newBg.Spec.Extensions) == len(oldBg.Spec.Extensions) || len(allErrs) > 0 {
+		return allErrs
+	}
+
+	if !isActive(oldBg) {
+		return append(allErrs, field.Forbidden(extensionsPath, "only active access can be extended"))
+	}
+	names := make(map[string]struct{}, len(newBg.Spec.Extensions))
+	for i, ext := range newBg.Spec.Extensions {
+		extPath := extensionsPath.Index(i)
+		if _, ok := names[ext.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(extPath.Child("name"), ext.Name))
+		}
+		names[ext.Name] = struct{}{}
+		if i < len(oldBg.Spec.Extensions) {
+			continue
+		}
+		if ext.Duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(extPath.Child("duration"),
+				ext.Duration.Duration.String(), "duration must be greater than 0"))
+		}
+		if ext.RequestedBy == nil || ext.RequestedBy.Username == "" {
+			allErrs = append(allErrs, field.Required(extPath.Child("requestedBy"), "requester identity was not stamped"))
+		}
+	}
+	return allErrs
+}
+
+// findExtension returns the extension named name, or nil.
+func findExtension(extensions []accessv1alpha1.ExtensionRequest, name string) *accessv1alpha1.ExtensionRequest {
+	for i := range extensions {
+		if extensions[i].Name == name {
+			return &extensions[i]
+		}
+	}
+	return nil
+}
+
+// isActive reports whether bg currently holds granted access.
+func isActive(bg *accessv1alpha1.Breakglass) bool {
+	return usecases.CurrentPhase(bg) == accessv1alpha1.PhaseActive
+}
+
+// isApproved reports whether bg reached the Approved phase or a later one. Requests that are being
+// validated, wait for approval or failed validation keep an editable spec, so an invalid request can be
+// fixed and validated again.
+func isApproved(bg *accessv1alpha1.Breakglass) bool {
+	switch usecases.CurrentPhase(bg) {
+	case "", accessv1alpha1.PhaseValidating, accessv1alpha1.PhasePending:
+		return bg.Status.ApprovedBy != ""
+	case accessv1alpha1.PhaseFailed:
+		return !usecases.FailedValidation(bg)
+	}
+	return true
+}
@@ -0,0 +1,675 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/config"
+	"github.com/cloud-nimbus/firedoor/internal/controller/mocks"
+)
+
+func validBreakglass() *accessv1alpha1.Breakglass {
+	return &accessv1alpha1.Breakglass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default"},
+		Spec: accessv1alpha1.BreakglassSpec{
+			Subjects:     []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+			ClusterRoles: []string{"view"},
+			Schedule: accessv1alpha1.ScheduleSpec{
+				Start:    metav1.NewTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+			Justification: "incident",
+		},
+	}
+}
+
+func TestBreakglassValidator_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(bg *accessv1alpha1.Breakglass)
+		wantErr string
+	}{
+		{
+			name:   "valid one-time request",
+			mutate: func(bg *accessv1alpha1.Breakglass) {},
+		},
+		{
+			name: "empty schedule",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Schedule = accessv1alpha1.ScheduleSpec{}
+			},
+			wantErr: "schedule spec is required",
+		},
+		{
+			name: "one-time without start",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Schedule.Start = metav1.Time{}
+			},
+			wantErr: "spec.schedule.start",
+		},
+		{
+			name: "bad cron",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Schedule.Cron = "not a cron"
+			},
+			wantErr: "spec.schedule.cron",
+		},
+		{
+			name: "cron without duration",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Schedule.Cron = "0 2 * * *"
+				bg.Spec.Schedule.Duration = metav1.Duration{}
+			},
+			wantErr: "spec.schedule.duration",
+		},
+		{
+			name: "bad location",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Schedule.Location = "Mars/Olympus"
+			},
+			wantErr: "spec.schedule.location",
+		},
+		{
+			name: "until before start",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				until := metav1.NewTime(bg.Spec.Schedule.Start.Add(-time.Hour))
+				bg.Spec.Schedule.Until = &until
+			},
+			wantErr: "spec.schedule.until",
+		},
+		{
+			name: "policy and clusterRoles",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.Policy = []accessv1alpha1.Policy{{
+					Namespace: "default",
+					Rules:     []rbacv1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}},
+				}}
+			},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name: "neither policy nor clusterRoles",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoles = nil
+			},
+			wantErr: "exactly one of policy or clusterRoles",
+		},
+		{
+			name: "clusterRoles scoped to namespaces",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoleScope = &accessv1alpha1.NamespaceScope{
+					Namespaces: []string{"payments", "payments-worker"},
+					Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				}
+			},
+		},
+		{
+			name: "empty clusterRoleScope",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoleScope = &accessv1alpha1.NamespaceScope{}
+			},
+			wantErr: "at least one of namespaces or selector",
+		},
+		{
+			name: "invalid scoped namespace",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoleScope = &accessv1alpha1.NamespaceScope{Namespaces: []string{"Not_Valid"}}
+			},
+			wantErr: "spec.clusterRoleScope.namespaces[0]",
+		},
+		{
+			name: "clusterRoleScope with policy",
+			mutate: func(bg *accessv1alpha1.Breakglass) {
+				bg.Spec.ClusterRoles = nil
+				bg.Spec.Policy = []accessv1alpha1.Policy{{
+					Namespace: "default",
+					Rules:     []rbacv1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}},
+				}}
+				bg.Spec.ClusterRoleScope = &accessv1alpha1.NamespaceScope{Namespaces: []string{"payments"}}
+			},
+			wantErr: "clusterRoleScope requires clusterRoles",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			op := mocks.NewMockBreakglassOperator(ctrl)
+			op.EXPECT().ValidateAccess(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			bg := validBreakglass()
+			tt.mutate(bg)
+
+			_, err := (&BreakglassCustomValidator{Operator: op}).ValidateCreate(context.Background(), bg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBreakglassValidator_OperatorRejects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	op := mocks.NewMockBreakglassOperator(ctrl)
+	op.EXPECT().ValidateAccess(gomock.Any(), gomock.Any()).Return(errors.New("not allowed"))
+
+	_, err := (&BreakglassCustomValidator{Operator: op}).ValidateCreate(context.Background(), validBreakglass())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "not allowed")
+	}
+}
+
+func TestBreakglassValidator_RequireTicket(t *testing.T) {
+	v := &BreakglassCustomValidator{RequireTicket: true}
+
+	_, err := v.ValidateCreate(context.Background(), validBreakglass())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "spec.ticketID")
+	}
+
+	bg := validBreakglass()
+	bg.Spec.TicketID = "INC-1"
+	_, err = v.ValidateCreate(context.Background(), bg)
+	assert.NoError(t, err)
+}
+
+func TestBreakglassValidator_ValidateUpdate(t *testing.T) {
+	v := &BreakglassCustomValidator{}
+
+	pending := validBreakglass()
+	pending.Status.Conditions = []metav1.Condition{{Type: string(accessv1alpha1.ConditionPending)}}
+	changed := pending.DeepCopy()
+	changed.Spec.Justification = "changed"
+	_, err := v.ValidateUpdate(context.Background(), pending, changed)
+	assert.NoError(t, err, "spec changes are allowed while pending")
+
+	approved := pending.DeepCopy()
+	approved.Status.ApprovedBy = "bob"
+	changed = approved.DeepCopy()
+	changed.Spec.Justification = "changed"
+	_, err = v.ValidateUpdate(context.Background(), approved, changed)
+	assert.Error(t, err, "spec changes are rejected after approval")
+
+	finalizer := approved.DeepCopy()
+	finalizer.Finalizers = []string{"example"}
+	_, err = v.ValidateUpdate(context.Background(), approved, finalizer)
+	assert.NoError(t, err, "metadata-only updates are allowed after approval")
+
+	validating := validBreakglass()
+	validating.Status.Phase = accessv1alpha1.PhaseValidating
+	changed = validating.DeepCopy()
+	changed.Spec.Justification = "changed"
+	_, err = v.ValidateUpdate(context.Background(), validating, changed)
+	assert.NoError(t, err, "spec changes are allowed while validating")
+}
+
+func TestBreakglassValidator_ApprovalsLockSpec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	newApproval := func(name string, uid types.UID) *accessv1alpha1.BreakglassApproval {
+		return &accessv1alpha1.BreakglassApproval{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: accessv1alpha1.BreakglassApprovalSpec{
+				BreakglassRef: "bg",
+				BreakglassUID: uid,
+				Decision:      accessv1alpha1.DecisionApprove,
+				Approver:      &accessv1alpha1.UserIdentity{Username: "bob"},
+			},
+		}
+	}
+
+	pending := validBreakglass()
+	pending.UID = "bg-uid"
+	pending.Status.Conditions = []metav1.Condition{{Type: string(accessv1alpha1.ConditionPending)}}
+	changed := pending.DeepCopy()
+	changed.Spec.ClusterRoles = []string{"edit"}
+	annotated := pending.DeepCopy()
+	annotated.Annotations = map[string]string{"example": "true"}
+
+	tests := []struct {
+		name      string
+		approvals []client.Object
+		updated   *accessv1alpha1.Breakglass
+		wantErr   bool
+	}{
+		{name: "no approvals yet", updated: changed},
+		{
+			name:      "approval of an earlier request with the same name",
+			approvals: []client.Object{newApproval("stale", "deleted-uid")},
+			updated:   changed,
+		},
+		{
+			name:      "first approval locks the spec",
+			approvals: []client.Object{newApproval("first", "bg-uid")},
+			updated:   changed,
+			wantErr:   true,
+		},
+		{
+			name:      "metadata can still change",
+			approvals: []client.Object{newApproval("first", "bg-uid")},
+			updated:   annotated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &BreakglassCustomValidator{
+				Approvals: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.approvals...).Build(),
+			}
+			_, err := v.ValidateUpdate(context.Background(), pending, tt.updated)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), `spec cannot be changed once approvals have been recorded`)
+			}
+		})
+	}
+}
+
+func TestBreakglassValidator_FixInvalidRequest(t *testing.T) {
+	v := &BreakglassCustomValidator{}
+
+	invalid := validBreakglass()
+	invalid.Status.Phase = accessv1alpha1.PhaseFailed
+	invalid.Status.Conditions = []metav1.Condition{{
+		Type:   string(accessv1alpha1.ConditionFailed),
+		Status: metav1.ConditionTrue,
+		Reason: string(accessv1alpha1.ReasonInvalidRequest),
+	}}
+	fixed := invalid.DeepCopy()
+	fixed.Spec.ClusterRoles = []string{"edit"}
+	_, err := v.ValidateUpdate(context.Background(), invalid, fixed)
+	assert.NoError(t, err, "a request that failed validation can have its spec fixed")
+
+	failed := invalid.DeepCopy()
+	failed.Status.Conditions[0].Reason = string(accessv1alpha1.ReasonRBACCreationFailed)
+	fixed = failed.DeepCopy()
+	fixed.Spec.ClusterRoles = []string{"edit"}
+	_, err = v.ValidateUpdate(context.Background(), failed, fixed)
+	assert.Error(t, err, "other failures keep the spec immutable")
+}
+
+func TestBreakglassDefaulter(t *testing.T) {
+	d := &BreakglassCustomDefaulter{Defaults: config.BreakglassConfig{
+		DefaultDuration:  time.Hour,
+		DefaultLocation:  "Europe/London",
+		ApprovalRequired: true,
+	}}
+
+	bg := validBreakglass()
+	bg.Spec.Schedule.Duration = metav1.Duration{}
+	bg.Annotations = map[string]string{accessv1alpha1.RequestedByAnnotation: "spoofed"}
+
+	require.NoError(t, d.Default(admissionContext(admissionv1.Create, "alice", "sre", "dev"), bg))
+	assert.Equal(t, "alice", bg.Annotations[accessv1alpha1.RequestedByAnnotation])
+	assert.Equal(t, "sre,dev", bg.Annotations[accessv1alpha1.RequestedByGroupsAnnotation])
+	assert.Equal(t, time.Hour, bg.Spec.Schedule.Duration.Duration)
+	assert.Equal(t, "Europe/London", bg.Spec.Schedule.Location)
+	require.NotNil(t, bg.Spec.Approval)
+	assert.True(t, bg.Spec.Approval.Required)
+
+	explicit := validBreakglass()
+	explicit.Spec.Approval = &accessv1alpha1.ApprovalSpec{Required: false}
+	require.NoError(t, d.Default(admissionContext(admissionv1.Create, "alice"), explicit))
+	assert.Equal(t, time.Hour, explicit.Spec.Schedule.Duration.Duration)
+	assert.False(t, explicit.Spec.Approval.Required, "explicit values are kept")
+}
+
+func TestBreakglassValidator_RequesterImmutable(t *testing.T) {
+	v := &BreakglassCustomValidator{}
+
+	oldBg := validBreakglass()
+	oldBg.Annotations = map[string]string{accessv1alpha1.RequestedByAnnotation: "alice"}
+	newBg := oldBg.DeepCopy()
+	newBg.Annotations[accessv1alpha1.RequestedByAnnotation] = "mallory"
+
+	_, err := v.ValidateUpdate(context.Background(), oldBg, newBg)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "requester identity is immutable")
+	}
+
+	newBg = oldBg.DeepCopy()
+	delete(newBg.Annotations, accessv1alpha1.RequestedByAnnotation)
+	_, err = v.ValidateUpdate(context.Background(), oldBg, newBg)
+	assert.Error(t, err)
+}
+
+func TestBreakglassDefaulter_StampsRevoker(t *testing.T) {
+	d := &BreakglassCustomDefaulter{}
+	oldBg := validBreakglass()
+	raw, err := json.Marshal(oldBg)
+	require.NoError(t, err)
+
+	newBg := oldBg.DeepCopy()
+	newBg.Spec.Revocation = &accessv1alpha1.RevocationSpec{
+		Reason:    "incident closed",
+		RevokedBy: &accessv1alpha1.UserIdentity{Username: "spoofed"},
+	}
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Update,
+			UserInfo:  authenticationv1.UserInfo{Username: "bob"},
+			OldObject: runtime.RawExtension{Raw: raw},
+		},
+	})
+
+	require.NoError(t, d.Default(ctx, newBg))
+	require.NotNil(t, newBg.Spec.Revocation.RevokedBy)
+	assert.Equal(t, "bob", newBg.Spec.Revocation.RevokedBy.Username)
+}
+
+func TestBreakglassValidator_Revocation(t *testing.T) {
+	v := &BreakglassCustomValidator{}
+	approved := validBreakglass()
+	approved.Status.ApprovedBy = "bob"
+
+	revoked := approved.DeepCopy()
+	revoked.Spec.Revocation = &accessv1alpha1.RevocationSpec{
+		Reason:    "incident closed",
+		RevokedBy: &accessv1alpha1.UserIdentity{Username: "bob"},
+	}
+	_, err := v.ValidateUpdate(context.Background(), approved, revoked)
+	assert.NoError(t, err, "revocation may be added after approval")
+
+	changed := revoked.DeepCopy()
+	changed.Spec.Revocation.Reason = "other"
+	_, err = v.ValidateUpdate(context.Background(), revoked, changed)
+	assert.Error(t, err, "revocation is immutable")
+
+	removed := revoked.DeepCopy()
+	removed.Spec.Revocation = nil
+	_, err = v.ValidateUpdate(context.Background(), revoked, removed)
+	assert.Error(t, err, "revocation cannot be removed")
+
+	unstamped := approved.DeepCopy()
+	unstamped.Spec.Revocation = &accessv1alpha1.RevocationSpec{Reason: "incident closed"}
+	_, err = v.ValidateUpdate(context.Background(), approved, unstamped)
+	assert.Error(t, err, "revoker must be stamped")
+
+	_, err = v.ValidateCreate(context.Background(), revoked)
+	assert.Error(t, err, "revocation cannot be set on create")
+}
+
+func TestBreakglassDefaulter_StampsExtensionRequester(t *testing.T) {
+	d := &BreakglassCustomDefaulter{}
+	oldBg := validBreakglass()
+	oldBg.Spec.Extensions = []accessv1alpha1.ExtensionRequest{{
+		Name:        "first",
+		Duration:    metav1.Duration{Duration: time.Hour},
+		Reason:      "still debugging",
+		RequestedBy: &accessv1alpha1.UserIdentity{Username: "alice"},
+	}}
+	raw, err := json.Marshal(oldBg)
+	require.NoError(t, err)
+
+	newBg := oldBg.DeepCopy()
+	newBg.Spec.Extensions = append(newBg.Spec.Extensions, accessv1alpha1.ExtensionRequest{
+		Name:        "second",
+		Duration:    metav1.Duration{Duration: time.Hour},
+		Reason:      "rollback pending",
+		RequestedBy: &accessv1alpha1.UserIdentity{Username: "spoofed"},
+	})
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Update,
+			UserInfo:  authenticationv1.UserInfo{Username: "bob"},
+			OldObject: runtime.RawExtension{Raw: raw},
+		},
+	})
+
+	require.NoError(t, d.Default(ctx, newBg))
+	assert.Equal(t, "alice", newBg.Spec.Extensions[0].RequestedBy.Username, "existing entries are left alone")
+	assert.Equal(t, "bob", newBg.Spec.Extensions[1].RequestedBy.Username)
+}
+
+func TestBreakglassValidator_Extensions(t *testing.T) {
+	v := &BreakglassCustomValidator{}
+	active := validBreakglass()
+	active.Status.ApprovedBy = "bob"
+	active.Status.Conditions = []metav1.Condition{{Type: string(accessv1alpha1.ConditionActive)}}
+
+	extension := accessv1alpha1.ExtensionRequest{
+		Name:        "more-time",
+		Duration:    metav1.Duration{Duration: time.Hour},
+		Reason:      "still debugging",
+		RequestedBy: &accessv1alpha1.UserIdentity{Username: "alice"},
+	}
+	extended := active.DeepCopy()
+	extended.Spec.Extensions = []accessv1alpha1.ExtensionRequest{extension}
+	_, err := v.ValidateUpdate(context.Background(), active, extended)
+	assert.NoError(t, err, "extension may be appended while active")
+
+	changed := extended.DeepCopy()
+	changed.Spec.Extensions[0].Duration = metav1.Duration{Duration: 2 * time.Hour}
+	_, err = v.ValidateUpdate(context.Background(), extended, changed)
+	assert.Error(t, err, "extensions are immutable")
+
+	removed := extended.DeepCopy()
+	removed.Spec.Extensions = nil
+	_, err = v.ValidateUpdate(context.Background(), extended, removed)
+	assert.Error(t, err, "extensions cannot be removed")
+
+	duplicate := extended.DeepCopy()
+	duplicate.Spec.Extensions = append(duplicate.Spec.Extensions, extension)
+	_, err = v.ValidateUpdate(context.Background(), extended, duplicate)
+	assert.Error(t, err, "extension names must be unique")
+
+	invalid := active.DeepCopy()
+	invalid.Spec.Extensions = []accessv1alpha1.ExtensionRequest{{Name: "zero", Reason: "r"}}
+	_, err = v.ValidateUpdate(context.Background(), active, invalid)
+	assert.Error(t, err, "duration and requester are required")
+
+	pending := validBreakglass()
+	pending.Status.Conditions = []metav1.Condition{{Type: string(accessv1alpha1.ConditionPending)}}
+	pendingExtended := pending.DeepCopy()
+	pendingExtended.Spec.Extensions = []accessv1alpha1.ExtensionRequest{extension}
+	_, err = v.ValidateUpdate(context.Background(), pending, pendingExtended)
+	assert.Error(t, err, "only active access can be extended")
+
+	_, err = v.ValidateCreate(context.Background(), extended)
+	assert.Error(t, err, "extensions cannot be set on create")
+}
+
+func TestBreakglassValidator_Policy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	guardrail := &accessv1alpha1.BreakglassPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "short"},
+		Spec: accessv1alpha1.BreakglassPolicySpec{
+			AllowedClusterRoles: []string{"view"},
+			AllowClusterScope:   true,
+			MaxDuration:         &metav1.Duration{Duration: 90 * time.Minute},
+		},
+	}
+	v := &BreakglassCustomValidator{
+		Policies: fake.NewClientBuilder().WithScheme(scheme).WithObjects(guardrail).Build(),
+	}
+
+	_, err := v.ValidateCreate(context.Background(), validBreakglass())
+	assert.NoError(t, err)
+
+	tooLong := validBreakglass()
+	tooLong.Spec.Schedule.Duration = metav1.Duration{Duration: 2 * time.Hour}
+	_, err = v.ValidateCreate(context.Background(), tooLong)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "exceeds the maximum")
+	}
+
+	active := validBreakglass()
+	active.Status.ApprovedBy = "bob"
+	active.Status.Conditions = []metav1.Condition{{Type: string(accessv1alpha1.ConditionActive)}}
+	extended := active.DeepCopy()
+	extended.Spec.Extensions = []accessv1alpha1.ExtensionRequest{{
+		Name:        "more-time",
+		Duration:    metav1.Duration{Duration: time.Hour},
+		Reason:      "still debugging",
+		RequestedBy: &accessv1alpha1.UserIdentity{Username: "alice"},
+	}}
+	_, err = v.ValidateUpdate(context.Background(), active, extended)
+	assert.Error(t, err, "extensions count towards the maximum duration")
+}
+
+func TestBreakglassValidator_GrantChanges(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	newPolicy := func(allowChanges bool) *accessv1alpha1.BreakglassPolicy {
+		return &accessv1alpha1.BreakglassPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "ops"},
+			Spec: accessv1alpha1.BreakglassPolicySpec{
+				AllowedClusterRoles: []string{"view", "edit"},
+				AllowClusterScope:   true,
+				AllowGrantChanges:   allowChanges,
+			},
+		}
+	}
+
+	active := validBreakglass()
+	active.Status.ApprovedBy = "bob"
+	active.Status.Conditions = []metav1.Condition{{Type: string(accessv1alpha1.ConditionActive)}}
+	regranted := active.DeepCopy()
+	regranted.Spec.Subjects = append(regranted.Spec.Subjects, rbacv1.Subject{Kind: rbacv1.UserKind, Name: "carol"})
+	regranted.Spec.ClusterRoles = []string{"edit"}
+	rescheduled := regranted.DeepCopy()
+	rescheduled.Spec.Justification = "changed"
+	forbidden := regranted.DeepCopy()
+	forbidden.Spec.ClusterRoles = []string{"cluster-admin"}
+
+	tests := []struct {
+		name         string
+		allowChanges bool
+		updated      *accessv1alpha1.Breakglass
+		wantErr      string
+	}{
+		{name: "policy allows grant changes", allowChanges: true, updated: regranted},
+		{
+			name:    "policy keeps grants immutable",
+			updated: regranted,
+			wantErr: "unless the admitting BreakglassPolicy sets allowGrantChanges",
+		},
+		{
+			name:         "other fields stay immutable",
+			allowChanges: true,
+			updated:      rescheduled,
+			wantErr:      "spec cannot be changed after the request has been approved",
+		},
+		{
+			name:         "changed grants must be admitted",
+			allowChanges: true,
+			updated:      forbidden,
+			wantErr:      "not admitted by any BreakglassPolicy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &BreakglassCustomValidator{
+				Policies: fake.NewClientBuilder().WithScheme(scheme).WithObjects(newPolicy(tt.allowChanges)).Build(),
+			}
+			_, err := v.ValidateUpdate(context.Background(), active, tt.updated)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBreakglassValidator_Denylist(t *testing.T) {
+	v := &BreakglassCustomValidator{Denylist: config.NewDefaultConfig().Denylist}
+
+	_, err := v.ValidateCreate(context.Background(), validBreakglass())
+	assert.NoError(t, err)
+
+	admin := validBreakglass()
+	admin.Spec.ClusterRoles = []string{"cluster-admin"}
+	_, err = v.ValidateCreate(context.Background(), admin)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `clusterRole "cluster-admin" is protected`)
+	}
+
+	masters := validBreakglass()
+	masters.Spec.Subjects = []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:masters"}}
+	_, err = v.ValidateCreate(context.Background(), masters)
+	assert.Error(t, err)
+
+	// A policy with allowProtected lifts the denylist for the requests it admits
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	protected := &accessv1alpha1.BreakglassPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "protected"},
+		Spec: accessv1alpha1.BreakglassPolicySpec{
+			AllowedClusterRoles: []string{"cluster-admin"},
+			AllowClusterScope:   true,
+			AllowProtected:      true,
+		},
+	}
+	v.Policies = fake.NewClientBuilder().WithScheme(scheme).WithObjects(protected).Build()
+	_, err = v.ValidateCreate(context.Background(), admin)
+	assert.NoError(t, err)
+}
+
+func TestBreakglassValidator_DenylistWildcardClusterRole(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+	godMode := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "god-mode"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+	}
+	v := &BreakglassCustomValidator{
+		Denylist:     config.NewDefaultConfig().Denylist,
+		ClusterRoles: fake.NewClientBuilder().WithScheme(scheme).WithObjects(godMode).Build(),
+	}
+
+	bg := validBreakglass()
+	bg.Spec.ClusterRoles = []string{"god-mode"}
+	_, err := v.ValidateCreate(context.Background(), bg)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `clusterRole "god-mode".rules[0] grants all verbs on all resources`)
+	}
+
+	v.Denylist.AllowWildcardRules = true
+	_, err = v.ValidateCreate(context.Background(), bg)
+	assert.NoError(t, err)
+}
+
+func TestBreakglassValidator_RestrictToNamespace(t *testing.T) {
+	v := &BreakglassCustomValidator{RestrictToNamespace: true}
+
+	scoped := validBreakglass()
+	scoped.Spec.ClusterRoleScope = &accessv1alpha1.NamespaceScope{Namespaces: []string{"default"}}
+	_, err := v.ValidateCreate(context.Background(), scoped)
+	assert.NoError(t, err)
+
+	_, err = v.ValidateCreate(context.Background(), validBreakglass())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `clusterRoles must be scoped to namespace "default"`)
+	}
+
+	cluster := &accessv1alpha1.ClusterBreakglass{ObjectMeta: metav1.ObjectMeta{Name: "bg"}, Spec: validBreakglass().Spec}
+	_, err = (&ClusterBreakglassCustomValidator{BreakglassCustomValidator: *v}).ValidateCreate(context.Background(), cluster)
+	assert.NoError(t, err, "ClusterBreakglass requests are not restricted")
+}
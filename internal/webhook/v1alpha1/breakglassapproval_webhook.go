@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Cloud-Nimbus Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+var breakglassapprovallog = logf.Log.WithName("breakglassapproval-resource")
+
+var breakglassApprovalGK = accessv1alpha1.GroupVersion.WithKind("BreakglassApproval").GroupKind()
+
+// SetupBreakglassApprovalWebhookWithManager registers the webhook for BreakglassApproval in the manager.
+func SetupBreakglassApprovalWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&accessv1alpha1.BreakglassApproval{}).
+		WithDefaulter(&BreakglassApprovalCustomDefaulter{Requests: mgr.GetAPIReader()}).
+		WithValidator(&BreakglassApprovalCustomValidator{}).
+		Complete()
+}
+
+//nolint:lll
+// +kubebuilder:webhook:path=/mutate-access-cloudnimbus-io-v1alpha1-breakglassapproval,mutating=true,failurePolicy=fail,sideEffects=None,groups=access.cloudnimbus.io,resources=breakglassapprovals,verbs=create;update,versions=v1alpha1,name=mbreakglassapproval-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BreakglassApprovalCustomDefaulter stamps the authenticated requester as the approver and the UID of
+// the request the decision applies to.
+type BreakglassApprovalCustomDefaulter struct {
+	// Requests reads the referenced Breakglass or ClusterBreakglass. It should bypass the cache so a
+	// request created moments before its approval is found.
+	Requests client.Reader
+}
+
+var _ webhook.CustomDefaulter = &BreakglassApprovalCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter.
+// The approver is always taken from the admission request so clients cannot approve on behalf of someone else.
+func (d *BreakglassApprovalCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	approval, ok := obj.(*accessv1alpha1.BreakglassApproval)
+	if !ok {
+		return fmt.Errorf("expected a BreakglassApproval object but got %T", obj)
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("read admission request: %w", err)
+	}
+	// Spec is immutable after creation; the validator rejects any change on update.
+	if req.Operation != admissionv1.Create {
+		return nil
+	}
+
+	breakglassapprovallog.Info("stamping approver", "name", approval.GetName(), "username", req.UserInfo.Username)
+	approval.Spec.Approver = userIdentity(req)
+
+	uid, err := d.breakglassUID(ctx, approval)
+	if err != nil {
+		return fmt.Errorf("read referenced request %q: %w", approval.Spec.BreakglassRef, err)
+	}
+	approval.Spec.BreakglassUID = uid
+	return nil
+}
+
+// breakglassUID returns the UID of the request approval refers to, or an empty UID if it does not exist.
+func (d *BreakglassApprovalCustomDefaulter) breakglassUID(
+	ctx context.Context,
+	approval *accessv1alpha1.BreakglassApproval,
+) (types.UID, error) {
+	var obj client.Object = &accessv1alpha1.Breakglass{}
+	key := client.ObjectKey{Namespace: approval.Namespace, Name: approval.Spec.BreakglassRef}
+	if approval.Spec.BreakglassKind == accessv1alpha1.RefKindClusterBreakglass {
+		obj, key.Namespace = &accessv1alpha1.ClusterBreakglass{}, ""
+	}
+	if err := d.Requests.Get(ctx, key, obj); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	return obj.GetUID(), nil
+}
+
+//nolint:lll
+// +kubebuilder:webhook:path=/validate-access-cloudnimbus-io-v1alpha1-breakglassapproval,mutating=false,failurePolicy=fail,sideEffects=None,groups=access.cloudnimbus.io,resources=breakglassapprovals,verbs=create;update,versions=v1alpha1,name=vbreakglassapproval-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BreakglassApprovalCustomValidator validates BreakglassApproval decisions.
+type BreakglassApprovalCustomValidator struct{}
+
+var _ webhook.CustomValidator = &BreakglassApprovalCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *BreakglassApprovalCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	approval, ok := obj.(*accessv1alpha1.BreakglassApproval)
+	if !ok {
+		return nil, fmt.Errorf("expected a BreakglassApproval object but got %T", obj)
+	}
+
+	specPath := field.NewPath("spec")
+	var allErrs field.ErrorList
+	if approval.Spec.Approver == nil || approval.Spec.Approver.Username == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("approver"), "approver identity was not stamped"))
+	}
+	if approval.Spec.BreakglassUID == "" {
+		allErrs = append(allErrs, field.NotFound(specPath.Child("breakglassRef"), approval.Spec.BreakglassRef))
+	}
+	if approval.Spec.Decision == accessv1alpha1.DecisionDeny && approval.Spec.Reason == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("reason"), "a reason is required when denying"))
+	}
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(breakglassApprovalGK, approval.Name, allErrs)
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+// Decisions are part of the audit trail and cannot be changed once recorded.
+func (v *BreakglassApprovalCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldApproval, ok := oldObj.(*accessv1alpha1.BreakglassApproval)
+	if !ok {
+		return nil, fmt.Errorf("expected a BreakglassApproval object but got %T", oldObj)
+	}
+	newApproval, ok := newObj.(*accessv1alpha1.BreakglassApproval)
+	if !ok {
+		return nil, fmt.Errorf("expected a BreakglassApproval object but got %T", newObj)
+	}
+
+	if !equality.Semantic.DeepEqual(oldApproval.Spec, newApproval.Spec) {
+		return nil, apierrors.NewInvalid(breakglassApprovalGK, newApproval.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), "approval decisions are immutable"),
+		})
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *BreakglassApprovalCustomValidator) ValidateDelete(
+	_ context.Context,
+	_ runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
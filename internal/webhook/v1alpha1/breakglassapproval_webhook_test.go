@@ -0,0 +1,146 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+)
+
+func admissionContext(op admissionv1.Operation, username string, groups ...string) context.Context {
+	return admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			UserInfo:  authenticationv1.UserInfo{Username: username, Groups: groups},
+		},
+	})
+}
+
+func TestBreakglassApprovalDefaulter_StampsApprover(t *testing.T) {
+	approval := &accessv1alpha1.BreakglassApproval{
+		Spec: accessv1alpha1.BreakglassApprovalSpec{
+			BreakglassRef: "bg",
+			Decision:      accessv1alpha1.DecisionApprove,
+			Approver:      &accessv1alpha1.UserIdentity{Username: "spoofed"},
+		},
+	}
+
+	d := &BreakglassApprovalCustomDefaulter{Requests: newRequestReader(t)}
+	require.NoError(t, d.Default(admissionContext(admissionv1.Create, "alice", "sre"), approval))
+	require.NotNil(t, approval.Spec.Approver)
+	assert.Equal(t, "alice", approval.Spec.Approver.Username)
+	assert.Equal(t, []string{"sre"}, approval.Spec.Approver.Groups)
+}
+
+func newRequestReader(t *testing.T) client.Reader {
+	scheme := runtime.NewScheme()
+	require.NoError(t, accessv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&accessv1alpha1.Breakglass{ObjectMeta: metav1.ObjectMeta{Name: "bg", Namespace: "default", UID: "bg-uid"}},
+		&accessv1alpha1.ClusterBreakglass{ObjectMeta: metav1.ObjectMeta{Name: "bg", UID: "cluster-uid"}},
+	).Build()
+}
+
+func TestBreakglassApprovalDefaulter_StampsBreakglassUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     string
+		kind    accessv1alpha1.BreakglassRefKind
+		wantUID types.UID
+	}{
+		{name: "breakglass", ref: "bg", wantUID: "bg-uid"},
+		{name: "cluster breakglass", ref: "bg", kind: accessv1alpha1.RefKindClusterBreakglass, wantUID: "cluster-uid"},
+		{name: "missing request", ref: "gone"},
+	}
+
+	d := &BreakglassApprovalCustomDefaulter{Requests: newRequestReader(t)}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approval := &accessv1alpha1.BreakglassApproval{
+				ObjectMeta: metav1.ObjectMeta{Name: "approval", Namespace: "default"},
+				Spec: accessv1alpha1.BreakglassApprovalSpec{
+					BreakglassRef:  tt.ref,
+					BreakglassKind: tt.kind,
+					BreakglassUID:  "spoofed",
+					Decision:       accessv1alpha1.DecisionApprove,
+				},
+			}
+			require.NoError(t, d.Default(admissionContext(admissionv1.Create, "alice"), approval))
+			assert.Equal(t, tt.wantUID, approval.Spec.BreakglassUID)
+
+			_, err := (&BreakglassApprovalCustomValidator{}).ValidateCreate(context.Background(), approval)
+			assert.Equal(t, tt.wantUID == "", err != nil, "approvals for missing requests are rejected: %v", err)
+		})
+	}
+}
+
+func TestBreakglassApprovalValidator(t *testing.T) {
+	v := &BreakglassApprovalCustomValidator{}
+	approver := &accessv1alpha1.UserIdentity{Username: "alice"}
+
+	tests := []struct {
+		name    string
+		spec    accessv1alpha1.BreakglassApprovalSpec
+		wantErr bool
+	}{
+		{
+			name: "approve is valid",
+			spec: accessv1alpha1.BreakglassApprovalSpec{
+				BreakglassRef: "bg", BreakglassUID: "bg-uid", Decision: accessv1alpha1.DecisionApprove, Approver: approver,
+			},
+		},
+		{
+			name: "deny without reason",
+			spec: accessv1alpha1.BreakglassApprovalSpec{
+				BreakglassRef: "bg", BreakglassUID: "bg-uid", Decision: accessv1alpha1.DecisionDeny, Approver: approver,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing approver",
+			spec: accessv1alpha1.BreakglassApprovalSpec{
+				BreakglassRef: "bg", BreakglassUID: "bg-uid", Decision: accessv1alpha1.DecisionApprove,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.ValidateCreate(context.Background(), &accessv1alpha1.BreakglassApproval{Spec: tt.spec})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBreakglassApprovalValidator_Immutable(t *testing.T) {
+	v := &BreakglassApprovalCustomValidator{}
+	oldObj := &accessv1alpha1.BreakglassApproval{Spec: accessv1alpha1.BreakglassApprovalSpec{
+		BreakglassRef: "bg",
+		Decision:      accessv1alpha1.DecisionApprove,
+		Approver:      &accessv1alpha1.UserIdentity{Username: "alice"},
+	}}
+	newObj := oldObj.DeepCopy()
+	newObj.Spec.Decision = accessv1alpha1.DecisionDeny
+
+	_, err := v.ValidateUpdate(context.Background(), oldObj, newObj)
+	assert.Error(t, err)
+
+	_, err = v.ValidateUpdate(context.Background(), oldObj, oldObj.DeepCopy())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Cloud-Nimbus Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	accessv1alpha1 "github.com/cloud-nimbus/firedoor/api/v1alpha1"
+	"github.com/cloud-nimbus/firedoor/internal/config"
+	"github.com/cloud-nimbus/firedoor/internal/controller"
+)
+
+// SetupClusterBreakglassWebhookWithManager registers the webhook for ClusterBreakglass in the manager.
+func SetupClusterBreakglassWebhookWithManager(
+	mgr ctrl.Manager,
+	cfg *config.Config,
+	operator controller.BreakglassOperator,
+) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&accessv1alpha1.ClusterBreakglass{}).
+		WithDefaulter(&ClusterBreakglassCustomDefaulter{
+			BreakglassCustomDefaulter: BreakglassCustomDefaulter{Defaults: cfg.Breakglass},
+		}).
+		WithValidator(&ClusterBreakglassCustomValidator{
+			BreakglassCustomValidator: BreakglassCustomValidator{
+				Operator:                 operator,
+				Policies:                 mgr.GetClient(),
+				Denylist:                 cfg.Denylist,
+				ClusterRoles:             mgr.GetAPIReader(),
+				RequireTicket:            cfg.Tickets.Provider != "" && cfg.Tickets.Required,
+				Approvals:                mgr.GetAPIReader(),
+				ClusterApprovalNamespace: cfg.Controller.ClusterApprovalNamespace,
+			},
+		}).
+		Complete()
+}
+
+//nolint:lll
+// +kubebuilder:webhook:path=/mutate-access-cloudnimbus-io-v1alpha1-clusterbreakglass,mutating=true,failurePolicy=fail,sideEffects=None,groups=access.cloudnimbus.io,resources=clusterbreakglasses,verbs=create;update,versions=v1alpha1,name=mclusterbreakglass-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterBreakglassCustomDefaulter applies the Breakglass defaulting to ClusterBreakglass requests.
+type ClusterBreakglassCustomDefaulter struct {
+	BreakglassCustomDefaulter
+}
+
+var _ webhook.CustomDefaulter = &ClusterBreakglassCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter.
+func (d *ClusterBreakglassCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	cbg, ok := obj.(*accessv1alpha1.ClusterBreakglass)
+	if !ok {
+		return fmt.Errorf("expected a ClusterBreakglass object but got %T", obj)
+	}
+	bg := cbg.ToBreakglass()
+	if err := d.BreakglassCustomDefaulter.Default(ctx, bg); err != nil {
+		return err
+	}
+	cbg.FromBreakglass(bg)
+	return nil
+}
+
+//nolint:lll
+// +kubebuilder:webhook:path=/validate-access-cloudnimbus-io-v1alpha1-clusterbreakglass,mutating=false,failurePolicy=fail,sideEffects=None,groups=access.cloudnimbus.io,resources=clusterbreakglasses,verbs=create;update,versions=v1alpha1,name=vclusterbreakglass-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterBreakglassCustomValidator applies the Breakglass validation to ClusterBreakglass requests.
+type ClusterBreakglassCustomValidator struct {
+	BreakglassCustomValidator
+}
+
+var _ webhook.CustomValidator = &ClusterBreakglassCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *ClusterBreakglassCustomValidator) ValidateCreate(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	bg, err := asBreakglass(obj)
+	if err != nil {
+		return nil, err
+	}
+	return v.BreakglassCustomValidator.ValidateCreate(ctx, bg)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *ClusterBreakglassCustomValidator) ValidateUpdate(
+	ctx context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldBg, err := asBreakglass(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newBg, err := asBreakglass(newObj)
+	if err != nil {
+		return nil, err
+	}
+	return v.BreakglassCustomValidator.ValidateUpdate(ctx, oldBg, newBg)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *ClusterBreakglassCustomValidator) ValidateDelete(
+	_ context.Context,
+	_ runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// asBreakglass converts a ClusterBreakglass into the Breakglass form the shared checks work on.
+func asBreakglass(obj runtime.Object) (*accessv1alpha1.Breakglass, error) {
+	cbg, ok := obj.(*accessv1alpha1.ClusterBreakglass)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterBreakglass object but got %T", obj)
+	}
+	return cbg.ToBreakglass(), nil
+}
@@ -32,10 +32,48 @@ for b in $CRD_FILES; do
     # Extract filename
     filename=$(basename "$b")
     
+    # CRDs serving more than one version convert through the operator's webhook when it is enabled.
+    # Without the webhook there is nothing to convert, so only the storage version is served.
+    if [ "$(grep -c '^    served: true' "$b")" -gt 1 ]; then
+        body=$(awk '
+            held != "" {
+                if ($0 == "    storage: false") {
+                    print "    served: {{ .Values.webhook.enabled }}"
+                } else {
+                    print held
+                }
+                held = ""
+            }
+            /^    served: true$/ { held = $0; next }
+            { print }
+            /^    controller-gen.kubebuilder.io\/version:/ {
+                print "    {{- if and .Values.webhook.enabled .Values.webhook.certManager.enabled }}"
+                print "    cert-manager.io/inject-ca-from: {{ .Release.Namespace }}/{{ include \"firedoor.fullname\" . }}-serving-cert"
+                print "    {{- end }}"
+            }
+            /^spec:$/ {
+                print "  {{- if .Values.webhook.enabled }}"
+                print "  conversion:"
+                print "    strategy: Webhook"
+                print "    webhook:"
+                print "      clientConfig:"
+                print "        service:"
+                print "          name: {{ include \"firedoor.fullname\" . }}-webhook"
+                print "          namespace: {{ .Release.Namespace }}"
+                print "          path: /convert"
+                print "      conversionReviewVersions:"
+                print "      - v1"
+                print "  {{- end }}"
+            }
+        ' "$b")
+    else
+        body=$(cat "$b")
+    fi
+
     # Create wrapped version with Helm templating (no kubebuilder reference, no extra indentation)
     {
         echo "{{- if .Values.crds.install }}"
-        cat "$b"
+        echo "$body"
         echo "{{- end }}"
     } > "$TEMPLATE_CRD_DIR/$filename"
     